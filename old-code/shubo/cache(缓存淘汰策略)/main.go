@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 同一份访问轨迹分别交给 LRU、LFU、FIFO、Clock，对比命中率
+//
+//	go run main.go policy.go simulate.go -file "../LRUCache(LRU缓存)/LRUCache.go"
+//	go run main.go policy.go simulate.go -cap 2 -keys "1 2 1 3 1 4 2"
+//	go run main.go policy.go simulate.go -cap 100 -zipf 1.2 -n 100000
+func main() {
+	file := flag.String("file", "", "LeetCode 命令重放格式的文件")
+	capacity := flag.Int("cap", 2, "缓存容量，-keys/-zipf 时使用")
+	keys := flag.String("keys", "", "空格分隔的 key 序列")
+	zipf := flag.Float64("zipf", 0, "生成 Zipf 分布的 key 序列，参数 s > 1")
+	n := flag.Int("n", 10000, "-zipf 生成的 key 个数")
+	seed := flag.Int64("seed", 1, "随机种子")
+	flag.Parse()
+	if *capacity < 0 {
+		fmt.Fprintln(os.Stderr, "容量不能是负数:", *capacity)
+		os.Exit(2)
+	}
+
+	var rp *Replay
+	switch {
+	case *file != "":
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		if rp, err = ParseReplay(f); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case *keys != "":
+		var ks []int
+		for _, s := range strings.Fields(*keys) {
+			k, err := strconv.Atoi(s)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "key 应该是整数:", s)
+				os.Exit(1)
+			}
+			ks = append(ks, k)
+		}
+		rp = KeysReplay(*capacity, ks)
+	case *zipf > 1:
+		z := rand.NewZipf(rand.New(rand.NewSource(*seed)), *zipf, 1, uint64(*capacity*10))
+		ks := make([]int, *n)
+		for i := range ks {
+			ks[i] = int(z.Uint64())
+		}
+		rp = KeysReplay(*capacity, ks)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if _, err := RunAll(rp, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
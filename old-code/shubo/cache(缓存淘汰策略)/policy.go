@@ -0,0 +1,241 @@
+package main
+
+import "container/list"
+
+// 缓存淘汰策略，接口和 LeetCode 146/460 保持一致：容量固定，key/value 都是 int
+
+type Policy interface {
+	Name() string
+	Get(key int) (int, bool)
+	// Put 返回是否淘汰了别的 key
+	Put(key, value int) bool
+	Len() int
+}
+
+var policies = []struct {
+	Name string
+	New  func(capacity int) Policy
+}{
+	{"LRU", NewLRU},
+	{"LFU", NewLFU},
+	{"FIFO", NewFIFO},
+	{"Clock", NewClock},
+}
+
+type kv struct {
+	k, v int
+	freq int // LFU 用
+}
+
+// LRU：淘汰最久未使用的，链表头是最久未使用的
+type LRU struct {
+	cap int
+	m   map[int]*list.Element
+	l   *list.List
+}
+
+func NewLRU(capacity int) Policy {
+	return &LRU{cap: capacity, m: map[int]*list.Element{}, l: list.New()}
+}
+
+func (c *LRU) Name() string { return "LRU" }
+func (c *LRU) Len() int     { return len(c.m) }
+
+func (c *LRU) Get(key int) (int, bool) {
+	e, ok := c.m[key]
+	if !ok {
+		return 0, false
+	}
+	c.l.MoveToBack(e)
+	return e.Value.(*kv).v, true
+}
+
+func (c *LRU) Put(key, value int) bool {
+	if c.cap <= 0 {
+		return false
+	}
+	if e, ok := c.m[key]; ok {
+		e.Value.(*kv).v = value
+		c.l.MoveToBack(e)
+		return false
+	}
+	c.m[key] = c.l.PushBack(&kv{k: key, v: value})
+	if len(c.m) > c.cap {
+		old := c.l.Remove(c.l.Front()).(*kv)
+		delete(c.m, old.k)
+		return true
+	}
+	return false
+}
+
+// LFU：460. LFU 缓存，淘汰使用次数最少的，次数相同淘汰最久未使用的
+// 每个使用次数一个链表（频率桶），minFreq 记录最小的非空桶，Get/Put 都是 O(1)
+type LFU struct {
+	cap     int
+	m       map[int]*list.Element
+	buckets map[int]*list.List
+	minFreq int
+}
+
+func NewLFU(capacity int) Policy {
+	return &LFU{cap: capacity, m: map[int]*list.Element{}, buckets: map[int]*list.List{}}
+}
+
+func (c *LFU) Name() string { return "LFU" }
+func (c *LFU) Len() int     { return len(c.m) }
+
+// touch 使用次数 +1，挪到下一个桶的队尾
+func (c *LFU) touch(e *list.Element) *list.Element {
+	item := e.Value.(*kv)
+	b := c.buckets[item.freq]
+	b.Remove(e)
+	if b.Len() == 0 {
+		delete(c.buckets, item.freq)
+		if c.minFreq == item.freq {
+			c.minFreq++
+		}
+	}
+	item.freq++
+	return c.bucket(item.freq).PushBack(item)
+}
+
+func (c *LFU) bucket(freq int) *list.List {
+	b, ok := c.buckets[freq]
+	if !ok {
+		b = list.New()
+		c.buckets[freq] = b
+	}
+	return b
+}
+
+func (c *LFU) Get(key int) (int, bool) {
+	e, ok := c.m[key]
+	if !ok {
+		return 0, false
+	}
+	c.m[key] = c.touch(e)
+	return e.Value.(*kv).v, true
+}
+
+func (c *LFU) Put(key, value int) bool {
+	if c.cap <= 0 {
+		return false
+	}
+	if e, ok := c.m[key]; ok {
+		e.Value.(*kv).v = value
+		c.m[key] = c.touch(e)
+		return false
+	}
+	evicted := false
+	if len(c.m) >= c.cap {
+		b := c.buckets[c.minFreq]
+		old := b.Remove(b.Front()).(*kv)
+		if b.Len() == 0 {
+			delete(c.buckets, c.minFreq)
+		}
+		delete(c.m, old.k)
+		evicted = true
+	}
+	c.minFreq = 1
+	c.m[key] = c.bucket(1).PushBack(&kv{k: key, v: value, freq: 1})
+	return evicted
+}
+
+// FIFO：淘汰最早写入的，Get 不影响顺序
+type FIFO struct {
+	cap int
+	m   map[int]*list.Element
+	l   *list.List
+}
+
+func NewFIFO(capacity int) Policy {
+	return &FIFO{cap: capacity, m: map[int]*list.Element{}, l: list.New()}
+}
+
+func (c *FIFO) Name() string { return "FIFO" }
+func (c *FIFO) Len() int     { return len(c.m) }
+
+func (c *FIFO) Get(key int) (int, bool) {
+	e, ok := c.m[key]
+	if !ok {
+		return 0, false
+	}
+	return e.Value.(*kv).v, true
+}
+
+func (c *FIFO) Put(key, value int) bool {
+	if c.cap <= 0 {
+		return false
+	}
+	if e, ok := c.m[key]; ok {
+		e.Value.(*kv).v = value
+		return false
+	}
+	c.m[key] = c.l.PushBack(&kv{k: key, v: value})
+	if len(c.m) > c.cap {
+		old := c.l.Remove(c.l.Front()).(*kv)
+		delete(c.m, old.k)
+		return true
+	}
+	return false
+}
+
+// Clock：FIFO 的环形版本，每个槽位一个访问位，用 LRU 的近似换 O(1) 的 Get（不用挪节点）
+// 淘汰时指针转一圈，访问位是 1 的清零放过，遇到 0 就淘汰
+type Clock struct {
+	slots []clockSlot
+	m     map[int]int // key -> 槽位下标
+	hand  int
+}
+
+type clockSlot struct {
+	k, v int
+	ref  bool
+	used bool
+}
+
+// NewClock 容量是负数时当 0 处理，和其他策略一样什么都存不下
+func NewClock(capacity int) Policy {
+	return &Clock{slots: make([]clockSlot, max(capacity, 0)), m: map[int]int{}}
+}
+
+func (c *Clock) Name() string { return "Clock" }
+func (c *Clock) Len() int     { return len(c.m) }
+
+func (c *Clock) Get(key int) (int, bool) {
+	i, ok := c.m[key]
+	if !ok {
+		return 0, false
+	}
+	c.slots[i].ref = true
+	return c.slots[i].v, true
+}
+
+func (c *Clock) Put(key, value int) bool {
+	if len(c.slots) == 0 {
+		return false
+	}
+	if i, ok := c.m[key]; ok {
+		c.slots[i].v = value
+		c.slots[i].ref = true
+		return false
+	}
+	evicted := false
+	for {
+		s := &c.slots[c.hand]
+		if !s.used {
+			break
+		}
+		if !s.ref {
+			delete(c.m, s.k)
+			evicted = true
+			break
+		}
+		s.ref = false
+		c.hand = (c.hand + 1) % len(c.slots)
+	}
+	c.slots[c.hand] = clockSlot{k: key, v: value, used: true}
+	c.m[key] = c.hand
+	c.hand = (c.hand + 1) % len(c.slots)
+	return evicted
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLRUCacheExample(t *testing.T) {
+	f, err := os.Open("../LRUCache(LRU缓存)/LRUCache.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rp, err := ParseReplay(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.Class != "LRUCache" || rp.Capacity != 2 || len(rp.Ops) != 9 {
+		t.Fatalf("%+v", rp)
+	}
+	var out strings.Builder
+	if _, err := RunAll(rp, &out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 460. LFU 缓存 的示例
+func TestLFUCacheExample(t *testing.T) {
+	rp, err := ParseReplay(strings.NewReader(`
+["LFUCache", "put", "put", "get", "put", "get", "get", "put", "get", "get", "get"]
+[[2], [1, 1], [2, 2], [1], [3, 3], [2], [3], [4, 4], [1], [3], [4]]
+[null, null, null, 1, null, -1, 3, null, -1, 3, 4]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(rp, Simulate(NewLFU(rp.Capacity), rp)); err != nil {
+		t.Fatal(err)
+	}
+	// 期望输出改错一个，Check 要指出是哪一步
+	rp.Expected[9] = -1
+	err = Check(rp, Simulate(NewLFU(rp.Capacity), rp))
+	if err == nil || !strings.Contains(err.Error(), "第 9 个操作 get[3]") {
+		t.Fatal(err)
+	}
+}
+
+// 朴素实现：每个 key 记录最后访问时间、写入时间和访问次数，淘汰时线性扫描
+type naive struct {
+	name  string
+	cap   int
+	clock int
+	m     map[int]*naiveEntry
+}
+
+type naiveEntry struct {
+	v, used, added, freq int
+}
+
+func (c *naive) Name() string { return c.name }
+func (c *naive) Len() int     { return len(c.m) }
+
+func (c *naive) Get(key int) (int, bool) {
+	c.clock++
+	e, ok := c.m[key]
+	if !ok {
+		return 0, false
+	}
+	e.used = c.clock
+	e.freq++
+	return e.v, true
+}
+
+func (c *naive) Put(key, value int) bool {
+	c.clock++
+	if e, ok := c.m[key]; ok {
+		e.v = value
+		if c.name != "FIFO" {
+			e.used = c.clock
+			e.freq++
+		}
+		return false
+	}
+	evicted := false
+	if len(c.m) >= c.cap {
+		victim := -1
+		for k, e := range c.m {
+			if victim == -1 || c.worse(e, c.m[victim]) {
+				victim = k
+			}
+		}
+		delete(c.m, victim)
+		evicted = true
+	}
+	c.m[key] = &naiveEntry{v: value, used: c.clock, added: c.clock, freq: 1}
+	return evicted
+}
+
+// worse a 比 b 更应该被淘汰
+func (c *naive) worse(a, b *naiveEntry) bool {
+	switch c.name {
+	case "LFU":
+		if a.freq != b.freq {
+			return a.freq < b.freq
+		}
+		return a.used < b.used
+	case "FIFO":
+		return a.added < b.added
+	}
+	return a.used < b.used
+}
+
+func randomReplay(r *rand.Rand, capacity, n, keys int) *Replay {
+	rp := &Replay{Class: "Cache", Capacity: capacity}
+	for i := 0; i < n; i++ {
+		k := r.Intn(keys)
+		if r.Intn(2) == 0 {
+			rp.Ops = append(rp.Ops, Op{Method: "put", Args: []int{k, r.Intn(100)}})
+		} else {
+			rp.Ops = append(rp.Ops, Op{Method: "get", Args: []int{k}})
+		}
+	}
+	return rp
+}
+
+func TestAgainstNaive(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, name := range []string{"LRU", "LFU", "FIFO"} {
+		var newPolicy func(int) Policy
+		for _, p := range policies {
+			if p.Name == name {
+				newPolicy = p.New
+			}
+		}
+		for i := 0; i < 200; i++ {
+			capacity := 1 + r.Intn(5)
+			rp := randomReplay(r, capacity, 200, 3*capacity)
+			got := Simulate(newPolicy(capacity), rp)
+			want := Simulate(&naive{name: name, cap: capacity, m: map[int]*naiveEntry{}}, rp)
+			for j := range want.Outputs {
+				if got.Outputs[j] != want.Outputs[j] {
+					t.Fatalf("%s cap=%d 第 %d 个操作 %v 输出 %v，期望 %v", name, capacity, j, rp.Ops[j-1], got.Outputs[j], want.Outputs[j])
+				}
+			}
+			if got.Evictions != want.Evictions {
+				t.Fatalf("%s 淘汰次数 %d != %d", name, got.Evictions, want.Evictions)
+			}
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	c := NewClock(3)
+	for _, k := range []int{1, 2, 3} {
+		c.Put(k, k)
+	}
+	// 1 被访问过，第二次机会；指针清掉 1 的访问位后淘汰 2
+	c.Get(1)
+	if !c.Put(4, 4) {
+		t.Fatal("应该淘汰一个")
+	}
+	if _, ok := c.Get(2); ok {
+		t.Fatal("2 应该被淘汰了")
+	}
+	for _, k := range []int{1, 3, 4} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("%d 应该还在", k)
+		}
+	}
+	if c.Len() != 3 {
+		t.Fatal(c.Len())
+	}
+}
+
+func TestZipfHitRatio(t *testing.T) {
+	z := rand.NewZipf(rand.New(rand.NewSource(1)), 1.2, 1, 1000)
+	keys := make([]int, 20000)
+	for i := range keys {
+		keys[i] = int(z.Uint64())
+	}
+	reports, err := RunAll(KeysReplay(100, keys), &strings.Builder{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ratio := map[string]float64{}
+	for _, r := range reports {
+		ratio[r.Policy] = r.HitRatio()
+	}
+	// 热点集中时 LFU 最好，FIFO 最差，Clock 接近 LRU
+	if !(ratio["LFU"] > ratio["LRU"] && ratio["LRU"] > ratio["FIFO"] && ratio["Clock"] > ratio["FIFO"]) {
+		t.Fatal(ratio)
+	}
+}
+
+// TestNegativeCapacity 绕过 ParseReplay 直接传 0 或负数容量，所有策略都不能 panic
+// 什么都存不下，也就没有别的 key 可淘汰，Put 一律返回 false
+func TestNegativeCapacity(t *testing.T) {
+	for _, p := range policies {
+		for _, capacity := range []int{0, -1} {
+			c := p.New(capacity)
+			for k := 0; k < 3; k++ {
+				if c.Put(k, k) {
+					t.Fatal(p.Name, capacity, "容量存不下东西却报告了淘汰")
+				}
+			}
+			if _, ok := c.Get(1); ok || c.Len() != 0 {
+				t.Fatal(p.Name, capacity, "容量不是正数还存下了")
+			}
+		}
+	}
+}
+
+func TestParseReplayError(t *testing.T) {
+	for _, s := range []string{
+		`["LRUCache"]`,
+		"[\"LRUCache\", \"put\"]\n[[2], [1]]",
+		"[\"LRUCache\", \"del\"]\n[[2], [1]]",
+		"[\"LRUCache\", \"get\"]\n[[2], [1]]\n[null]",
+		"[\"LRUCache\", \"get\"]\n[[], [1]]",
+		"[\"LRUCache\", \"get\"]\n[[-1], [1]]",
+	} {
+		if _, err := ParseReplay(strings.NewReader(s)); err == nil {
+			t.Fatalf("%s 应该解析失败", s)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// 按 LeetCode 的命令重放格式驱动各个淘汰策略，和 LRUCache(LRU缓存) 注释里的写法一样：
+//
+//	["LRUCache", "put", "put", "get", "put", "get", "put", "get", "get", "get"]
+//	[[2], [1, 1], [2, 2], [1], [3, 3], [2], [4, 4], [1], [3], [4]]
+//	[null, null, null, 1, null, -1, null, -1, 3, 4]
+//
+// 第三行期望输出可以省略。除了 get/put，还支持 access k：
+// 先 get，未命中再 put(k, k)，用来模拟只有 key 序列的访问轨迹。
+
+type Op struct {
+	Method string
+	Args   []int
+}
+
+type Replay struct {
+	Class    string
+	Capacity int
+	Ops      []Op
+	Expected []interface{} // 可以为空
+}
+
+// ParseReplay 每行开头的 // 会被去掉，不以 [ 开头的行会被跳过，
+// 所以可以直接把 LRUCache.go 这样带注释示例的源文件喂进来
+func ParseReplay(r io.Reader) (*Replay, error) {
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(sc.Text()), "//"))
+		if strings.HasPrefix(line, "[") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("至少需要操作和参数两行")
+	}
+	var methods []string
+	var args [][]int
+	if err := json.Unmarshal([]byte(lines[0]), &methods); err != nil {
+		return nil, fmt.Errorf("操作行: %w", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &args); err != nil {
+		return nil, fmt.Errorf("参数行: %w", err)
+	}
+	if len(methods) != len(args) || len(methods) == 0 || len(args[0]) != 1 {
+		return nil, fmt.Errorf("操作 %d 个、参数 %d 组，第一个操作应该是带容量的构造函数", len(methods), len(args))
+	}
+	// 容量 0 的缓存什么都存不下，可以用来对照；负数没有意义
+	if args[0][0] < 0 {
+		return nil, fmt.Errorf("容量 %d 不能是负数", args[0][0])
+	}
+	rp := &Replay{Class: methods[0], Capacity: args[0][0]}
+	for i := 1; i < len(methods); i++ {
+		want := map[string]int{"get": 1, "put": 2, "access": 1}[methods[i]]
+		if want == 0 || len(args[i]) != want {
+			return nil, fmt.Errorf("第 %d 个操作 %s%v 不合法", i, methods[i], args[i])
+		}
+		rp.Ops = append(rp.Ops, Op{Method: methods[i], Args: args[i]})
+	}
+	if len(lines) > 2 {
+		if err := json.Unmarshal([]byte(lines[2]), &rp.Expected); err != nil {
+			return nil, fmt.Errorf("输出行: %w", err)
+		}
+		if len(rp.Expected) != len(methods) {
+			return nil, fmt.Errorf("输出 %d 个，操作 %d 个", len(rp.Expected), len(methods))
+		}
+	}
+	return rp, nil
+}
+
+// KeysReplay 把 key 序列转成 access 操作
+func KeysReplay(capacity int, keys []int) *Replay {
+	rp := &Replay{Class: "Cache", Capacity: capacity}
+	for _, k := range keys {
+		rp.Ops = append(rp.Ops, Op{Method: "access", Args: []int{k}})
+	}
+	return rp
+}
+
+type Report struct {
+	Policy    string        `json:"policy"`
+	Gets      int           `json:"gets"`
+	Hits      int           `json:"hits"`
+	Evictions int           `json:"evictions"`
+	Outputs   []interface{} `json:"outputs"` // LeetCode 格式，put 为 null，get 未命中为 -1
+}
+
+func (r Report) HitRatio() float64 {
+	if r.Gets == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(r.Gets)
+}
+
+func Simulate(p Policy, rp *Replay) Report {
+	ret := Report{Policy: p.Name(), Outputs: []interface{}{nil}}
+	for _, op := range rp.Ops {
+		switch op.Method {
+		case "put":
+			if p.Put(op.Args[0], op.Args[1]) {
+				ret.Evictions++
+			}
+			ret.Outputs = append(ret.Outputs, nil)
+		case "get", "access":
+			ret.Gets++
+			v, ok := p.Get(op.Args[0])
+			if ok {
+				ret.Hits++
+				ret.Outputs = append(ret.Outputs, v)
+				continue
+			}
+			ret.Outputs = append(ret.Outputs, -1)
+			if op.Method == "access" && p.Put(op.Args[0], op.Args[0]) {
+				ret.Evictions++
+			}
+		}
+	}
+	return ret
+}
+
+// 重放里的类名对应的策略，用来核对期望输出
+var classPolicy = map[string]string{
+	"LRUCache": "LRU",
+	"LFUCache": "LFU",
+}
+
+// Check 如果重放带了期望输出，并且类名对应这个策略，核对每一步的输出
+func Check(rp *Replay, r Report) error {
+	if rp.Expected == nil || classPolicy[rp.Class] != r.Policy {
+		return nil
+	}
+	for i := 1; i < len(rp.Expected); i++ {
+		want, _ := json.Marshal(rp.Expected[i])
+		got, _ := json.Marshal(r.Outputs[i])
+		if string(want) != string(got) {
+			return fmt.Errorf("%s 第 %d 个操作 %s%v 输出 %s，期望 %s", r.Policy, i, rp.Ops[i-1].Method, rp.Ops[i-1].Args, got, want)
+		}
+	}
+	return nil
+}
+
+// RunAll 所有策略各跑一遍，并排打印命中率
+func RunAll(rp *Replay, w io.Writer) ([]Report, error) {
+	var reports []Report
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "策略\t访问\t命中\t命中率\t淘汰\n")
+	for _, p := range policies {
+		r := Simulate(p.New(rp.Capacity), rp)
+		if err := Check(rp, r); err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f%%\t%d\n", r.Policy, r.Gets, r.Hits, 100*r.HitRatio(), r.Evictions)
+	}
+	return reports, tw.Flush()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// formatReplay ParseReplay 的逆操作，打印成三行
+func formatReplay(rp *Replay) string {
+	methods := []string{rp.Class}
+	args := [][]int{{rp.Capacity}}
+	for _, op := range rp.Ops {
+		methods = append(methods, op.Method)
+		args = append(args, op.Args)
+	}
+	m, _ := json.Marshal(methods)
+	a, _ := json.Marshal(args)
+	s := string(m) + "\n" + string(a)
+	if rp.Expected != nil {
+		e, _ := json.Marshal(rp.Expected)
+		s += "\n" + string(e)
+	}
+	return s
+}
+
+// go test -fuzz FuzzParseReplay
+// 解析成功的重放，所有策略都要能跑完，打印再解析结果不变
+func FuzzParseReplay(f *testing.F) {
+	src, err := os.ReadFile("../LRUCache(LRU缓存)/LRUCache.go")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(string(src))
+	f.Add(`["LFUCache", "put", "put", "get", "put", "get", "get", "put", "get", "get", "get"]
+[[2], [1, 1], [2, 2], [1], [3, 3], [2], [3], [4, 4], [1], [3], [4]]
+[null, null, null, 1, null, -1, 3, null, -1, 3, 4]`)
+	f.Add(`["Cache", "access", "access", "access"]
+[[1], [1], [2], [1]]`)
+	f.Add(`["LRUCache", "get"]
+[[0], [1]]`)
+	// 负数容量以前能解析，NewClock 里 make 会 panic
+	f.Add(`["LRUCache", "get"]
+[[-1], [1]]`)
+	f.Fuzz(func(t *testing.T, s string) {
+		rp, err := ParseReplay(strings.NewReader(s))
+		if err != nil {
+			return
+		}
+		if len(rp.Ops) > 1000 {
+			return
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Fatalf("%q panic: %v", s, r)
+				}
+			}()
+			RunAll(rp, io.Discard)
+		}()
+		out := formatReplay(rp)
+		again, err := ParseReplay(strings.NewReader(out))
+		if err != nil || !reflect.DeepEqual(rp, again) {
+			t.Fatalf("%q -> %q: %v", s, out, err)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../graph(图)/graph.go ../graph(图)/topo.go
+
+// 入度表和出边表交给 graph，Kahn 取不完就是有环
+func canFinishGraph(numCourses int, prerequisites [][]int) bool {
+	_, err := FromPrerequisites(numCourses, prerequisites).TopoKahn()
+	return err == nil
+}
+
+func TestCanFinishGraph(t *testing.T) {
+	for _, c := range []struct {
+		n    int
+		pre  [][]int
+		want bool
+	}{
+		{2, [][]int{{1, 0}}, true},
+		{2, [][]int{{1, 0}, {0, 1}}, false},
+		{1, [][]int{}, true},
+		{5, [][]int{{1, 4}, {2, 4}, {3, 1}, {3, 2}}, true},
+	} {
+		if got := canFinishGraph(c.n, c.pre); got != c.want {
+			t.Fatalf("%d %v: %v", c.n, c.pre, got)
+		}
+	}
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		n := 1 + r.Intn(10)
+		var pre [][]int
+		for k := 0; k < r.Intn(2*n); k++ {
+			pre = append(pre, []int{r.Intn(n), r.Intn(n)})
+		}
+		want := canFinish(n, pre)
+		if canFinishGraph(n, pre) != want {
+			t.Fatalf("%d %v: 和 DFS 写法结论不同", n, pre)
+		}
+		// 不能完成时，环上的每门课都是下一门课的先修课
+		_, err := FromPrerequisites(n, pre).TopoDFS()
+		var ce *CycleError
+		if want != (err == nil) || (err != nil && !errors.As(err, &ce)) {
+			t.Fatalf("%d %v: %v", n, pre, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 297. 二叉树的序列化与反序列化
+// 三种格式：
+//   - LevelOrder LeetCode 的层序格式 [1,2,3,null,null,4,5]
+//   - PreOrder   先序，空节点写 #，比层序少了方括号，也不用去尾部的 null：1,2,#,#,3,4,#,#,5,#,#
+//   - Binary     先序，每个位置一个 uvarint：0 是空节点，否则是 zigzag(值)+1，不支持 math.MinInt64
+// 编码解码都是流式的：边遍历边写 io.Writer，边读边建树，全程不递归，链一样深的树也没问题。
+// PreOrder 和 Binary 自带结束位置，同一个流里可以连续读出多棵树。
+
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+type Format int
+
+const (
+	LevelOrder Format = iota
+	PreOrder
+	Binary
+)
+
+func (f Format) String() string {
+	switch f {
+	case LevelOrder:
+		return "level"
+	case PreOrder:
+		return "preorder"
+	case Binary:
+		return "binary"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+var (
+	ErrFormat = errors.New("codec: 格式错误")
+	ErrValue  = errors.New("codec: 节点值不能编码")
+)
+
+type Codec struct {
+	Format Format
+}
+
+// Constructor LeetCode 的接口，用层序格式
+func Constructor() Codec {
+	return Codec{Format: LevelOrder}
+}
+
+// serialize / deserialize 是 LeetCode 要求的方法名
+func (this *Codec) serialize(root *TreeNode) string {
+	var b strings.Builder
+	this.Encode(&b, root)
+	return b.String()
+}
+
+// deserialize 输入不合法时返回 nil，要知道原因用 Decode
+func (this *Codec) deserialize(data string) *TreeNode {
+	root, err := this.Decode(strings.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return root
+}
+
+// Encode 把树写到 w；返回 ErrValue 时 w 里可能已经写了一部分
+func (this *Codec) Encode(w io.Writer, root *TreeNode) error {
+	bw := bufio.NewWriter(w)
+	switch this.Format {
+	case LevelOrder:
+		encodeLevel(bw, root)
+	case PreOrder:
+		encodePre(bw, root, false)
+	case Binary:
+		if err := encodePre(bw, root, true); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("codec: 未知格式 %v", this.Format)
+	}
+	return bw.Flush()
+}
+
+// Decode 从 r 读一棵树
+// r 实现了 io.ByteScanner（比如 *bufio.Reader）时不会多读，读完一棵树可以接着读下一棵；
+// 否则内部包一层 bufio.Reader，可能多读一些字节。
+func (this *Codec) Decode(r io.Reader) (*TreeNode, error) {
+	br, ok := r.(io.ByteScanner)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	switch this.Format {
+	case LevelOrder:
+		return decodeLevel(br)
+	case PreOrder:
+		return decodePre(&textReader{r: br})
+	case Binary:
+		return decodePre(&binaryReader{r: br})
+	}
+	return nil, fmt.Errorf("codec: 未知格式 %v", this.Format)
+}
+
+// Marshal / Unmarshal 内存里的便捷版本
+func (this *Codec) Marshal(root *TreeNode) ([]byte, error) {
+	var b bytes.Buffer
+	if err := this.Encode(&b, root); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func (this *Codec) Unmarshal(data []byte) (*TreeNode, error) {
+	return this.Decode(bytes.NewReader(data))
+}
+
+func main() {
+	//[1,2,3,null,null,4,5]
+	root := &TreeNode{Val: 1, Left: &TreeNode{Val: 2}, Right: &TreeNode{Val: 3, Left: &TreeNode{Val: 4}, Right: &TreeNode{Val: 5}}}
+	for _, f := range []Format{LevelOrder, PreOrder, Binary} {
+		c := Codec{Format: f}
+		data, _ := c.Marshal(root)
+		back, _ := c.Unmarshal(data)
+		fmt.Printf("%v %q %v\n", f, data, back.Val)
+	}
+	c := Constructor()
+	fmt.Println(c.serialize(c.deserialize("[1,2,3,null,null,4,5]")))
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var formats = []Format{LevelOrder, PreOrder, Binary}
+
+// random n 个节点的随机树，值在 [-1000, 1000]
+func random(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	nodes := []*TreeNode{{Val: r.Intn(2001) - 1000}}
+	for len(nodes) < n {
+		p := nodes[r.Intn(len(nodes))]
+		c := &TreeNode{Val: r.Intn(2001) - 1000}
+		switch {
+		case p.Left == nil && (p.Right != nil || r.Intn(2) == 0):
+			p.Left = c
+		case p.Right == nil:
+			p.Right = c
+		default:
+			continue
+		}
+		nodes = append(nodes, c)
+	}
+	return nodes[0]
+}
+
+// chain 一条 n 个节点的链，左右交替
+func chain(n int) *TreeNode {
+	var root *TreeNode
+	for i := n; i > 0; i-- {
+		if i%2 == 0 {
+			root = &TreeNode{Val: i, Left: root}
+		} else {
+			root = &TreeNode{Val: -i, Right: root}
+		}
+	}
+	return root
+}
+
+// marshal 测试里用的树都能编码，出错直接失败
+func marshal(tb testing.TB, c Codec, root *TreeNode) []byte {
+	tb.Helper()
+	data, err := c.Marshal(root)
+	if err != nil {
+		tb.Fatalf("%v: %v", c.Format, err)
+	}
+	return data
+}
+
+// equal 比较用栈，链一样深的树也不会爆栈
+func equal(a, b *TreeNode) bool {
+	stack := [][2]*TreeNode{{a, b}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if p[0] == nil || p[1] == nil {
+			if p[0] != p[1] {
+				return false
+			}
+			continue
+		}
+		if p[0].Val != p[1].Val {
+			return false
+		}
+		stack = append(stack, [2]*TreeNode{p[0].Left, p[1].Left}, [2]*TreeNode{p[0].Right, p[1].Right})
+	}
+	return true
+}
+
+func TestLeetCode(t *testing.T) {
+	c := Constructor()
+	for _, s := range []string{"[]", "[1]", "[1,2,3,null,null,4,5]", "[1,null,2,null,3]", "[-1,0,1]", "[5,4,7,3,null,2,null,-1,null,9]"} {
+		if got := c.serialize(c.deserialize(s)); got != s {
+			t.Fatalf("%s -> %s", s, got)
+		}
+	}
+	if got := c.serialize(c.deserialize(" [ 1 , 2 ,\n null , 3 ] ")); got != "[1,2,null,3]" {
+		t.Fatal("空白没有忽略:", got)
+	}
+}
+
+func TestPreOrderText(t *testing.T) {
+	c := Codec{Format: PreOrder}
+	root := Constructor()
+	tree := root.deserialize("[1,2,3,null,null,4,5]")
+	if got := c.serialize(tree); got != "1,2,#,#,3,4,#,#,5,#,#" {
+		t.Fatal(got)
+	}
+	if got := c.serialize(nil); got != "#" {
+		t.Fatal(got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, f := range formats {
+		c := Codec{Format: f}
+		for n := 0; n <= 50; n++ {
+			for k := 0; k < 20; k++ {
+				root := random(r, n)
+				data := marshal(t, c, root)
+				got, err := c.Unmarshal(data)
+				if err != nil || !equal(root, got) {
+					t.Fatalf("%v n=%d: %q err=%v", f, n, data, err)
+				}
+			}
+		}
+	}
+}
+
+// 10^6 层的链，递归写法会很慢甚至爆栈
+func TestDeep(t *testing.T) {
+	root := chain(1_000_000)
+	for _, f := range formats {
+		c := Codec{Format: f}
+		got, err := c.Unmarshal(marshal(t, c, root))
+		if err != nil || !equal(root, got) {
+			t.Fatalf("%v err=%v", f, err)
+		}
+	}
+}
+
+// 每次只给一个字节，解码器也要能正常工作
+func TestOneByteReader(t *testing.T) {
+	root := random(rand.New(rand.NewSource(2)), 200)
+	for _, f := range formats {
+		c := Codec{Format: f}
+		got, err := c.Decode(iotest.OneByteReader(bytes.NewReader(marshal(t, c, root))))
+		if err != nil || !equal(root, got) {
+			t.Fatalf("%v err=%v", f, err)
+		}
+	}
+}
+
+// 同一个流里连续写多棵树，再按顺序读回来
+func TestStream(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for _, f := range formats {
+		c := Codec{Format: f}
+		var trees []*TreeNode
+		var buf bytes.Buffer
+		for i := 0; i < 30; i++ {
+			root := random(r, r.Intn(20))
+			trees = append(trees, root)
+			if err := c.Encode(&buf, root); err != nil {
+				t.Fatal(err)
+			}
+		}
+		br := bufio.NewReader(&buf)
+		for i, want := range trees {
+			got, err := c.Decode(br)
+			if err != nil || !equal(want, got) {
+				t.Fatalf("%v 第 %d 棵 err=%v", f, i, err)
+			}
+		}
+		if _, err := br.ReadByte(); err == nil {
+			t.Fatalf("%v 还有没读完的数据", f)
+		}
+	}
+}
+
+func TestMalformed(t *testing.T) {
+	cases := map[Format][]string{
+		LevelOrder: {"", "1,2", "[", "[1,2", "[null]", "[1,x]", "[1,,2]", "[1,null,null,2]", "[1.5]",
+			"[1 2,3]", "[1,2 3]", "[1,nu ll]", "[#]", "[1,#,2]"},
+		PreOrder: {"", "1", "1,#", "1,2,#,#", "x", "1;#;#", "1 # #"},
+		Binary:   {"", "\x02", "\x02\x00", "\x80"},
+	}
+	for f, inputs := range cases {
+		c := Codec{Format: f}
+		for _, s := range inputs {
+			_, err := c.Decode(strings.NewReader(s))
+			if !errors.Is(err, ErrFormat) {
+				t.Fatalf("%v %q: err=%v", f, s, err)
+			}
+			if c.deserialize(s) != nil {
+				t.Fatalf("%v %q 应该返回 nil", f, s)
+			}
+		}
+	}
+}
+
+func TestZigzag(t *testing.T) {
+	for _, v := range []int{0, 1, -1, 63, -64, 1 << 40, -(1 << 40), 1<<63 - 1} {
+		if got := unzigzag(zigzag(v)); got != v {
+			t.Fatalf("%d -> %d", v, got)
+		}
+		if zigzag(v)+1 == 0 {
+			t.Fatalf("%d 和空节点冲突", v)
+		}
+	}
+}
+
+// math.MinInt64 写成二进制会和空节点撞上，要报错而不是悄悄丢掉这棵子树；文本格式没有这个问题
+func TestMinInt64(t *testing.T) {
+	root := &TreeNode{Val: 1, Left: &TreeNode{Val: math.MinInt64, Left: &TreeNode{Val: 2}}, Right: &TreeNode{Val: 3}}
+	c := Codec{Format: Binary}
+	if _, err := c.Marshal(root); !errors.Is(err, ErrValue) {
+		t.Fatalf("err=%v", err)
+	}
+	for _, f := range []Format{LevelOrder, PreOrder} {
+		c := Codec{Format: f}
+		got, err := c.Unmarshal(marshal(t, c, root))
+		if err != nil || !equal(root, got) {
+			t.Fatalf("%v err=%v", f, err)
+		}
+	}
+}
+
+// 各格式在不同规模下的体积和编码、解码速度
+// go test -bench . -benchmem *.go
+func BenchmarkCodec(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10000} {
+		root := random(rand.New(rand.NewSource(int64(n))), n)
+		for _, f := range formats {
+			c := Codec{Format: f}
+			data := marshal(b, c, root)
+			b.Run(fmt.Sprintf("encode/%v/%d", f, n), func(b *testing.B) {
+				var buf bytes.Buffer
+				for i := 0; i < b.N; i++ {
+					buf.Reset()
+					c.Encode(&buf, root)
+				}
+				b.ReportMetric(float64(len(data)), "bytes")
+				b.ReportMetric(float64(len(data))/float64(n), "bytes/node")
+			})
+			b.Run(fmt.Sprintf("decode/%v/%d", f, n), func(b *testing.B) {
+				b.SetBytes(int64(len(data)))
+				for i := 0; i < b.N; i++ {
+					if _, err := c.Unmarshal(data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+var examples = []string{"[]", "[1]", "[1,2,3,null,null,4,5]", "[1,null,2,null,3]", "[-1,0,1]", "[5,4,7,3,null,2,null,-1,null,9]"}
+
+// 解码成功时，编码再解码要得到同一棵树，再编码一次字节也不变
+func fuzzDecode(f *testing.F, format Format) {
+	level := Constructor()
+	c := Codec{Format: format}
+	for _, s := range examples {
+		f.Add(marshal(f, c, level.deserialize(s)))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		root, err := c.Unmarshal(data)
+		if err != nil {
+			return
+		}
+		out := marshal(t, c, root)
+		again, err := c.Unmarshal(out)
+		if err != nil || !equal(root, again) {
+			t.Fatalf("%q -> %q: %v", data, out, err)
+		}
+		if out2 := marshal(t, c, again); !bytes.Equal(out2, out) {
+			t.Fatalf("%q -> %q -> %q", data, out, out2)
+		}
+	})
+}
+
+// go test -fuzz FuzzLevelOrder *.go
+func FuzzLevelOrder(f *testing.F) { fuzzDecode(f, LevelOrder) }
+
+func FuzzPreOrder(f *testing.F) { fuzzDecode(f, PreOrder) }
+
+func FuzzBinary(f *testing.F) { fuzzDecode(f, Binary) }
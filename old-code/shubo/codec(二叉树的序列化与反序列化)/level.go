@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// 层序格式：队列里只放非空节点，空孩子先记个数，后面出现非空节点时才补写 null，这样末尾的 null 自然就去掉了
+func encodeLevel(w *bufio.Writer, root *TreeNode) {
+	w.WriteByte('[')
+	if root != nil {
+		w.WriteString(strconv.Itoa(root.Val))
+		queue := []*TreeNode{root}
+		nulls := 0
+		for head := 0; head < len(queue); head++ {
+			for _, c := range [2]*TreeNode{queue[head].Left, queue[head].Right} {
+				if c == nil {
+					nulls++
+					continue
+				}
+				for ; nulls > 0; nulls-- {
+					w.WriteString(",null")
+				}
+				w.WriteByte(',')
+				w.WriteString(strconv.Itoa(c.Val))
+				queue = append(queue, c)
+			}
+			queue[head] = nil // 已经写完的节点不再引用
+		}
+	}
+	w.WriteByte(']')
+}
+
+// 层序解析：第 k 个非空节点的孩子是后面的第 2k+1、2k+2 个元素
+// 队列里是等着挂孩子的节点，读一个元素就挂到队头节点上
+func decodeLevel(r io.ByteScanner) (*TreeNode, error) {
+	t := &textReader{r: r}
+	if err := t.expect('['); err != nil {
+		return nil, err
+	}
+	tok, end, err := t.next(']')
+	if err != nil {
+		return nil, err
+	}
+	if end && tok == "" {
+		return nil, nil
+	}
+	root, err := parseNode(tok)
+	if err != nil || root == nil {
+		return nil, fmt.Errorf("%w: 根节点 %q", ErrFormat, tok)
+	}
+	queue := []*TreeNode{root}
+	head, left := 0, true
+	for !end {
+		tok, end, err = t.next(']')
+		if err != nil {
+			return nil, err
+		}
+		if head == len(queue) {
+			return nil, fmt.Errorf("%w: %q 没有父节点", ErrFormat, tok)
+		}
+		n, err := parseNode(tok)
+		if err != nil {
+			return nil, err
+		}
+		if left {
+			queue[head].Left = n
+		} else {
+			queue[head].Right = n
+			queue[head] = nil
+			head++
+		}
+		left = !left
+		if n != nil {
+			queue = append(queue, n)
+		}
+	}
+	return root, nil
+}
+
+// parseNode 层序格式的空节点只有 null，# 是先序格式的
+func parseNode(tok string) (*TreeNode, error) {
+	if tok == "null" {
+		return nil, nil
+	}
+	v, err := strconv.Atoi(tok)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 节点值 %q", ErrFormat, tok)
+	}
+	return &TreeNode{Val: v}, nil
+}
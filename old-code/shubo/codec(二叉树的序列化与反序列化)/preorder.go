@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+// 先序格式：显式栈，先压右孩子再压左孩子
+func encodePre(w *bufio.Writer, root *TreeNode, bin bool) error {
+	var buf [binary.MaxVarintLen64]byte
+	first := true
+	put := func(n *TreeNode) error {
+		if bin {
+			var x uint64
+			if n != nil {
+				if n.Val == math.MinInt64 {
+					return fmt.Errorf("%w: 二进制格式不能写 math.MinInt64", ErrValue)
+				}
+				x = zigzag(n.Val) + 1
+			}
+			w.Write(buf[:binary.PutUvarint(buf[:], x)])
+			return nil
+		}
+		if !first {
+			w.WriteByte(',')
+		}
+		first = false
+		if n == nil {
+			w.WriteByte('#')
+		} else {
+			w.WriteString(strconv.Itoa(n.Val))
+		}
+		return nil
+	}
+	stack := []*TreeNode{root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if err := put(n); err != nil {
+			return err
+		}
+		if n != nil {
+			stack = append(stack, n.Right, n.Left)
+		}
+	}
+	return nil
+}
+
+// 0 留给空节点，所以写的是 zigzag(值)+1；math.MinInt64 的 zigzag 是全 1，+1 会回绕成 0，
+// 写出来就成了空节点，整棵子树都丢了，所以 encodePre 遇到它直接报错
+func zigzag(v int) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func unzigzag(x uint64) int {
+	return int(x>>1) ^ -int(x&1)
+}
+
+// tokenReader 先序解析用，每次读一个位置：节点或者空
+type tokenReader interface {
+	node() (*TreeNode, error)
+}
+
+// 先序解析：栈里放等着填的孩子指针，填进非空节点后先压右孩子再压左孩子
+func decodePre(t tokenReader) (*TreeNode, error) {
+	var root *TreeNode
+	stack := []**TreeNode{&root}
+	for len(stack) > 0 {
+		slot := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n, err := t.node()
+		if err != nil {
+			return nil, err
+		}
+		*slot = n
+		if n != nil {
+			stack = append(stack, &n.Right, &n.Left)
+		}
+	}
+	return root, nil
+}
+
+type binaryReader struct {
+	r io.ByteScanner
+}
+
+func (b *binaryReader) node() (*TreeNode, error) {
+	x, err := binary.ReadUvarint(b.r)
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: 数据提前结束", ErrFormat)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFormat, err)
+	}
+	if x == 0 {
+		return nil, nil
+	}
+	return &TreeNode{Val: unzigzag(x - 1)}, nil
+}
+
+// textReader 逗号分隔的文本，忽略空白
+type textReader struct {
+	r     io.ByteScanner
+	count int // 已经读了几个元素，先序格式据此判断要不要先吃掉逗号
+}
+
+func (t *textReader) skipSpace() (byte, error) {
+	for {
+		c, err := t.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !unicode.IsSpace(rune(c)) {
+			return c, nil
+		}
+	}
+}
+
+func (t *textReader) expect(want byte) error {
+	c, err := t.skipSpace()
+	if err != nil || c != want {
+		return fmt.Errorf("%w: 应该以 %q 开头", ErrFormat, want)
+	}
+	return nil
+}
+
+// next 读到逗号或者 end 为止，end 为 true 表示读到了结束符
+// 元素前后可以有空白，中间不行："1 2" 不能当成 12
+func (t *textReader) next(end byte) (tok string, atEnd bool, err error) {
+	c, err := t.skipSpace()
+	var b []byte
+	for err == nil && c != ',' && c != end {
+		if unicode.IsSpace(rune(c)) {
+			if c, err = t.skipSpace(); err == nil && c != ',' && c != end {
+				return "", false, fmt.Errorf("%w: %q 后面缺少逗号", ErrFormat, b)
+			}
+			break
+		}
+		b = append(b, c)
+		c, err = t.r.ReadByte()
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("%w: 数据提前结束", ErrFormat)
+	}
+	return string(b), c == end, nil
+}
+
+// node 先序格式没有结束符，读完一个元素就停，不多读下一个逗号
+func (t *textReader) node() (*TreeNode, error) {
+	c, err := t.skipSpace()
+	if err == nil && t.count > 0 {
+		if c != ',' {
+			return nil, fmt.Errorf("%w: 缺少逗号", ErrFormat)
+		}
+		c, err = t.skipSpace()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: 数据提前结束", ErrFormat)
+	}
+	t.count++
+	if c == '#' {
+		return nil, nil
+	}
+	b := []byte{c}
+	for {
+		c, err := t.r.ReadByte()
+		if err != nil {
+			break
+		}
+		if c < '0' || c > '9' {
+			t.r.UnreadByte()
+			break
+		}
+		b = append(b, c)
+	}
+	v, err := strconv.Atoi(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: 节点值 %q", ErrFormat, b)
+	}
+	return &TreeNode{Val: v}, nil
+}
@@ -3,11 +3,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 )
 
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../queue(环形队列)/ring.go
+
 type TreeNode struct {
 	Val   int
 	Left  *TreeNode
@@ -51,80 +52,99 @@ func convertBST(root *TreeNode) *TreeNode {
 // S(n)+1 = 2^(n)
 // n =  ln(S(n)+1)/ ln2 = Log2(S(n)+1)
 
-func transportCase2BSTTree(caseStr string) (root *TreeNode) {
-	caseStr = strings.ReplaceAll(strings.ReplaceAll(caseStr, "[", ""), "]", "")
+// 上面的推导是原来按满二叉树解析用的：n 个元素有 Log2(n+1) 层，第 i 层是下标 [2^(i-1), 2^i-1]。
+// LeetCode 的格式里空节点的孩子不占位置，按层算下标会错位，已经换成下面按非空节点分配孩子的写法，推导留着。
+
+// transportCase2BSTTree 解析 LeetCode 的层序格式，比如 [4,1,6,0,2,5,7,null,null,null,3]
+// 第 k 个非空节点的孩子是后面的第 2k+1、2k+2 个元素，空节点不占孩子的位置
+func transportCase2BSTTree(caseStr string) (root *TreeNode, err error) {
+	caseStr = strings.TrimSpace(caseStr)
+	if !strings.HasPrefix(caseStr, "[") || !strings.HasSuffix(caseStr, "]") {
+		return nil, fmt.Errorf("应该是 [...] 格式: %q", caseStr)
+	}
+	caseStr = caseStr[1 : len(caseStr)-1]
+	if strings.TrimSpace(caseStr) == "" {
+		return nil, nil
+	}
 	nodes := strings.Split(caseStr, ",")
-	Sn := len(nodes)
-	if Sn < 1 || nodes[0] == "null" {
-		return &TreeNode{}
+	rootVal, err := transportElement(nodes[0])
+	if err != nil {
+		return nil, err
 	}
-	root = &TreeNode{Val: *transportElement(nodes[0])}
-	dpath := math.Ceil(math.Log2(float64(Sn + 1)))
-	stack := []*TreeNode{root}
-	for i := 2; i <= int(dpath); i++ {
-		l := 1 << (i - 1)
-		r := 1<<(i) - 1
-		for len(stack) != 0 && l < r {
-			par := stack[0]
-			stack = stack[1:]
-			var lPtr, rPtr *int
-			if len(nodes) > l {
-				lPtr = transportElement(nodes[l-1])
-				rPtr = transportElement(nodes[l])
-			}
-			if lPtr != nil {
-				par.Left = &TreeNode{Val: *lPtr}
-				stack = append(stack, par.Left)
-			}
-			if rPtr != nil {
-				par.Right = &TreeNode{Val: *rPtr}
-				stack = append(stack, par.Right)
-			}
-			l += 2
+	if rootVal == nil {
+		return nil, fmt.Errorf("根节点不能是 null: %q", caseStr)
+	}
+	root = &TreeNode{Val: *rootVal}
+	queue := NewQueue[*TreeNode](len(nodes))
+	queue.Push(root)
+	for i := 1; i < len(nodes); i++ {
+		if queue.Len() == 0 {
+			return nil, fmt.Errorf("第 %d 个元素没有父节点", i)
+		}
+		v, err := transportElement(nodes[i])
+		if err != nil {
+			return nil, err
+		}
+		var node *TreeNode
+		if v != nil {
+			node = &TreeNode{Val: *v}
+			queue.Push(node)
+		}
+		if i%2 == 1 {
+			queue.Peek().Left = node
+		} else {
+			queue.Pop().Right = node
 		}
 	}
-	return
+	return root, nil
 }
-func transportElement(str string) *int {
+
+// transportElement 解析一个元素，null 返回 nil，不是整数时报错
+func transportElement(str string) (*int, error) {
+	str = strings.TrimSpace(str)
 	if str == "null" {
-		return nil
+		return nil, nil
 	}
 	i, err := strconv.Atoi(str)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("不是整数也不是 null: %q", str)
 	}
-	return &i
+	return &i, nil
 }
+
+// bst2Array 输出 LeetCode 的层序格式：只有非空节点的孩子占位置，末尾的 null 去掉
 func bst2Array(root *TreeNode) (arr []*int) {
 	arr = []*int{}
 	if root == nil {
 		return
 	}
 	arr = append(arr, &root.Val)
-	stack := []*TreeNode{root}
-	for len(stack) != 0 {
-		par := stack[0]
-		stack = stack[1:]
-		if par == nil {
-			arr = append(arr, nil, nil)
-			continue
-		}
+	var stack Queue[*TreeNode]
+	stack.Push(root)
+	for stack.Len() != 0 {
+		par := stack.Pop()
 		addValOrNil(par.Left, &arr, &stack)
 		addValOrNil(par.Right, &arr, &stack)
 	}
-	// 把最后一层截掉
-	return arr[:(1<<(int(math.Log2(float64(len(arr)+1)))-1))-1]
+	for arr[len(arr)-1] == nil {
+		arr = arr[:len(arr)-1]
+	}
+	return
 }
-func addValOrNil(node *TreeNode, arr *[]*int, stack *[]*TreeNode) {
-	*stack = append(*stack, node)
+func addValOrNil(node *TreeNode, arr *[]*int, stack *Queue[*TreeNode]) {
 	if node == nil {
 		*arr = append(*arr, nil)
 	} else {
 		*arr = append(*arr, &node.Val)
+		stack.Push(node)
 	}
 }
 func main() {
-	root := transportCase2BSTTree(`[4,1,6,0,2,5,7,null,null,null,3,null,null,null,8]`)
+	root, err := transportCase2BSTTree(`[4,1,6,0,2,5,7,null,null,null,3,null,null,null,8]`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 	arrBefore := bst2Array(root)
 	b, _ := json.Marshal(arrBefore)
 	fmt.Printf("%s\n", string(b))
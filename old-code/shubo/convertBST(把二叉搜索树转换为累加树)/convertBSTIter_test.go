@@ -0,0 +1,37 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -o traverse_test.go -- ../tree(二叉树遍历)/traverse.go
+
+// 反向中序从大到小累加
+func convertBSTIter(root *TreeNode) *TreeNode {
+	sum := 0
+	for n := range ReverseInOrder(root) {
+		sum += n.Val
+		n.Val = sum
+	}
+	return root
+}
+
+// randomTree n 个节点的随机形状，值在 [0, 10)
+func randomTree(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	k := r.Intn(n)
+	return &TreeNode{Val: r.Intn(10), Left: randomTree(r, k), Right: randomTree(r, n-1-k)}
+}
+
+func TestConvertBSTIter(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		a := convertBSTIter(randomTree(rand.New(rand.NewSource(seed)), int(seed%20)))
+		b := convertBST(randomTree(rand.New(rand.NewSource(seed)), int(seed%20)))
+		if x, y := toJSON(bst2Array(a)), toJSON(bst2Array(b)); x != y {
+			t.Fatalf("seed=%d: %s != %s", seed, x, y)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTransportCase2BSTTree(t *testing.T) {
+	for _, s := range []string{"[]", "[2,1]", "[1,null,2]", "[2,null,3,1]", "[4,1,6,0,2,5,7,null,null,null,3,null,null,null,8]"} {
+		root, err := transportCase2BSTTree(s)
+		if err != nil {
+			t.Fatal(s, err)
+		}
+		if got := toJSON(bst2Array(root)); got != s {
+			t.Fatalf("%s -> %s", s, got)
+		}
+	}
+	for _, s := range []string{"", "1,2", "[null]", "[1,x]", "[1,,2]", "[1,null,null,2]"} {
+		if _, err := transportCase2BSTTree(s); err == nil {
+			t.Fatalf("%q 应该报错", s)
+		}
+	}
+}
+
+// go test -fuzz FuzzTransportCase2BSTTree
+// 解析成功时：每个非 null 元素都要变成一个节点，打印出来再解析要得到同一棵树
+func FuzzTransportCase2BSTTree(f *testing.F) {
+	for _, s := range []string{"[]", "[2,1]", "[1,null,2]", "[2,null,3,1]", "[1,x]", " [ 3 , 9 ] ",
+		"[4,1,6,0,2,5,7,null,null,null,3,null,null,null,8]"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		root, err := transportCase2BSTTree(s)
+		if err != nil {
+			return
+		}
+		want := 0
+		for _, tok := range strings.Split(strings.TrimSpace(s), ",") {
+			if tok = strings.Trim(strings.TrimSpace(tok), "[]"); strings.TrimSpace(tok) != "" && strings.TrimSpace(tok) != "null" {
+				want++
+			}
+		}
+		if count(root) != want {
+			t.Fatalf("%q 有 %d 个节点，解析出 %d 个", s, want, count(root))
+		}
+		out := toJSON(bst2Array(root))
+		again, err := transportCase2BSTTree(out)
+		if err != nil {
+			t.Fatalf("%q -> %s 解析失败: %v", s, out, err)
+		}
+		if toJSON(bst2Array(again)) != out {
+			t.Fatalf("%q -> %s -> %s", s, out, toJSON(bst2Array(again)))
+		}
+	})
+}
+
+func toJSON(arr []*int) string {
+	b, _ := json.Marshal(arr)
+	return string(b)
+}
+
+func count(root *TreeNode) int {
+	if root == nil {
+		return 0
+	}
+	return 1 + count(root.Left) + count(root.Right)
+}
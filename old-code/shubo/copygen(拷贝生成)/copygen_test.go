@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src", "meta.go")
+	if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("package main\n\nvar x = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := generate(src, filepath.Join(dir, "meta.go"), ""); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "meta.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, ok := generatedFrom(b)
+	if !ok || from != filepath.ToSlash(src) || !strings.HasSuffix(string(b), "\n\npackage main\n\nvar x = 1\n") {
+		t.Fatalf("%s\n%s", from, b)
+	}
+	// 生成的文件不能再拿来拷贝
+	if err := generate(filepath.Join(dir, "meta.go"), filepath.Join(t.TempDir(), "meta.go"), ""); err == nil {
+		t.Fatal("从生成的文件拷贝了")
+	}
+}
+
+func TestGeneratePackage(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src", "stack.go")
+	if err := os.MkdirAll(filepath.Dir(src), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// 注释里的 package 不能被换掉
+	if err := os.WriteFile(src, []byte("// package monotonic 单调栈\npackage monotonic\n\nvar x = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// 换了文件名，文件头里还是原文件
+	if err := generate(src, filepath.Join(dir, "stack_test.go"), "main"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "stack_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from, _ := generatedFrom(b); from != filepath.ToSlash(src) || !strings.HasSuffix(string(b), "\n\n// package monotonic 单调栈\npackage main\n\nvar x = 1\n") {
+		t.Fatalf("%s", b)
+	}
+}
+
+// TestUpToDate 生成的文件都要和原文件一样，原文件改了忘了 go generate 时这里会失败
+// 查所有作者目录下的题解（songzhibin97 也用了 shubo 的库），还有 shubo 工具目录的子目录
+func TestUpToDate(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{"../../*/*/*.go", "../*/*/*.go"} {
+		m, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, m...)
+	}
+	n := 0
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, ok := generatedFrom(b)
+		if !ok {
+			continue
+		}
+		n++
+		src, err := os.ReadFile(filepath.Join(filepath.Dir(f), filepath.FromSlash(from)))
+		if err != nil {
+			t.Error(f, err)
+			continue
+		}
+		// 生成时可能用 -pkg 换了包名，比较前按生成的文件换回来
+		if pkg := packageOf(b); pkg != packageOf(src) {
+			if src, err = setPackage(src, pkg); err != nil {
+				t.Error(f, err)
+				continue
+			}
+		}
+		if want := append([]byte(header(from)), src...); !bytes.Equal(b, want) {
+			t.Errorf("%s 和 %s 不一样了，在 %s 下运行 go generate", f, from, filepath.Dir(f))
+		}
+	}
+	if n == 0 {
+		t.Fatal("没有找到生成的文件")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// 几个工具目录共用的文件（题目元数据、目录解析、LeetCode 格式的参数解析）只在一个地方维护，
+// 用到的目录通过 go:generate 拷贝一份，文件头标明出处，改的时候改原文件再重新生成：
+//
+//	//go:generate go run ../copygen(拷贝生成)/main.go -- ../judge(本地判题服务)/parse.go
+//
+// -- 不能省，不然 go run 会把后面的 .go 文件当成要编译的源文件。
+// 从库目录（比如 package monotonic）拷到 package main 的目录时加 -pkg main，只改 package 那一行：
+//
+//	//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../monotonic(单调栈)/stack.go
+//
+// 生成的文件放在当前目录，文件名和原文件一样。只拷一个文件时可以用 -o 换个名字，
+// 比如只有测试用到的拷成 _test.go，或者原文件叫 main.go 和当前目录的撞了：
+//
+//	//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -o traverse_test.go -- ../tree(二叉树遍历)/traverse.go
+//
+// 没有 go.mod，目录之间不能互相 import，只能拷贝。
+// 别的作者的目录也可以用，比如 songzhibin97/课程表 用的环形队列：
+//
+//	//go:generate go run ../../shubo/copygen(拷贝生成)/main.go -pkg main -- ../../shubo/queue(环形队列)/ring.go
+//
+// copygen_test.go 检查所有生成的文件和原文件还是一样的。
+func main() {
+	pkg := flag.String("pkg", "", "生成的文件改用这个包名，为空则和原文件一样")
+	out := flag.String("o", "", "生成的文件名，为空则和原文件一样，只能拷一个文件时用")
+	flag.Parse()
+	if flag.NArg() == 0 || (*out != "" && flag.NArg() != 1) {
+		fmt.Fprintln(os.Stderr, "用法: go run ../copygen(拷贝生成)/main.go [-pkg main] [-o 文件名] -- 原文件...")
+		os.Exit(2)
+	}
+	for _, src := range flag.Args() {
+		name := *out
+		if name == "" {
+			name = filepath.Base(src)
+		}
+		if err := generate(src, filepath.Join(".", name), *pkg); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// headerPattern 生成的文件的第一行，记下原文件相对生成目录的路径
+var headerPattern = regexp.MustCompile(`^// Code generated by copygen\(拷贝生成\) from (\S+); DO NOT EDIT\.$`)
+
+func header(src string) string {
+	return fmt.Sprintf("// Code generated by copygen(拷贝生成) from %s; DO NOT EDIT.\n\n", filepath.ToSlash(src))
+}
+
+// generate 把 src 拷贝到 dst，加上文件头；src 本身是生成的文件时拒绝，只从原文件拷贝
+// pkg 不为空时把 package 那一行换成 pkg
+func generate(src, dst, pkg string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if _, ok := generatedFrom(b); ok {
+		return fmt.Errorf("%s 是生成的文件，应该从它的原文件拷贝", src)
+	}
+	if pkg != "" {
+		if b, err = setPackage(b, pkg); err != nil {
+			return fmt.Errorf("%s: %w", src, err)
+		}
+	}
+	return os.WriteFile(dst, append([]byte(header(src)), b...), 0o644)
+}
+
+var packagePattern = regexp.MustCompile(`(?m)^package (\w+)$`)
+
+// packageOf 返回第一个 package 子句里的包名
+func packageOf(b []byte) string {
+	m := packagePattern.FindSubmatch(b)
+	if m == nil {
+		return ""
+	}
+	return string(m[1])
+}
+
+// setPackage 把第一个 package 子句换成 pkg，注释里的 package 不算
+func setPackage(b []byte, pkg string) ([]byte, error) {
+	loc := packagePattern.FindSubmatchIndex(b)
+	if loc == nil {
+		return nil, fmt.Errorf("没有 package 子句")
+	}
+	return append(append(append([]byte{}, b[:loc[2]]...), pkg...), b[loc[3]:]...), nil
+}
+
+// generatedFrom 生成的文件返回原文件的路径（相对生成的文件所在的目录）
+func generatedFrom(b []byte) (string, bool) {
+	line, _, _ := bytes.Cut(b, []byte("\n"))
+	m := headerPattern.FindSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	return string(m[1]), true
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 每个题目、每个作者的状态
+const (
+	StatusMissing   = "缺失"
+	StatusBroken    = "编译失败"
+	StatusCompiles  = "能编译" // 没有测试，或者 README 的代码没有判题用例
+	StatusFailing   = "测试失败"
+	StatusPasses    = "测试通过"
+	StatusUnchecked = "未检查" // -check=false 时只看目录在不在
+)
+
+// rank 一个作者同一道题有多个目录时取最好的那个
+var rank = map[string]int{
+	StatusMissing:   0,
+	StatusBroken:    1,
+	StatusFailing:   2,
+	StatusUnchecked: 3,
+	StatusCompiles:  3,
+	StatusPasses:    4,
+}
+
+var statuses = []string{StatusPasses, StatusCompiles, StatusFailing, StatusBroken, StatusUnchecked, StatusMissing}
+
+type Options struct {
+	Root    string        // old-code 目录
+	Readme  string        // readme(README题解验证) 工具的目录，检查只有 README 的题解
+	Timeout time.Duration // 每个目录 go test 的超时
+	Jobs    int
+}
+
+// Check 填上每个题解目录的状态。有 go 文件的目录先编译（带上测试文件），有测试再跑测试；
+// 只有 README.md 的目录交给 readme 工具，它会把代码块抠出来编译，再用判题用例跑
+func Check(dirs []*Dir, opt Options) error {
+	var goDirs []*Dir
+	readmes := map[string][]*Dir{} // 作者 -> 只有 README 的目录
+	for _, d := range dirs {
+		switch {
+		case d.ID == "":
+		case len(d.goFiles)+len(d.testFiles) > 0:
+			goDirs = append(goDirs, d)
+		case d.readme:
+			readmes[d.Author] = append(readmes[d.Author], d)
+		default:
+			d.Status, d.Detail = StatusMissing, "目录里没有代码"
+		}
+	}
+
+	jobs := make(chan *Dir)
+	var wg sync.WaitGroup
+	for range max(opt.Jobs, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for d := range jobs {
+				checkGo(d, filepath.Join(opt.Root, filepath.FromSlash(d.Path)), opt.Timeout)
+			}
+		}()
+	}
+	for _, d := range goDirs {
+		jobs <- d
+	}
+	close(jobs)
+	wg.Wait()
+
+	for author, ds := range readmes {
+		if err := checkREADMEs(opt.Readme, filepath.Join(opt.Root, author), ds); err != nil {
+			return fmt.Errorf("检查 %s 的 README 失败: %w", author, err)
+		}
+	}
+	return nil
+}
+
+func checkGo(d *Dir, dir string, timeout time.Duration) {
+	files := append(append([]string{}, d.goFiles...), d.testFiles...)
+	// -run '^$' 只编译不跑，没有测试文件时 go test 也会编译包
+	if out, err := goTest(dir, timeout, append([]string{"-vet=off", "-run", "^$"}, files...)); err != nil {
+		d.Status, d.Detail = StatusBroken, firstLines(out, err, 5)
+		return
+	}
+	if len(d.testFiles) == 0 {
+		d.Status = StatusCompiles
+		return
+	}
+	if out, err := goTest(dir, timeout, append([]string{"-timeout", timeout.String()}, files...)); err != nil {
+		d.Status, d.Detail = StatusFailing, failLines(out, err)
+		return
+	}
+	d.Status = StatusPasses
+}
+
+func goTest(dir string, timeout time.Duration, args []string) (string, error) {
+	// 测试自己的 -timeout 会先触发，这里多留一点给编译
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+time.Minute)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "go", append([]string{"test"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func firstLines(out string, err error, n int) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) == 0 || lines[0] == "" {
+		return err.Error()
+	}
+	return strings.Join(lines[:min(n, len(lines))], "\n")
+}
+
+// failLines 只留下 --- FAIL 和 panic 那几行
+func failLines(out string, err error) string {
+	var keep []string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "--- FAIL") || strings.HasPrefix(line, "panic:") {
+			keep = append(keep, line)
+		}
+	}
+	if len(keep) == 0 {
+		return firstLines(out, err, 5)
+	}
+	return strings.Join(keep, "\n")
+}
+
+// readme 工具 -format json 的输出，只取用得到的字段
+type readmeReport struct {
+	Path   string `json:"path"`
+	Blocks []struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	} `json:"blocks"`
+}
+
+// readme 工具的代码块状态 -> 这里的状态
+var blockStatus = map[string]string{
+	"通过":    StatusPasses,
+	"未通过":   StatusFailing,
+	"未判题":   StatusCompiles,
+	"签名不匹配": StatusCompiles,
+	"编译失败":  StatusBroken,
+	"解析失败":  StatusBroken,
+	"空代码块":  StatusMissing,
+}
+
+func checkREADMEs(tool, root string, dirs []*Dir) error {
+	entries, err := os.ReadDir(tool)
+	if err != nil {
+		return err
+	}
+	args := []string{"run"}
+	for _, e := range entries {
+		if name := e.Name(); strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			args = append(args, name)
+		}
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("go", append(args, "-root", abs, "-format", "json")...)
+	cmd.Dir = tool
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("%v\n%s", err, stderr.String())
+	}
+	var reports []readmeReport
+	if err := json.Unmarshal(out, &reports); err != nil {
+		return err
+	}
+	byPath := map[string]readmeReport{}
+	for _, r := range reports {
+		byPath[path.Dir(filepath.ToSlash(r.Path))] = r
+	}
+	for _, d := range dirs {
+		r, ok := byPath[strings.TrimPrefix(d.Path, d.Author+"/")]
+		if !ok || len(r.Blocks) == 0 {
+			d.Status, d.Detail = StatusMissing, "README 里没有 go 代码"
+			continue
+		}
+		d.Status = StatusMissing
+		for _, b := range r.Blocks {
+			if s := blockStatus[b.Status]; rank[s] > rank[d.Status] {
+				d.Status, d.Detail = s, b.Error
+			}
+		}
+		if d.Status == StatusMissing {
+			d.Detail = "README 里只有空的 go 代码块"
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func loadProblems(t *testing.T) map[string]Problem {
+	problems, err := LoadProblems("../../../docs/leetcode-hot-100.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return problems
+}
+
+func TestResolve(t *testing.T) {
+	r := NewResolver(loadProblems(t))
+	cases := []struct {
+		name, id, by string
+	}{
+		{"121. 买卖股票的最佳时机", "121", ByID},
+		{"208. 实现 Trie (前缀树)", "208", ByID},
+		{"买卖股票的最佳时机", "121", ByTitle},
+		{"环形链表II", "142", ByTitle},
+		{"搜索二维矩阵 II", "240", ByTitle},
+		{"maxProfit(买卖股票的最佳时机)", "121", ByTitle},
+		{"rob(打家劫舍III)", "337", ByTitle},
+		{"LRUCache(LRU缓存)", "146", ByTitle},
+		{"findAnagrams(找字符串中所有字母异位词)", "438", ByFunc},
+		{"subarraySum(和为K的子树组个数)", "560", ByFunc},
+		{"trie(前缀树)", "208", ByFunc},
+		{"maxProfit(最佳买卖股票时机含冷冻期)", "309", ByFuncTitle},
+		{"rob(小偷)", "198", ByFuncTitle},
+	}
+	for _, c := range cases {
+		id, by, ok := r.Resolve(c.name)
+		if !ok || id != c.id || by != c.by {
+			t.Fatalf("%s: %s %s %v", c.name, id, by, ok)
+		}
+	}
+	// 不在 hot 100 里的题、工具目录
+	for _, name := range []string{"24. 两两交换链表中的节点", "从中序与后序遍历序列构造二叉树", "printBin(double转二进制字符串)", "judge(本地判题服务)", "链表"} {
+		if id, _, ok := r.Resolve(name); ok {
+			t.Fatal(name, id)
+		}
+	}
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, src := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const climbStairs = "func climbStairs(n int) int {\n\ta, b := 1, 1\n\tfor i := 1; i < n; i++ {\n\t\ta, b = b, a+b\n\t}\n\treturn b\n}\n"
+
+// tree 三种命名方式、各种状态都有的目录树
+func tree(t *testing.T) string {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/climbStairs(爬楼梯)/climbStairs.go":  "package main\n\n" + climbStairs,
+		"a/climbStairs(爬楼梯)/climb_test.go":   "package main\n\nimport \"testing\"\n\nfunc TestClimb(t *testing.T) {\n\tif climbStairs(5) != 8 {\n\t\tt.Fatal()\n\t}\n}\n",
+		"a/twoSum(两数之和)/twoSum_test.go":      "package main\n\nimport \"testing\"\n\nfunc TestTwoSum(t *testing.T) {\n\tt.Fatal(\"错了\")\n}\n",
+		"a/isValid(有效的括号)/isValid.go":        "package main\n\nfunc isValid(s string) bool {\n\treturn len(s) %\n}\n",
+		"a/isValid(有效的括号)/other_js.go":       "package main\n\nfunc onlyJS() {}\n",
+		"a/tool(工具)/main.go":                 "package main\n\nfunc main() {}\n",
+		"a/trie(泛型前缀树)/trie.go":              "package trie\n\ntype Trie struct{}\n",
+		"b/爬楼梯/main.go":                      "package main\n\nfunc main() {}\n",
+		"b/二叉树的中序遍历/README.md":               "没有代码\n",
+		"c/动态规划/70. 爬楼梯/README.md":           "# 70. 爬楼梯\n\n```go\n" + climbStairs + "```\n",
+		"c/动态规划/README.md":                   "分类说明\n",
+		"c/哈希/1. 两数之和/README.md":             "# 1. 两数之和\n\n思路\n",
+		"c/链表/24. 两两交换链表中的节点/README.md":      "不在 hot 100\n",
+		"c/链表/206. 反转链表/README.assets/1.png": "",
+	})
+	return root
+}
+
+func TestScan(t *testing.T) {
+	dirs, err := Scan(tree(t), NewResolver(loadProblems(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, d := range dirs {
+		got[d.Path] = d.ID + " " + d.By
+	}
+	want := map[string]string{
+		"a/climbStairs(爬楼梯)":  "70 标题",
+		"a/isValid(有效的括号)":    "20 标题",
+		"a/tool(工具)":          " ",
+		"a/trie(泛型前缀树)":       " ",
+		"a/twoSum(两数之和)":      "1 标题",
+		"b/二叉树的中序遍历":          "94 标题",
+		"b/爬楼梯":               "70 标题",
+		"c/动态规划/70. 爬楼梯":      "70 题号",
+		"c/哈希/1. 两数之和":        "1 题号",
+		"c/链表/206. 反转链表":      "206 题号",
+		"c/链表/24. 两两交换链表中的节点": " ",
+	}
+	if len(got) != len(want) {
+		t.Fatal(got)
+	}
+	for p, w := range want {
+		if got[p] != w {
+			t.Fatalf("%s: %q", p, got[p])
+		}
+	}
+	for _, d := range dirs {
+		if d.Path == "a/isValid(有效的括号)" && strings.Join(d.goFiles, ",") != "isValid.go" {
+			t.Fatal("other_js.go 只在 js 下编译", d.goFiles)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("要调用 go test")
+	}
+	root := tree(t)
+	problems := loadProblems(t)
+	dirs, err := Scan(root, NewResolver(problems))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Check(dirs, Options{Root: root, Readme: "../readme(README题解验证)", Timeout: time.Minute, Jobs: 4}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"a/climbStairs(爬楼梯)": StatusPasses,
+		"a/isValid(有效的括号)":   StatusBroken,
+		"a/tool(工具)":         "",
+		"a/twoSum(两数之和)":     StatusFailing,
+		"b/二叉树的中序遍历":         StatusMissing,
+		"b/爬楼梯":              StatusCompiles,
+		"c/动态规划/70. 爬楼梯":     StatusPasses,
+		"c/哈希/1. 两数之和":       StatusMissing,
+		"c/链表/206. 反转链表":     StatusMissing,
+	}
+	for _, d := range dirs {
+		if w, ok := want[d.Path]; ok && d.Status != w {
+			t.Fatalf("%s: %s %s", d.Path, d.Status, d.Detail)
+		}
+		if d.Path == "a/twoSum(两数之和)" && !strings.Contains(d.Detail, "--- FAIL: TestTwoSum") {
+			t.Fatal(d.Detail)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	problems := loadProblems(t)
+	dirs := []*Dir{
+		{Author: "a", Path: "a/x", ID: "70", Status: StatusBroken},
+		{Author: "a", Path: "a/y", ID: "70", Status: StatusPasses},
+		{Author: "a", Path: "a/z", ID: "1", Status: StatusMissing},
+		{Author: "a", Path: "a/tool"},
+		{Author: "b", Path: "b/爬楼梯", ID: "70", Status: StatusCompiles},
+	}
+	c := Build(problems, dirs)
+	if strings.Join(c.Authors, ",") != "a,b" || len(c.Problems) != 100 || c.Problems[0].ID != "1" {
+		t.Fatal(c.Authors, len(c.Problems))
+	}
+	if len(c.Unresolved) != 1 || c.Unresolved[0].Path != "a/tool" {
+		t.Fatal(c.Unresolved)
+	}
+	if c.Totals["a"][StatusPasses] != 1 || c.Totals["a"][StatusMissing] != 99 || c.Totals["b"][StatusCompiles] != 1 {
+		t.Fatal(c.Totals)
+	}
+	var row Row
+	for _, r := range c.Problems {
+		if r.ID == "70" {
+			row = r
+		}
+	}
+	if row.Cells["a"].Status != StatusPasses || len(row.Cells["a"].Dirs) != 2 {
+		t.Fatal(row.Cells)
+	}
+	// 70 有 动态规划 标签，题多的标签排前面
+	for i, tag := range c.Tags {
+		if i > 0 && len(tag.Problems) > len(c.Tags[i-1].Problems) {
+			t.Fatal("标签没按题数排序")
+		}
+		if tag.Slug == "dynamic-programming" && (tag.Counts["a"][StatusPasses] != 1 || tag.Counts["b"][StatusCompiles] != 1) {
+			t.Fatal(tag.Counts)
+		}
+	}
+
+	md := c.Markdown()
+	for _, s := range []string{
+		"| a | 1 | 0 | 0 | 0 | 99 |",
+		"| 动态规划 | 29 | 1/29（通过 1） | 1/29（通过 0） |",
+		"| 70 | [爬楼梯](https://leetcode.cn/problems/climbing-stairs/) | EASY | 记忆化搜索、数学、动态规划 | 测试通过 | 能编译 |",
+		"| 1 | [两数之和](https://leetcode.cn/problems/two-sum/) | EASY | 数组、哈希表 | 缺失 | — |",
+		"- a/tool\n",
+	} {
+		if !strings.Contains(md, s) {
+			t.Fatalf("缺少 %q\n%s", s, md)
+		}
+	}
+}
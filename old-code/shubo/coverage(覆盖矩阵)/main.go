@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../storyboard(分镜生成)/meta.go
+
+// 把三个人的目录都对到 hot 100 的题号上，输出 题目 × 作者 的覆盖矩阵
+// go run $(ls *.go | grep -v _test) -format md > coverage.md
+func main() {
+	root := flag.String("root", "../..", "old-code 目录，下面每个子目录是一个作者")
+	metaPath := flag.String("meta", "../../../docs/leetcode-hot-100.json", "题目元数据")
+	readme := flag.String("readme", "../readme(README题解验证)", "readme 工具的目录，用来检查只有 README 的题解")
+	check := flag.Bool("check", true, "编译、跑测试；false 时只看目录在不在")
+	format := flag.String("format", "md", "输出格式：md 或 json")
+	timeout := flag.Duration("timeout", 5*time.Minute, "每个目录 go test 的超时")
+	jobs := flag.Int("j", runtime.NumCPU(), "同时检查的目录数")
+	flag.Parse()
+
+	problems, err := LoadProblems(*metaPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "读取题目元数据失败:", err)
+		os.Exit(1)
+	}
+	dirs, err := Scan(*root, NewResolver(problems))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *check {
+		if err := Check(dirs, Options{Root: *root, Readme: *readme, Timeout: *timeout, Jobs: *jobs}); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else {
+		for _, d := range dirs {
+			if d.ID != "" {
+				d.Status = StatusUnchecked
+			}
+		}
+	}
+
+	c := Build(problems, dirs)
+	switch *format {
+	case "json":
+		b, _ := json.MarshalIndent(c, "", "  ")
+		fmt.Println(string(b))
+	default:
+		fmt.Print(c.Markdown())
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Cell 一个作者在一道题上的状态，多个目录取最好的
+type Cell struct {
+	Status string   `json:"status"`
+	Dirs   []string `json:"dirs,omitempty"`
+}
+
+type Row struct {
+	ID         string          `json:"id"`
+	Title      string          `json:"title"`
+	Slug       string          `json:"slug"`
+	Difficulty string          `json:"difficulty"`
+	Tags       []string        `json:"tags"` // 标签 slug
+	Cells      map[string]Cell `json:"cells"`
+}
+
+// TagSummary 一个标签下的题目，每个作者各状态的题数
+type TagSummary struct {
+	Slug     string                    `json:"slug"`
+	Name     string                    `json:"name"`
+	Problems []string                  `json:"problems"`
+	Counts   map[string]map[string]int `json:"counts"` // 作者 -> 状态 -> 题数
+}
+
+type Coverage struct {
+	Authors    []string                  `json:"authors"`
+	Totals     map[string]map[string]int `json:"totals"` // 作者 -> 状态 -> 题数
+	Problems   []Row                     `json:"problems"`
+	Tags       []TagSummary              `json:"tags"`
+	Dirs       []*Dir                    `json:"dirs"`
+	Unresolved []*Dir                    `json:"unresolved"` // 解析不出题号：工具、库、不在 hot 100 的题
+}
+
+// Build 按题号排好题目，汇总每个作者、每个标签的覆盖情况
+func Build(problems map[string]Problem, dirs []*Dir) *Coverage {
+	c := &Coverage{Totals: map[string]map[string]int{}, Dirs: dirs, Unresolved: []*Dir{}}
+	cells := map[string]map[string]Cell{} // 题号 -> 作者 -> 状态
+	for _, d := range dirs {
+		if _, ok := c.Totals[d.Author]; !ok {
+			c.Authors = append(c.Authors, d.Author)
+			c.Totals[d.Author] = map[string]int{}
+		}
+		if d.ID == "" {
+			c.Unresolved = append(c.Unresolved, d)
+			continue
+		}
+		if cells[d.ID] == nil {
+			cells[d.ID] = map[string]Cell{}
+		}
+		cell, ok := cells[d.ID][d.Author]
+		if !ok || rank[d.Status] > rank[cell.Status] {
+			cell.Status = d.Status
+		}
+		cell.Dirs = append(cell.Dirs, d.Path)
+		cells[d.ID][d.Author] = cell
+	}
+	sort.Strings(c.Authors)
+
+	ids := make([]string, 0, len(problems))
+	for id := range problems {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return idLess(ids[i], ids[j]) })
+
+	tags := map[string]*TagSummary{}
+	for _, id := range ids {
+		p := problems[id]
+		row := Row{ID: id, Title: p.TranslatedTitle, Slug: p.TitleSlug, Difficulty: p.Difficulty, Cells: map[string]Cell{}}
+		for _, a := range c.Authors {
+			cell, ok := cells[id][a]
+			if !ok {
+				cell.Status = StatusMissing
+			}
+			row.Cells[a] = cell
+			c.Totals[a][cell.Status]++
+		}
+		for _, t := range p.TopicTags {
+			row.Tags = append(row.Tags, t.Slug)
+			ts, ok := tags[t.Slug]
+			if !ok {
+				ts = &TagSummary{Slug: t.Slug, Name: t.NameTranslated, Counts: map[string]map[string]int{}}
+				for _, a := range c.Authors {
+					ts.Counts[a] = map[string]int{}
+				}
+				tags[t.Slug] = ts
+			}
+			ts.Problems = append(ts.Problems, id)
+			for _, a := range c.Authors {
+				ts.Counts[a][row.Cells[a].Status]++
+			}
+		}
+		c.Problems = append(c.Problems, row)
+	}
+	for _, ts := range tags {
+		c.Tags = append(c.Tags, *ts)
+	}
+	// 题多的标签排前面
+	sort.Slice(c.Tags, func(i, j int) bool {
+		if len(c.Tags[i].Problems) != len(c.Tags[j].Problems) {
+			return len(c.Tags[i].Problems) > len(c.Tags[j].Problems)
+		}
+		return c.Tags[i].Slug < c.Tags[j].Slug
+	})
+	return c
+}
+
+func idLess(a, b string) bool {
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+	return x < y
+}
+
+// covered 目录存在的题数，summary 形如 "12/40（通过 3）"
+func summary(counts map[string]int, total int) string {
+	return fmt.Sprintf("%d/%d（通过 %d）", total-counts[StatusMissing], total, counts[StatusPasses])
+}
+
+func (c *Coverage) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# Hot 100 覆盖情况\n\n")
+	b.WriteString("状态：测试通过 > 能编译（没有测试或判题用例）> 测试失败 > 编译失败 > 缺失，一道题有多个目录时取最好的。\n\n")
+
+	b.WriteString("| 作者 |")
+	for _, s := range statuses {
+		if s != StatusUnchecked || c.has(StatusUnchecked) {
+			b.WriteString(" " + s + " |")
+		}
+	}
+	b.WriteString("\n|---|")
+	for _, s := range statuses {
+		if s != StatusUnchecked || c.has(StatusUnchecked) {
+			b.WriteString("---|")
+		}
+	}
+	b.WriteString("\n")
+	for _, a := range c.Authors {
+		b.WriteString("| " + a + " |")
+		for _, s := range statuses {
+			if s != StatusUnchecked || c.has(StatusUnchecked) {
+				fmt.Fprintf(&b, " %d |", c.Totals[a][s])
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n## 按标签\n\n每格是 有题解的题数/标签下的题数（测试通过的题数）。\n\n| 标签 | 题数 |")
+	for _, a := range c.Authors {
+		b.WriteString(" " + a + " |")
+	}
+	b.WriteString("\n|---|---|" + strings.Repeat("---|", len(c.Authors)) + "\n")
+	for _, t := range c.Tags {
+		fmt.Fprintf(&b, "| %s | %d |", t.Name, len(t.Problems))
+		for _, a := range c.Authors {
+			b.WriteString(" " + summary(t.Counts[a], len(t.Problems)) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	names := map[string]string{}
+	for _, t := range c.Tags {
+		names[t.Slug] = t.Name
+	}
+	b.WriteString("\n## 题目\n\n| 题号 | 题目 | 难度 | 标签 |")
+	for _, a := range c.Authors {
+		b.WriteString(" " + a + " |")
+	}
+	b.WriteString("\n|---|---|---|---|" + strings.Repeat("---|", len(c.Authors)) + "\n")
+	for _, r := range c.Problems {
+		tags := make([]string, len(r.Tags))
+		for i, t := range r.Tags {
+			tags[i] = names[t]
+		}
+		fmt.Fprintf(&b, "| %s | [%s](https://leetcode.cn/problems/%s/) | %s | %s |", r.ID, r.Title, r.Slug, r.Difficulty, strings.Join(tags, "、"))
+		for _, a := range c.Authors {
+			s := r.Cells[a].Status
+			if s == StatusMissing && len(r.Cells[a].Dirs) == 0 {
+				s = "—"
+			}
+			b.WriteString(" " + s + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(c.Unresolved) > 0 {
+		b.WriteString("\n## 没有对应题目的目录\n\n工具、库，或者不在 hot 100 里的题。\n\n")
+		for _, d := range c.Unresolved {
+			b.WriteString("- " + d.Path + "\n")
+		}
+	}
+	return b.String()
+}
+
+func (c *Coverage) has(status string) bool {
+	for _, a := range c.Authors {
+		if c.Totals[a][status] > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// 三个人的目录命名各不相同：
+//   shubo:        maxProfit(买卖股票的最佳时机)，括号里的中文有时和官方标题对不上
+//   songzhibin97: 买卖股票的最佳时机
+//   cc11001100:   贪心算法/121. 买卖股票的最佳时机
+// 依次按题号、标题、函数名解析成 questionFrontendId
+// diff(多作者对拍) 里的 resolve.go 是从这里 go generate 拷贝的
+
+// funcNames LeetCode 给的 Go 函数名，设计类题目是类名
+var funcNames = map[string]string{
+	"1": "twoSum", "2": "addTwoNumbers", "3": "lengthOfLongestSubstring", "4": "findMedianSortedArrays",
+	"5": "longestPalindrome", "10": "isMatch", "11": "maxArea", "15": "threeSum",
+	"17": "letterCombinations", "19": "removeNthFromEnd", "20": "isValid", "21": "mergeTwoLists",
+	"22": "generateParenthesis", "23": "mergeKLists", "31": "nextPermutation", "32": "longestValidParentheses",
+	"33": "search", "34": "searchRange", "39": "combinationSum", "42": "trap",
+	"46": "permute", "48": "rotate", "49": "groupAnagrams", "53": "maxSubArray",
+	"55": "canJump", "56": "merge", "62": "uniquePaths", "64": "minPathSum",
+	"70": "climbStairs", "72": "minDistance", "75": "sortColors", "76": "minWindow",
+	"78": "subsets", "79": "exist", "84": "largestRectangleArea", "85": "maximalRectangle",
+	"94": "inorderTraversal", "96": "numTrees", "98": "isValidBST", "101": "isSymmetric",
+	"102": "levelOrder", "104": "maxDepth", "105": "buildTree", "114": "flatten",
+	"121": "maxProfit", "124": "maxPathSum", "128": "longestConsecutive", "136": "singleNumber",
+	"139": "wordBreak", "141": "hasCycle", "142": "detectCycle", "146": "LRUCache",
+	"148": "sortList", "152": "maxProduct", "155": "MinStack", "160": "getIntersectionNode",
+	"169": "majorityElement", "198": "rob", "200": "numIslands", "206": "reverseList",
+	"207": "canFinish", "208": "Trie", "215": "findKthLargest", "221": "maximalSquare",
+	"226": "invertTree", "234": "isPalindrome", "236": "lowestCommonAncestor", "238": "productExceptSelf",
+	"239": "maxSlidingWindow", "240": "searchMatrix", "253": "minMeetingRooms", "279": "numSquares",
+	"283": "moveZeroes", "287": "findDuplicate", "297": "Codec", "300": "lengthOfLIS",
+	"301": "removeInvalidParentheses", "309": "maxProfit", "312": "maxCoins", "322": "coinChange",
+	"337": "rob", "338": "countBits", "347": "topKFrequent", "394": "decodeString",
+	"399": "calcEquation", "406": "reconstructQueue", "416": "canPartition", "437": "pathSum",
+	"438": "findAnagrams", "448": "findDisappearedNumbers", "461": "hammingDistance", "494": "findTargetSumWays",
+	"538": "convertBST", "543": "diameterOfBinaryTree", "560": "subarraySum", "581": "findUnsortedSubarray",
+	"617": "mergeTrees", "621": "leastInterval", "647": "countSubstrings", "739": "dailyTemperatures",
+}
+
+// 解析方式
+const (
+	ByID        = "题号"
+	ByTitle     = "标题"
+	ByFunc      = "函数名"
+	ByFuncTitle = "函数名+标题" // 函数名对应多道题（maxProfit、rob），用标题相似度挑一道
+)
+
+var (
+	idPattern   = regexp.MustCompile(`^(\d+)\.\s*(.+)$`)
+	funcPattern = regexp.MustCompile(`^([A-Za-z]\w*)\((.+)\)$`)
+	roman       = strings.NewReplacer("Ⅰ", "I", "Ⅱ", "II", "Ⅲ", "III", "（", "(", "）", ")")
+)
+
+type Resolver struct {
+	problems map[string]Problem
+	byTitle  map[string]string   // 归一化后的中文标题 -> 题号
+	byFunc   map[string][]string // 小写函数名 -> 题号
+}
+
+func NewResolver(problems map[string]Problem) *Resolver {
+	r := &Resolver{problems: problems, byTitle: map[string]string{}, byFunc: map[string][]string{}}
+	for id, p := range problems {
+		r.byTitle[normalize(p.TranslatedTitle)] = id
+		if fn, ok := funcNames[id]; ok {
+			k := strings.ToLower(fn)
+			r.byFunc[k] = append(r.byFunc[k], id)
+		}
+	}
+	return r
+}
+
+// Resolve 目录名 -> 题号，不是 hot 100 的题目或者不是题解目录返回 false
+func (r *Resolver) Resolve(name string) (id, by string, ok bool) {
+	if m := idPattern.FindStringSubmatch(name); m != nil {
+		if _, ok := r.problems[m[1]]; ok {
+			return m[1], ByID, true
+		}
+		return "", "", false
+	}
+	fn, title := "", name
+	if m := funcPattern.FindStringSubmatch(name); m != nil {
+		fn, title = m[1], m[2]
+	}
+	if id, ok := r.byTitle[normalize(title)]; ok {
+		return id, ByTitle, true
+	}
+	cands := r.byFunc[strings.ToLower(fn)]
+	switch len(cands) {
+	case 0:
+		return "", "", false
+	case 1:
+		return cands[0], ByFunc, true
+	}
+	best, score := "", -1.0
+	for _, c := range cands {
+		if s := similarity(title, r.problems[c].TranslatedTitle); s > score || s == score && c < best {
+			best, score = c, s
+		}
+	}
+	return best, ByFuncTitle, true
+}
+
+// normalize 去掉空白，统一罗马数字和全角括号，"环形链表II" 和 "环形链表 II" 算同一个标题
+func normalize(s string) string {
+	s = roman.Replace(strings.ToLower(s))
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// similarity 两个标题按字计数的 Dice 系数
+func similarity(a, b string) float64 {
+	ra, rb := []rune(normalize(a)), []rune(normalize(b))
+	if len(ra)+len(rb) == 0 {
+		return 0
+	}
+	cnt := map[rune]int{}
+	for _, c := range ra {
+		cnt[c]++
+	}
+	common := 0
+	for _, c := range rb {
+		if cnt[c] > 0 {
+			cnt[c]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(ra)+len(rb))
+}
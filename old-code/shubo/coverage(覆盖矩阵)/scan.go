@@ -0,0 +1,115 @@
+package main
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// diff(多作者对拍) 里的 scan.go 是从这里 go generate 拷贝的
+
+// Dir 一个作者目录下的子目录，解析不出题号的也记下来，报告里单独列出
+type Dir struct {
+	Author string `json:"author"`
+	Path   string `json:"path"` // 相对 root，如 shubo/maxProfit(买卖股票的最佳时机)
+	ID     string `json:"id,omitempty"`
+	By     string `json:"by,omitempty"` // 怎么解析出的题号
+	Status string `json:"status,omitempty"`
+	Detail string `json:"detail,omitempty"`
+
+	goFiles   []string // 当前平台会参与编译的文件，不含测试
+	pkg       string   // goFiles 的包名，题解都是 main
+	testFiles []string
+	readme    bool
+}
+
+// Scan root 下每个子目录是一个作者，作者目录下的子目录是题解或者分类目录（cc11001100 的 双指针、链表）
+func Scan(root string, r *Resolver) ([]*Dir, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []*Dir
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if err := scanDir(root, e.Name(), e.Name(), r, &dirs); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(dirs, func(i, j int) bool { return dirs[i].Path < dirs[j].Path })
+	return dirs, nil
+}
+
+func scanDir(root, author, rel string, r *Resolver, dirs *[]*Dir) error {
+	entries, err := os.ReadDir(filepath.Join(root, rel))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		d := &Dir{Author: author, Path: filepath.ToSlash(filepath.Join(rel, e.Name()))}
+		subdirs, err := d.load(root)
+		if err != nil {
+			return err
+		}
+		id, by, ok := r.Resolve(e.Name())
+		if ok && (by == ByFunc || by == ByFuncTitle) && d.pkg != "" && d.pkg != "main" {
+			// 只按函数名对上的库目录是名字碰巧一样，比如 trie(泛型前缀树)，不算这道题的题解；
+			// 标题对上的不管是不是 package main 都算是这道题的题解
+			id, by = "", ""
+		}
+		if !ok && len(d.goFiles)+len(d.testFiles) == 0 && subdirs > 0 {
+			// 分类目录（可能有自己的 README），往下一层找
+			if err := scanDir(root, author, d.Path, r, dirs); err != nil {
+				return err
+			}
+			continue
+		}
+		d.ID, d.By = id, by
+		*dirs = append(*dirs, d)
+	}
+	return nil
+}
+
+// load 列出目录里的 go 文件和 README.md，返回子目录个数
+func (d *Dir) load(root string) (int, error) {
+	dir := filepath.Join(root, filepath.FromSlash(d.Path))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	subdirs := 0
+	for _, e := range entries {
+		name := e.Name()
+		switch {
+		case e.IsDir():
+			subdirs++
+		case name == "README.md":
+			d.readme = true
+		case strings.HasSuffix(name, ".go"):
+			// 按构建约束过滤，wasm 的 main_js.go 这种只在别的平台编译
+			if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+				continue
+			}
+			if strings.HasSuffix(name, "_test.go") {
+				d.testFiles = append(d.testFiles, name)
+			} else {
+				d.goFiles = append(d.goFiles, name)
+				if d.pkg == "" {
+					if f, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly); err == nil {
+						d.pkg = f.Name.Name
+					}
+				}
+			}
+		}
+	}
+	return subdirs, nil
+}
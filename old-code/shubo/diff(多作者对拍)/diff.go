@@ -0,0 +1,543 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 实现的状态
+const (
+	StatusParse     = "解析失败"
+	StatusNotFound  = "没有找到"
+	StatusCompile   = "编译失败"
+	StatusSignature = "签名不匹配" // 能编译，但参数、返回值和题目对不上
+	StatusOK        = "参与对拍"
+	StatusAlone     = "无法对拍" // 这道题能跑的实现不到两个，没有可比的，不跑输入也不测速
+)
+
+// 输入的来源
+const (
+	KindExample = "示例"
+	KindGen     = "生成"
+	KindBench   = "测速"
+)
+
+type Options struct {
+	Root    string   // old-code 目录
+	Meta    string   // docs/leetcode-hot-100.json
+	Work    string   // 生成代码的目录，必须是空的
+	IDs     []string // 只对拍这些题，空表示 specs 里的全部
+	Seed    int64
+	MaxN    int // 生成输入的最大规模，题目自己的 MaxN 优先
+	PerN    int // 每个规模生成几组
+	BenchN  int // 测速输入的规模，题目自己的 BenchN 优先
+	Benches int // 测速输入的组数
+	Rounds  int
+	Timeout time.Duration // 每组输入的超时
+	Show    int           // 每道题最多列出几组不一致
+}
+
+var DefaultOptions = Options{Seed: 1, MaxN: 12, PerN: 20, BenchN: 10000, Benches: 3, Rounds: 5, Timeout: 2 * time.Second, Show: 3}
+
+type Input struct {
+	Kind     string `json:"kind"`
+	N        int    `json:"n,omitempty"`
+	Text     string `json:"input"`
+	Expected string `json:"expected,omitempty"` // 示例才有
+
+	args []json.RawMessage
+}
+
+// Group 输出相同的一组实现
+type Group struct {
+	Output string   `json:"output"`
+	Impls  []string `json:"impls"`
+}
+
+type Disagreement struct {
+	Input
+	Groups []Group `json:"groups"`
+}
+
+type Bench struct {
+	Ns       int64   `json:"ns"` // 每轮耗时的中位数
+	Allocs   int64   `json:"allocs"`
+	Bytes    int64   `json:"bytes"`
+	Relative float64 `json:"relative"` // 相对最快的实现
+	Error    string  `json:"error,omitempty"`
+}
+
+// ImplResult 一个实现在所有输入上的表现
+type ImplResult struct {
+	*Impl
+	Wrong    int    `json:"wrong"`    // 示例里和期望不一样的组数
+	Errors   int    `json:"errors"`   // panic、超时、参数解码失败的组数
+	Minority int    `json:"minority"` // 输出和多数实现不一样的组数
+	Bench    *Bench `json:"bench,omitempty"`
+}
+
+type Report struct {
+	ID            string         `json:"id"`
+	Title         string         `json:"title"`
+	Impls         []*ImplResult  `json:"impls"`
+	Inputs        map[string]int `json:"inputs"` // 来源 -> 组数
+	BenchN        int            `json:"benchN"`
+	Rounds        int            `json:"rounds"`
+	Disagreements []Disagreement `json:"disagreements"` // 输入最短的几组
+	Disagree      int            `json:"disagree"`      // 不一致的总组数
+}
+
+// Run 找出每道题各个作者的实现，编译后用同样的输入跑一遍，比较输出和速度
+func Run(opt Options) ([]*Report, error) {
+	problems, err := LoadProblems(opt.Meta)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := Scan(opt.Root, NewResolver(problems))
+	if err != nil {
+		return nil, err
+	}
+	var reports []*Report
+	for _, spec := range specs {
+		if len(opt.IDs) > 0 && indexOf(opt.IDs, spec.ID) < 0 {
+			continue
+		}
+		rep := &Report{ID: spec.ID, Title: problems[spec.ID].TranslatedTitle, Inputs: map[string]int{}, Rounds: opt.Rounds, Disagreements: []Disagreement{}}
+		for _, d := range dirs {
+			if d.ID != spec.ID {
+				continue
+			}
+			impls, err := Impls(opt.Root, d, spec)
+			if err != nil {
+				return nil, err
+			}
+			for _, impl := range impls {
+				rep.Impls = append(rep.Impls, &ImplResult{Impl: impl})
+			}
+		}
+		reports = append(reports, rep)
+	}
+	for _, id := range opt.IDs {
+		if specByID(id) == nil {
+			return nil, fmt.Errorf("题目 %s 还没有对拍的配置", id)
+		}
+	}
+
+	if err := emptyDir(opt.Work); err != nil {
+		return nil, err
+	}
+	if err := writeFile(opt.Work, "go.mod", "module "+module+"\n\ngo 1.22\n"); err != nil {
+		return nil, err
+	}
+	if err := writeLC(opt.Work); err != nil {
+		return nil, err
+	}
+	var impls []*ImplResult
+	for _, rep := range reports {
+		for _, r := range rep.Impls {
+			if r.Status != "" {
+				continue
+			}
+			r.Pkg = fmt.Sprintf("p%s_%s_%d", rep.ID, ident(r.Author), len(impls)+1)
+			if err := r.writeSolution(opt.Work); err != nil {
+				return nil, err
+			}
+			impls = append(impls, r)
+		}
+	}
+
+	// 第一遍只编译抠出来的代码，第二遍加上 run.go
+	failed, err := compile(opt.Work)
+	if err != nil {
+		return nil, err
+	}
+	var runnable []*ImplResult
+	for _, r := range impls {
+		if out, ok := failed[r.Pkg]; ok {
+			r.Status, r.Error = StatusCompile, out
+			continue
+		}
+		src, err := r.runSource(r.spec)
+		if err != nil {
+			r.Status, r.Error = StatusSignature, err.Error()
+			continue
+		}
+		if err := writeFile(r.dir(opt.Work), "run.go", src); err != nil {
+			return nil, err
+		}
+		runnable = append(runnable, r)
+	}
+	if failed, err = compile(opt.Work); err != nil {
+		return nil, err
+	}
+	for _, r := range runnable {
+		if out, ok := failed[r.Pkg]; ok {
+			r.Status, r.Error = StatusSignature, out
+			os.Remove(filepath.Join(r.dir(opt.Work), "run.go"))
+			continue
+		}
+		r.Status = StatusOK
+	}
+	var pkgs []string
+	for _, rep := range reports {
+		ok := rep.runnable()
+		for _, r := range ok {
+			if len(ok) < 2 {
+				r.Status = StatusAlone
+				continue
+			}
+			pkgs = append(pkgs, r.Pkg)
+		}
+	}
+	if len(pkgs) == 0 {
+		return reports, nil
+	}
+	if err := writeFile(opt.Work, "cmd/harness/main.go", harnessSource(pkgs)); err != nil {
+		return nil, err
+	}
+
+	inputs := map[*Report][]Input{}
+	type ref struct {
+		rep *Report
+		in  int
+		r   *ImplResult
+	}
+	var jobs []checkJob
+	var refs []ref
+	for _, rep := range reports {
+		if len(rep.runnable()) == 0 {
+			continue
+		}
+		spec := specByID(rep.ID)
+		ins, err := makeInputs(spec, opt)
+		if err != nil {
+			return nil, err
+		}
+		inputs[rep] = ins
+		rep.BenchN = benchN(spec, opt)
+		for _, in := range ins {
+			rep.Inputs[in.Kind]++
+		}
+		for _, r := range rep.Impls {
+			if r.Status != StatusOK {
+				continue
+			}
+			for i, in := range ins {
+				jobs = append(jobs, checkJob{Pkg: r.Pkg, Args: in.args})
+				refs = append(refs, ref{rep, i, r})
+			}
+		}
+	}
+	var results []checkResult
+	if err := harness(opt, "check", jobs, &results); err != nil {
+		return nil, err
+	}
+
+	// outputs[报告][第几组输入][实现] = 规范化后的输出
+	outputs := map[*Report][]map[*ImplResult]string{}
+	for rep, ins := range inputs {
+		outputs[rep] = make([]map[*ImplResult]string, len(ins))
+		for i := range ins {
+			outputs[rep][i] = map[*ImplResult]string{}
+		}
+	}
+	benchOK := map[*ImplResult]bool{}
+	for i, res := range results {
+		ref := refs[i]
+		in := inputs[ref.rep][ref.in]
+		out := res.Error
+		if res.Error != "" {
+			ref.r.Errors++
+		} else {
+			out = normalizeOutput(res.Output, specByID(ref.rep.ID).Order)
+		}
+		outputs[ref.rep][ref.in][ref.r] = out
+		if in.Kind == KindExample && out != normalizeOutput(json.RawMessage(in.Expected), specByID(ref.rep.ID).Order) {
+			ref.r.Wrong++
+		}
+		if _, ok := benchOK[ref.r]; !ok {
+			benchOK[ref.r] = true
+		}
+		if in.Kind == KindBench && res.Error != "" {
+			benchOK[ref.r] = false
+		}
+	}
+	for _, rep := range reports {
+		compare(rep, inputs[rep], outputs[rep], opt.Show)
+	}
+
+	// 测速：测速输入上出错的实现不参加，超时的会拖住整个进程
+	var bjobs []benchJob
+	var brefs []*ImplResult
+	for _, rep := range reports {
+		var benchInputs [][]json.RawMessage
+		for _, in := range inputs[rep] {
+			if in.Kind == KindBench {
+				benchInputs = append(benchInputs, in.args)
+			}
+		}
+		for _, r := range rep.Impls {
+			if r.Status == StatusOK && benchOK[r] {
+				bjobs = append(bjobs, benchJob{Pkg: r.Pkg, Inputs: benchInputs, Rounds: opt.Rounds})
+				brefs = append(brefs, r)
+			}
+		}
+	}
+	var bresults []benchResult
+	if err := harness(opt, "bench", bjobs, &bresults); err != nil {
+		return nil, err
+	}
+	for i, b := range bresults {
+		brefs[i].Bench = &Bench{Ns: b.Ns, Allocs: b.Allocs, Bytes: b.Bytes, Error: b.Error}
+	}
+	for _, rep := range reports {
+		var fastest int64
+		for _, r := range rep.Impls {
+			if b := r.Bench; b != nil && b.Error == "" && (fastest == 0 || b.Ns < fastest) {
+				fastest = b.Ns
+			}
+		}
+		for _, r := range rep.Impls {
+			if b := r.Bench; b != nil && b.Error == "" && fastest > 0 {
+				b.Relative = float64(b.Ns) / float64(fastest)
+			}
+		}
+	}
+	return reports, nil
+}
+
+// runnable 参与对拍的实现
+func (rep *Report) runnable() []*ImplResult {
+	var ret []*ImplResult
+	for _, r := range rep.Impls {
+		if r.Status == StatusOK {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+var nonIdent = regexp.MustCompile(`[^a-z0-9]+`)
+
+func ident(s string) string {
+	return nonIdent.ReplaceAllString(strings.ToLower(s), "_")
+}
+
+func benchN(spec *Spec, opt Options) int {
+	if spec.BenchN > 0 {
+		return spec.BenchN
+	}
+	return opt.BenchN
+}
+
+// makeInputs 示例、从小到大生成的输入、测速用的大输入，每道题的随机数种子固定，只跑部分题目时输入也不变
+func makeInputs(spec *Spec, opt Options) ([]Input, error) {
+	var ins []Input
+	for _, ex := range spec.Examples {
+		args, err := parseArgs(ex.Input, spec.Params)
+		if err != nil {
+			return nil, fmt.Errorf("%s 的示例 %s: %w", spec.ID, ex.Input, err)
+		}
+		ins = append(ins, Input{Kind: KindExample, Text: ex.Input, Expected: ex.Expected, args: args})
+	}
+	id, _ := strconv.Atoi(spec.ID)
+	r := rand.New(rand.NewSource(opt.Seed*10007 + int64(id)))
+	maxN := opt.MaxN
+	if spec.MaxN > 0 {
+		maxN = spec.MaxN
+	}
+	// 小规模时经常生成一样的输入，只留第一组
+	seen := map[string]bool{}
+	gen := func(kind string, n int) error {
+		var args []json.RawMessage
+		for _, v := range spec.Gen(r, n) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			args = append(args, b)
+		}
+		text := formatArgs(spec.Params, args)
+		if kind == KindGen && seen[text] {
+			return nil
+		}
+		seen[text] = true
+		ins = append(ins, Input{Kind: kind, N: n, Text: text, args: args})
+		return nil
+	}
+	for n := 0; n <= maxN; n++ {
+		for range opt.PerN {
+			if err := gen(KindGen, n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for range opt.Benches {
+		if err := gen(KindBench, benchN(spec, opt)); err != nil {
+			return nil, err
+		}
+	}
+	return ins, nil
+}
+
+func formatArgs(params []string, args []json.RawMessage) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = params[i] + " = " + string(a)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// compare 按输出分组，多于一组就是不一致；示例和期望不一样也算
+func compare(rep *Report, ins []Input, outputs []map[*ImplResult]string, show int) {
+	var found []Disagreement
+	for i, in := range ins {
+		byOut := map[string][]string{}
+		for _, r := range rep.Impls {
+			if out, ok := outputs[i][r]; ok {
+				byOut[out] = append(byOut[out], r.Name)
+			}
+		}
+		if len(byOut) == 0 {
+			continue
+		}
+		var groups []Group
+		for out, names := range byOut {
+			groups = append(groups, Group{Output: out, Impls: names})
+		}
+		// 人多的组排前面，输出一样多时按输出排，结果稳定
+		sort.Slice(groups, func(a, b int) bool {
+			if len(groups[a].Impls) != len(groups[b].Impls) {
+				return len(groups[a].Impls) > len(groups[b].Impls)
+			}
+			return groups[a].Output < groups[b].Output
+		})
+		wrong := in.Kind == KindExample && (len(groups) > 1 || groups[0].Output != normalizeOutput(json.RawMessage(in.Expected), specByID(rep.ID).Order))
+		if len(groups) == 1 && !wrong {
+			continue
+		}
+		// 有唯一的最大组时，其余的算少数派
+		if len(groups) > 1 && len(groups[0].Impls) > len(groups[1].Impls) {
+			for _, r := range rep.Impls {
+				if _, ok := outputs[i][r]; ok && indexOf(groups[0].Impls, r.Name) < 0 {
+					r.Minority++
+				}
+			}
+		}
+		found = append(found, Disagreement{Input: in, Groups: groups})
+	}
+	rep.Disagree = len(found)
+	sort.SliceStable(found, func(a, b int) bool { return len(found[a].Text) < len(found[b].Text) })
+	rep.Disagreements = found[:min(show, len(found))]
+}
+
+// normalizeOutput 去掉空白，按题目的要求把顺序无关的部分排好序
+func normalizeOutput(raw json.RawMessage, order Order) string {
+	var v any
+	if json.Unmarshal(raw, &v) != nil {
+		return string(raw)
+	}
+	// Go 里 nil 切片编码成 null，力扣上显示的是 []
+	if v == nil {
+		v = []any{}
+	}
+	arr, ok := v.([]any)
+	if ok {
+		switch order {
+		case SortInner:
+			sort.SliceStable(arr, func(i, j int) bool { return compareJSON(arr[i], arr[j]) < 0 })
+		case SortBoth:
+			for _, e := range arr {
+				if inner, ok := e.([]any); ok {
+					sort.SliceStable(inner, func(i, j int) bool { return compareJSON(inner[i], inner[j]) < 0 })
+				}
+			}
+			fallthrough
+		case SortOuter:
+			sort.SliceStable(arr, func(i, j int) bool { return compareJSON(arr[i], arr[j]) < 0 })
+		}
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// compareJSON 数字按大小，数组按字典序，其他按编码后的字符串
+func compareJSON(a, b any) int {
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case []any:
+		if y, ok := b.([]any); ok {
+			for i := 0; i < len(x) && i < len(y); i++ {
+				if c := compareJSON(x[i], y[i]); c != 0 {
+					return c
+				}
+			}
+			return len(x) - len(y)
+		}
+	}
+	sa, _ := json.Marshal(a)
+	sb, _ := json.Marshal(b)
+	return strings.Compare(string(sa), string(sb))
+}
+
+type checkJob struct {
+	Pkg  string
+	Args []json.RawMessage
+}
+
+type checkResult struct {
+	Output json.RawMessage
+	Error  string
+}
+
+type benchJob struct {
+	Pkg    string
+	Inputs [][]json.RawMessage
+	Rounds int
+}
+
+type benchResult struct {
+	Ns, Allocs, Bytes int64
+	Error             string
+}
+
+// harness 任务写文件，跑对拍程序，读回结果
+func harness(opt Options, mode string, jobs any, results any) error {
+	src, err := json.Marshal(jobs)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(opt.Work, mode+".json", string(src)); err != nil {
+		return err
+	}
+	resultsPath := filepath.Join(opt.Work, mode+"-results.json")
+	cmd := exec.Command("go", "run", "./cmd/harness", mode, mode+".json", resultsPath, opt.Timeout.String())
+	cmd.Dir = opt.Work
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("运行对拍程序失败: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(resultsPath)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, results)
+}
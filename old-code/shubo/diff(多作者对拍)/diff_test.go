@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const meta = "../../../docs/leetcode-hot-100.json"
+
+func TestImplsOf(t *testing.T) {
+	src := `package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// reverseList 头插
+func reverseList(head *ListNode) *ListNode {
+	var prev *ListNode
+	for head != nil {
+		prev, head = push(prev, head)
+	}
+	return prev
+}
+
+func push(prev, head *ListNode) (*ListNode, *ListNode) {
+	next := head.Next
+	head.Next = prev
+	return head, next
+}
+
+func reverseList2(head *ListNode) *ListNode {
+	if head == nil || head.Next == nil {
+		return head
+	}
+	p := reverseList2(head.Next)
+	head.Next.Next = head
+	head.Next = nil
+	return p
+}
+
+func reverseListPrint(head *ListNode) {
+	fmt.Println(head)
+}
+
+func TestReverseList(t *testing.T) {
+	reverseList(nil)
+}
+`
+	impls := implsOf(&Impl{Author: "a", Dir: "a/reverseList(反转链表)", Name: "a"}, []string{"x_test.go"}, [][]byte{[]byte(src)}, specByID("206"))
+	if len(impls) != 2 || impls[0].Name != "a" || impls[1].Name != "a/reverseList2" {
+		t.Fatal(impls)
+	}
+	first := impls[0].Source
+	if !strings.Contains(first, "// reverseList 头插") || !strings.Contains(first, "func push(") {
+		t.Fatal("要带上注释和用到的函数\n", first)
+	}
+	if strings.Contains(first, "type ListNode") || strings.Contains(first, "TestReverseList") || strings.Contains(first, "reverseList2") {
+		t.Fatal("共享类型、测试函数、别的解法不要\n", first)
+	}
+	if got := impls[0].unit.imports(impls[0].sel); len(got) != 0 {
+		t.Fatal(got)
+	}
+	if got := impls[0].unit.shared(impls[0].sel); strings.Join(got, ",") != "ListNode" {
+		t.Fatal(got)
+	}
+
+	// 找不到入口、解析失败
+	impls = implsOf(&Impl{Author: "b", Name: "b"}, []string{"main.go"}, [][]byte{[]byte("package main\n\nfunc main() {}\n")}, specByID("206"))
+	if len(impls) != 1 || impls[0].Status != StatusNotFound {
+		t.Fatal(impls[0])
+	}
+	impls = implsOf(&Impl{Author: "b", Name: "b"}, []string{"main.go"}, [][]byte{[]byte("package main\n\nfunc reverseList(\n")}, specByID("206"))
+	if len(impls) != 1 || impls[0].Status != StatusParse {
+		t.Fatal(impls[0])
+	}
+}
+
+func TestNormalizeOutput(t *testing.T) {
+	cases := []struct {
+		raw   string
+		order Order
+		want  string
+	}{
+		{"null", Exact, "[]"},
+		{"[3, 1, 2]", Exact, "[3,1,2]"},
+		{"[[2,-1,-1],[0,-1,1]]", SortBoth, "[[-1,-1,2],[-1,0,1]]"},
+		{"[[1,6],[8,10]]", SortOuter, "[[1,6],[8,10]]"},
+		{"[1,0]", SortInner, "[0,1]"},
+		{"超时", Exact, "超时"},
+	}
+	for _, c := range cases {
+		if got := normalizeOutput(json.RawMessage(c.raw), c.order); got != c.want {
+			t.Fatalf("%s: %s", c.raw, got)
+		}
+	}
+}
+
+// TestSpecs 示例能按参数名解析，生成的输入能编码，同一个种子生成的一样
+func TestSpecs(t *testing.T) {
+	opt := DefaultOptions
+	opt.MaxN, opt.PerN, opt.BenchN = 4, 3, 200
+	for _, spec := range specs {
+		ins, err := makeInputs(spec, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, _ := makeInputs(spec, opt)
+		if len(ins) != len(again) {
+			t.Fatal(spec.ID, "输入不固定")
+		}
+		for i, in := range ins {
+			if len(in.args) != len(spec.Params) || in.Text != again[i].Text {
+				t.Fatal(spec.ID, in.Text)
+			}
+		}
+	}
+}
+
+func TestSideBySide(t *testing.T) {
+	var buf bytes.Buffer
+	SideBySide(&buf, []string{"a", "b"}, []string{"// 中文注释\nfunc f() {\n\treturn\n}", "x := 1\n// 这一行特别特别特别长，要截断"}, 16)
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 6 {
+		t.Fatal(buf.String())
+	}
+	for _, line := range lines[2:] {
+		i := strings.Index(line, "│")
+		if i < 0 || displayWidth(line[:i]) != 17 {
+			t.Fatalf("竖线没对齐: %q", line)
+		}
+	}
+	if !strings.Contains(lines[3], "…") || displayWidth(lines[3]) > 16*2+3 {
+		t.Fatalf("%q", lines[3])
+	}
+}
+
+// TestRunWork 生成代码的目录里有东西时不能动它
+func TestRunWork(t *testing.T) {
+	work := t.TempDir()
+	keep := filepath.Join(work, "main.go")
+	if err := os.WriteFile(keep, []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opt := DefaultOptions
+	opt.Root, opt.Meta, opt.Work = t.TempDir(), meta, work
+	if _, err := Run(opt); err == nil {
+		t.Fatal("不是空目录也跑了")
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRun 两数之和两个作者三个实现，有一个是错的；爬楼梯只有一个实现；最长连续序列解析失败
+func TestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("要编译生成的代码")
+	}
+	root := t.TempDir()
+	files := map[string]string{
+		"a/twoSum(两数之和)/twoSum_test.go": "package main\n\nfunc twoSum(nums []int, target int) []int {\n\tseen := map[int]int{}\n\tfor i, v := range nums {\n\t\tif j, ok := seen[target-v]; ok {\n\t\t\treturn []int{j, i}\n\t\t}\n\t\tseen[v] = i\n\t}\n\treturn nil\n}\n\nfunc twoSum2(nums []int, target int) []int {\n\tfor i := range nums {\n\t\tfor j := i + 1; j < len(nums); j++ {\n\t\t\tif nums[i]+nums[j] == target {\n\t\t\t\treturn []int{i, j}\n\t\t\t}\n\t\t}\n\t}\n\treturn nil\n}\n",
+		"b/哈希/1. 两数之和/README.md":        "# 1. 两数之和\n\n```go\nfunc twoSum(nums []int, target int) []int {\n    return []int{0, 1}\n}\n```\n",
+		"b/动态规划/70. 爬楼梯/README.md":      "# 70. 爬楼梯\n\n```go\nfunc climbStairs(n int) int {\n    a, b := 1, 1\n    for i := 1; i < n; i++ {\n        a, b = b, a+b\n    }\n    return b\n}\n```\n",
+		"b/哈希/128. 最长连续序列/README.md":    "# 128. 最长连续序列\n\n```go\nfunc longestConsecutive(nums []int) int {\n    return len(\n}\n```\n",
+	}
+	for name, src := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	opt := DefaultOptions
+	opt.Root, opt.Meta, opt.Work, opt.IDs = root, meta, t.TempDir(), []string{"1", "70", "128"}
+	opt.MaxN, opt.PerN, opt.BenchN, opt.Rounds = 5, 5, 1000, 2
+	reports, err := Run(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 3 {
+		t.Fatal(len(reports))
+	}
+	rep := reports[0]
+	got := map[string]*ImplResult{}
+	for _, r := range rep.Impls {
+		got[r.Name] = r
+	}
+	if len(got) != 3 || got["a"] == nil || got["a/twoSum2"] == nil || got["b#1"] == nil {
+		t.Fatal(rep.Impls)
+	}
+	for _, name := range []string{"a", "a/twoSum2"} {
+		r := got[name]
+		if r.Status != StatusOK || r.Wrong+r.Errors+r.Minority != 0 || r.Bench == nil || r.Bench.Error != "" {
+			t.Fatal(name, r.Status, r.Error, r.Wrong, r.Errors, r.Minority)
+		}
+	}
+	if b := got["b#1"]; b.Wrong == 0 || b.Minority == 0 || rep.Disagree != b.Minority || len(rep.Disagreements) == 0 {
+		t.Fatal(b.Wrong, b.Minority, rep.Disagree)
+	}
+	// b#1 每个测速输入只分配返回的 []int{0, 1}，计数里不能混进解码参数和运行时自己的分配
+	if b := got["b#1"].Bench; b == nil || b.Allocs != int64(opt.Benches) || b.Bytes != 16*int64(opt.Benches) {
+		t.Fatal(b)
+	}
+	// 只有一个实现的题没有可比的，不跑输入也不测速
+	if rep := reports[1]; len(rep.Impls) != 1 || rep.Impls[0].Status != StatusAlone || rep.Impls[0].Bench != nil || len(rep.Inputs) != 0 {
+		t.Fatal(rep.Impls[0].Status, rep.Inputs)
+	}
+	if r := reports[2].Impls; len(r) != 1 || r[0].Status != StatusParse {
+		t.Fatal(r)
+	}
+
+	var buf bytes.Buffer
+	PrintText(&buf, reports, true, 40)
+	for _, want := range []string{"b#1", "不一致", "速度", "│", StatusParse, StatusAlone} {
+		if !strings.Contains(buf.String(), want) {
+			t.Fatal(want, "\n", buf.String())
+		}
+	}
+	if md := Markdown(reports); !strings.Contains(md, "<pre>func twoSum") || !strings.Contains(md, "| a/twoSum2 |") {
+		t.Fatal(md)
+	}
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 从题解目录里抠出实现：入口函数（设计类题目是类型、它的方法和 Constructor），
+// 加上它直接、间接用到的顶层声明。shubo 的题解常和测试、别的解法一起写在 _test.go 里，
+// songzhibin97 的在 main.go 里带着 main，cc11001100 的在 README 的代码块里，每个代码块单独算一个实现
+
+// Impl 某个作者对一道题的一个实现
+type Impl struct {
+	Author string `json:"author"`
+	Dir    string `json:"dir"`
+	Func   string `json:"func"`            // 入口，同一目录里的其他解法（longestConsecutive1）单独算一个实现
+	Block  int    `json:"block,omitempty"` // README 的第几个 go 代码块
+	Name   string `json:"name"`            // 报告里的名字：shubo、shubo/longestConsecutive1、cc11001100#2
+	Pkg    string `json:"package,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Source string `json:"source,omitempty"` // 抠出来的代码，按原来的顺序
+
+	spec  *Spec
+	unit  *unit
+	sel   map[ast.Decl]bool // 抠出来的声明
+	entry *ast.FuncDecl     // 函数题的入口；设计类题目是 Constructor
+	class *ast.TypeSpec
+}
+
+// unit 一组一起编译的源文件：一个目录的 go 文件，或者 README 的一个代码块
+type unit struct {
+	fset    *token.FileSet
+	files   []*ast.File
+	srcs    [][]byte
+	decls   map[string][]ast.Decl // 顶层名字 -> 声明，方法不在这里
+	methods map[string][]*ast.FuncDecl
+}
+
+// 代码里常用、但 LeetCode 上不用写 import 的标准库，README 的代码块靠它补 import
+var stdPackages = map[string]string{
+	"sort":    "sort",
+	"strings": "strings",
+	"strconv": "strconv",
+	"math":    "math",
+	"fmt":     "fmt",
+	"bytes":   "bytes",
+	"unicode": "unicode",
+	"heap":    "container/heap",
+	"list":    "container/list",
+	"bits":    "math/bits",
+	"slices":  "slices",
+	"maps":    "maps",
+	"errors":  "errors",
+}
+
+// 共享的节点类型：作者自己定义的去掉，换成 lc 包里的别名，和对拍程序之间才能传递
+var sharedTypes = map[string]bool{"TreeNode": true, "ListNode": true}
+
+var packageClause = regexp.MustCompile(`(?m)^\s*package\s+\w+\s*$`)
+
+// Impls 找出目录里这道题的所有实现，解析失败、找不到入口的也返回，带上状态
+func Impls(root string, d *Dir, spec *Spec) ([]*Impl, error) {
+	dir := filepath.Join(root, filepath.FromSlash(d.Path))
+	if len(d.goFiles)+len(d.testFiles) > 0 {
+		var names []string
+		var srcs [][]byte
+		for _, name := range append(append([]string{}, d.goFiles...), d.testFiles...) {
+			b, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return nil, err
+			}
+			names, srcs = append(names, name), append(srcs, b)
+		}
+		return implsOf(&Impl{Author: d.Author, Dir: d.Path, Name: d.Author}, names, srcs, spec), nil
+	}
+	if !d.readme {
+		return nil, nil
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		return nil, err
+	}
+	var ret []*Impl
+	for _, block := range extractGo(string(b)) {
+		if strings.TrimSpace(block.Code) == "" {
+			continue
+		}
+		// 去掉 package 子句，换行保留
+		code := packageClause.ReplaceAllStringFunc(block.Code, func(m string) string {
+			return strings.Repeat("\n", strings.Count(m, "\n"))
+		})
+		tmpl := &Impl{Author: d.Author, Dir: d.Path, Block: block.Index, Name: fmt.Sprintf("%s#%d", d.Author, block.Index)}
+		name := fmt.Sprintf("README.md:%d", block.Line)
+		ret = append(ret, implsOf(tmpl, []string{name}, [][]byte{[]byte("package main\n" + code + "\n")}, spec)...)
+	}
+	return ret, nil
+}
+
+// implsOf 解析一组源文件，每个入口一个实现，tmpl 提供作者、目录这些公共字段
+func implsOf(tmpl *Impl, names []string, srcs [][]byte, spec *Spec) []*Impl {
+	tmpl.spec = spec
+	u := &unit{fset: token.NewFileSet(), srcs: srcs, decls: map[string][]ast.Decl{}, methods: map[string][]*ast.FuncDecl{}}
+	for i, src := range srcs {
+		f, err := parser.ParseFile(u.fset, names[i], src, parser.ParseComments)
+		if err != nil {
+			impl := *tmpl
+			impl.Func, impl.Status, impl.Error = spec.Func, StatusParse, err.Error()
+			return []*Impl{&impl}
+		}
+		u.files = append(u.files, f)
+		u.index(f)
+	}
+
+	if spec.Class {
+		impl := *tmpl
+		impl.Func, impl.unit = spec.Func, u
+		for _, d := range u.decls[spec.Func] {
+			if g, ok := d.(*ast.GenDecl); ok {
+				for _, s := range g.Specs {
+					if ts, ok := s.(*ast.TypeSpec); ok && ts.Name.Name == spec.Func {
+						impl.class = ts
+					}
+				}
+			}
+		}
+		impl.entry = u.funcDecl("Constructor")
+		if impl.class == nil || impl.entry == nil {
+			impl.Status, impl.Error = StatusNotFound, fmt.Sprintf("没有找到类型 %s 和 Constructor", spec.Func)
+			return []*Impl{&impl}
+		}
+		impl.sel = u.closure(u.decls[spec.Func][0], impl.entry)
+		impl.Source = u.source(impl.sel)
+		return []*Impl{&impl}
+	}
+
+	first := u.funcDecl(spec.Func)
+	if first == nil {
+		impl := *tmpl
+		impl.Func, impl.Status, impl.Error = spec.Func, StatusNotFound, "没有找到函数 "+spec.Func
+		return []*Impl{&impl}
+	}
+	entries := []*ast.FuncDecl{first}
+	// 同一个目录里名字以入口开头、签名一样的函数是别的解法
+	var variants []*ast.FuncDecl
+	for name, ds := range u.decls {
+		f, ok := ds[0].(*ast.FuncDecl)
+		if ok && name != spec.Func && strings.HasPrefix(name, spec.Func) && u.typeString(f.Type) == u.typeString(first.Type) {
+			variants = append(variants, f)
+		}
+	}
+	sort.Slice(variants, func(i, j int) bool { return variants[i].Name.Name < variants[j].Name.Name })
+	entries = append(entries, variants...)
+
+	var ret []*Impl
+	for i, f := range entries {
+		impl := *tmpl
+		impl.Func, impl.unit, impl.entry = f.Name.Name, u, f
+		if i > 0 {
+			impl.Name += "/" + f.Name.Name
+		}
+		impl.sel = u.closure(f)
+		impl.Source = u.source(impl.sel)
+		ret = append(ret, &impl)
+	}
+	return ret
+}
+
+// index 记下顶层声明，测试函数和 main 不算，抠代码时不会被带进来
+func (u *unit) index(f *ast.File) {
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil {
+				if name := recvName(d.Recv.List[0].Type); name != "" {
+					u.methods[name] = append(u.methods[name], d)
+				}
+				continue
+			}
+			name := d.Name.Name
+			if name == "main" || name == "init" || isTestFunc(name) {
+				continue
+			}
+			u.decls[name] = append(u.decls[name], d)
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			for _, s := range d.Specs {
+				switch s := s.(type) {
+				case *ast.TypeSpec:
+					u.decls[s.Name.Name] = append(u.decls[s.Name.Name], d)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						u.decls[n.Name] = append(u.decls[n.Name], d)
+					}
+				}
+			}
+		}
+	}
+}
+
+func isTestFunc(name string) bool {
+	for _, prefix := range []string{"Test", "Benchmark", "Fuzz", "Example"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// recvName (this *MinStack) -> MinStack，泛型类型的 (q *Queue[T]) -> Queue
+func recvName(e ast.Expr) string {
+	for {
+		switch t := e.(type) {
+		case *ast.StarExpr:
+			e = t.X
+		case *ast.IndexExpr:
+			e = t.X
+		case *ast.IndexListExpr:
+			e = t.X
+		case *ast.Ident:
+			return t.Name
+		default:
+			return ""
+		}
+	}
+}
+
+func (u *unit) funcDecl(name string) *ast.FuncDecl {
+	for _, d := range u.decls[name] {
+		if f, ok := d.(*ast.FuncDecl); ok {
+			return f
+		}
+	}
+	return nil
+}
+
+// closure 从入口出发，把用到的顶层声明都找出来；用到类型时带上它所有的方法。
+// 按名字匹配，局部变量和顶层函数重名时会多带一些声明进来，不影响编译
+func (u *unit) closure(roots ...ast.Decl) map[ast.Decl]bool {
+	seen := map[ast.Decl]bool{}
+	var queue Queue[ast.Decl]
+	for _, d := range roots {
+		queue.Push(d)
+	}
+	for queue.Len() > 0 {
+		d := queue.Pop()
+		if seen[d] || u.isShared(d) {
+			continue
+		}
+		seen[d] = true
+		ast.Inspect(d, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			for _, d := range u.decls[id.Name] {
+				queue.Push(d)
+			}
+			for _, m := range u.methods[id.Name] {
+				queue.Push(m)
+			}
+			return true
+		})
+	}
+	return seen
+}
+
+// isShared 作者自己定义的 TreeNode、ListNode 和它们的方法
+func (u *unit) isShared(d ast.Decl) bool {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		return d.Recv != nil && sharedTypes[recvName(d.Recv.List[0].Type)]
+	case *ast.GenDecl:
+		for _, s := range d.Specs {
+			if ts, ok := s.(*ast.TypeSpec); ok && sharedTypes[ts.Name.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// source 按文件、位置顺序拼出选中的声明，带上文档注释
+func (u *unit) source(sel map[ast.Decl]bool) string {
+	var parts []string
+	for i, f := range u.files {
+		for _, d := range f.Decls {
+			if !sel[d] {
+				continue
+			}
+			start := d.Pos()
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Doc != nil {
+					start = d.Doc.Pos()
+				}
+			case *ast.GenDecl:
+				if d.Doc != nil {
+					start = d.Doc.Pos()
+				}
+			}
+			from, to := u.fset.Position(start).Offset, u.fset.Position(d.End()).Offset
+			parts = append(parts, string(u.srcs[i][from:to]))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// imports 选中的声明用到的包，优先用源文件里写的 import，README 的代码块没写的按 stdPackages 补
+func (u *unit) imports(sel map[ast.Decl]bool) []string {
+	need := map[string]bool{}
+	for _, f := range u.files {
+		byName := map[string]string{}
+		for _, im := range f.Imports {
+			p, _ := strconv.Unquote(im.Path.Value)
+			name := path.Base(p)
+			if im.Name != nil {
+				name = im.Name.Name
+			}
+			byName[name] = p
+		}
+		for _, d := range f.Decls {
+			if !sel[d] {
+				continue
+			}
+			ast.Inspect(d, func(n ast.Node) bool {
+				s, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				id, ok := s.X.(*ast.Ident)
+				if !ok || id.Obj != nil || len(u.decls[id.Name]) > 0 {
+					return true
+				}
+				p, ok := byName[id.Name]
+				if !ok {
+					p, ok = stdPackages[id.Name]
+				}
+				if !ok {
+					return true
+				}
+				if path.Base(p) == id.Name {
+					need[strconv.Quote(p)] = true
+				} else {
+					need[id.Name+" "+strconv.Quote(p)] = true
+				}
+				return true
+			})
+		}
+	}
+	var ret []string
+	for im := range need {
+		ret = append(ret, im)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// shared 选中的声明里用到了哪些共享节点类型
+func (u *unit) shared(sel map[ast.Decl]bool) []string {
+	used := map[string]bool{}
+	for d := range sel {
+		ast.Inspect(d, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && sharedTypes[id.Name] {
+				used[id.Name] = true
+			}
+			return true
+		})
+	}
+	var ret []string
+	for name := range used {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (u *unit) typeString(e ast.Expr) string {
+	var b bytes.Buffer
+	printer.Fprint(&b, u.fset, e)
+	return b.String()
+}
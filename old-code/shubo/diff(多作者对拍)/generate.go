@@ -0,0 +1,371 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 每个实现生成一个包 p<题号>_<作者>_<序号>，放在临时模块 diffcheck 里：
+//   - solution.go 是抠出来的代码，补上 import 和共享节点类型的别名
+//   - run.go 导出 Prepare：先把 LeetCode 格式的参数解码好，返回只调用题解的 run 和编码结果的 out，
+//     测速度时只计 run 的时间
+// 结构和 shubo/readme(README题解验证) 一样，lc 包、work.go、block.go 从那里 go generate 拷贝
+
+const module = "diffcheck"
+
+func (impl *Impl) dir(work string) string {
+	return filepath.Join(work, impl.Pkg)
+}
+
+func (impl *Impl) writeSolution(work string) error {
+	u := impl.unit
+	imports := u.imports(impl.sel)
+	shared := u.shared(impl.sel)
+	if len(shared) > 0 {
+		imports = append(imports, fmt.Sprintf("rlc %q", module+"/lc"))
+	}
+	sort.Strings(imports)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// 由 diff(多作者对拍) 从 %s 生成\n\npackage %s\n\n", impl.Dir, impl.Pkg)
+	if len(imports) > 0 {
+		fmt.Fprintf(&b, "import (\n\t%s\n)\n\n", strings.Join(imports, "\n\t"))
+	}
+	for _, name := range shared {
+		fmt.Fprintf(&b, "type %s = rlc.%s\n", name, name)
+	}
+	b.WriteString("\n" + impl.Source + "\n")
+	return writeFile(impl.dir(work), "solution.go", b.String())
+}
+
+// 参数类型怎么从 JSON 转过来：共享节点类型有专门的转换，只由内置类型组成的直接 json 解码
+var plainType = regexp.MustCompile(`^(\[\])*(int|int64|string|bool|byte|rune|float64)$`)
+
+var decoders = map[string]string{
+	"*TreeNode": "rlc.ParseTree",
+	"*ListNode": "rlc.ParseList",
+}
+
+var encoders = map[string]string{
+	"*TreeNode": "rlc.FormatTree",
+	"*ListNode": "rlc.FormatList",
+}
+
+// fieldTypes 参数或返回值列表展开成每个位置的类型
+func (u *unit) fieldTypes(fl *ast.FieldList) []string {
+	var ret []string
+	if fl == nil {
+		return nil
+	}
+	for _, field := range fl.List {
+		t := u.typeString(field.Type)
+		for range max(1, len(field.Names)) {
+			ret = append(ret, t)
+		}
+	}
+	return ret
+}
+
+func decoder(t string) (string, bool) {
+	if dec, ok := decoders[t]; ok {
+		return dec, true
+	}
+	return "rlc.Decode[" + t + "]", plainType.MatchString(t)
+}
+
+// encode 返回值转成能 json 编码的形式
+func encode(t, expr string) string {
+	if enc, ok := encoders[t]; ok {
+		return enc + "(" + expr + ")"
+	}
+	return expr
+}
+
+// runSource 生成 run.go，参数个数、类型对不上时返回错误
+func (impl *Impl) runSource(spec *Spec) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// 由 diff(多作者对拍) 生成\n\npackage %s\n\n", impl.Pkg)
+	fmt.Fprintf(&b, "import (\n\trjson \"encoding/json\"\n\n\trlc %q\n)\n\n", module+"/lc")
+	b.WriteString("func Prepare(args []rjson.RawMessage) (func(), func() any, error) {\n")
+	fmt.Fprintf(&b, "\tif len(args) != %d {\n\t\treturn nil, nil, rlc.ArgCount(len(args), %d)\n\t}\n", len(spec.Params), len(spec.Params))
+	var err error
+	if spec.Class {
+		err = impl.classBody(&b)
+	} else {
+		err = impl.funcBody(&b, spec)
+	}
+	if err != nil {
+		return "", err
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+func (impl *Impl) funcBody(b *strings.Builder, spec *Spec) error {
+	u, fn := impl.unit, impl.entry
+	params := u.fieldTypes(fn.Type.Params)
+	if len(params) != len(spec.Params) {
+		return fmt.Errorf("%s 有 %d 个参数，题目是 %d 个 %v", fn.Name.Name, len(params), len(spec.Params), spec.Params)
+	}
+	var names []string
+	for i, t := range params {
+		dec, ok := decoder(t)
+		if !ok {
+			return fmt.Errorf("参数 %s 的类型 %s 不支持", spec.Params[i], t)
+		}
+		fmt.Fprintf(b, "\ta%d, err := %s(args[%d])\n\tif err != nil {\n\t\treturn nil, nil, err\n\t}\n", i, dec, i)
+		names = append(names, fmt.Sprintf("a%d", i))
+	}
+	call := fmt.Sprintf("%s(%s)", fn.Name.Name, strings.Join(names, ", "))
+	switch results := u.fieldTypes(fn.Type.Results); len(results) {
+	case 0:
+		// 原地修改的题目，比如 283. 移动零，答案是第一个参数
+		fmt.Fprintf(b, "\treturn func() { %s }, func() any { return a0 }, nil\n", call)
+	case 1:
+		fmt.Fprintf(b, "\tvar r %s\n", results[0])
+		fmt.Fprintf(b, "\treturn func() { r = %s }, func() any { return %s }, nil\n", call, encode(results[0], "r"))
+	default:
+		return fmt.Errorf("%s 有 %d 个返回值", fn.Name.Name, len(results))
+	}
+	return nil
+}
+
+// classBody 设计类题目：args[0] 是操作名，args[1] 是每个操作的参数，第一个操作是构造。
+// 每个操作先解码成一个闭包，run 里只有构造和方法调用
+func (impl *Impl) classBody(b *strings.Builder) error {
+	u, ctor := impl.unit, impl.entry
+	results := u.fieldTypes(ctor.Type.Results)
+	if len(results) != 1 {
+		return fmt.Errorf("Constructor 应该返回一个 %s", impl.Func)
+	}
+	b.WriteString("\tops, err := rlc.Decode[[]string](args[0])\n\tif err != nil {\n\t\treturn nil, nil, err\n\t}\n")
+	b.WriteString("\tparams, err := rlc.Decode[[][]rjson.RawMessage](args[1])\n\tif err != nil {\n\t\treturn nil, nil, err\n\t}\n")
+	b.WriteString("\tif len(ops) == 0 || len(ops) != len(params) {\n\t\treturn nil, nil, rlc.ArgCount(len(params), len(ops))\n\t}\n")
+	ctorArgs, err := decodeParams(b, "\t", "c", "params[0]", u.fieldTypes(ctor.Type.Params))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(b, "\tvar obj %s\n", results[0])
+	b.WriteString("\tcalls := make([]func() any, len(ops)-1)\n\tfor i := 1; i < len(ops); i++ {\n")
+
+	var cases strings.Builder
+	usesP := false
+	for _, m := range u.methods[impl.Func] {
+		if !ast.IsExported(m.Name.Name) {
+			continue
+		}
+		params := u.fieldTypes(m.Type.Params)
+		usesP = usesP || len(params) > 0
+		r := []rune(m.Name.Name)
+		r[0] = unicode.ToLower(r[0])
+		fmt.Fprintf(&cases, "\t\tcase %q:\n", string(r))
+		args, err := decodeParams(&cases, "\t\t\t", "m", "p", params)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Name.Name, err)
+		}
+		call := fmt.Sprintf("obj.%s(%s)", m.Name.Name, strings.Join(args, ", "))
+		switch results := u.fieldTypes(m.Type.Results); len(results) {
+		case 0:
+			fmt.Fprintf(&cases, "\t\t\tcalls[i-1] = func() any { %s; return nil }\n", call)
+		case 1:
+			fmt.Fprintf(&cases, "\t\t\tcalls[i-1] = func() any { return %s }\n", encode(results[0], call))
+		default:
+			return fmt.Errorf("%s 有 %d 个返回值", m.Name.Name, len(results))
+		}
+	}
+	if usesP {
+		b.WriteString("\t\tp := params[i]\n")
+	}
+	b.WriteString("\t\tswitch ops[i] {\n" + cases.String())
+	b.WriteString("\t\tdefault:\n\t\t\treturn nil, nil, rlc.UnknownOp(ops[i])\n\t\t}\n\t}\n")
+	b.WriteString("\tout := make([]any, len(ops))\n")
+	fmt.Fprintf(b, "\treturn func() {\n\t\tobj = Constructor(%s)\n\t\tfor i, call := range calls {\n\t\t\tout[i+1] = call()\n\t\t}\n\t}, func() any { return out }, nil\n", strings.Join(ctorArgs, ", "))
+	return nil
+}
+
+// decodeParams 生成从 list[i] 解码出第 i 个参数的代码，返回变量名
+func decodeParams(b *strings.Builder, indent, prefix, list string, types []string) ([]string, error) {
+	var names []string
+	for i, t := range types {
+		dec, ok := decoder(t)
+		if !ok {
+			return nil, fmt.Errorf("参数类型 %s 不支持", t)
+		}
+		name := fmt.Sprintf("%s%d", prefix, i)
+		fmt.Fprintf(b, "%s%s, err := rlc.Param(%s, %d, %s)\n%sif err != nil {\n%s\treturn nil, nil, err\n%s}\n", indent, name, list, i, dec, indent, indent, indent)
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// harnessSource 对拍程序，两种模式：
+//
+//	check：每个任务单独一个 goroutine 跑一组输入，panic 和超时都记下来
+//	bench：每个实现把测速用的输入跑若干轮，每轮先解码好参数，只计调用题解的时间和分配
+func harnessSource(pkgs []string) string {
+	var imports, prepares strings.Builder
+	for _, p := range pkgs {
+		fmt.Fprintf(&imports, "\t%q\n", module+"/"+p)
+		fmt.Fprintf(&prepares, "\t%q: %s.Prepare,\n", p, p)
+	}
+	r := strings.NewReplacer("{{imports}}", imports.String(), "{{prepares}}", prepares.String())
+	return r.Replace(`// 由 diff(多作者对拍) 生成
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+{{imports}})
+
+type prepareFunc func([]json.RawMessage) (func(), func() any, error)
+
+var prepares = map[string]prepareFunc{
+{{prepares}}}
+
+type checkJob struct {
+	Pkg  string
+	Args []json.RawMessage
+}
+
+type checkResult struct {
+	Output json.RawMessage
+	Error  string
+}
+
+type benchJob struct {
+	Pkg    string
+	Inputs [][]json.RawMessage
+	Rounds int
+}
+
+type benchResult struct {
+	Ns, Allocs, Bytes int64
+	Error             string
+}
+
+// harness check|bench <任务文件> <结果文件> <超时>，结果写文件，题解自己往标准输出打印也不影响
+func main() {
+	testing.Init()
+	src, err := os.ReadFile(os.Args[2])
+	if err != nil {
+		panic(err)
+	}
+	var out any
+	switch os.Args[1] {
+	case "check":
+		timeout, err := time.ParseDuration(os.Args[4])
+		if err != nil {
+			panic(err)
+		}
+		var jobs []checkJob
+		if err := json.Unmarshal(src, &jobs); err != nil {
+			panic(err)
+		}
+		results := make([]checkResult, len(jobs))
+		for i, j := range jobs {
+			results[i] = check(prepares[j.Pkg], j.Args, timeout)
+		}
+		out = results
+	case "bench":
+		var jobs []benchJob
+		if err := json.Unmarshal(src, &jobs); err != nil {
+			panic(err)
+		}
+		results := make([]benchResult, len(jobs))
+		for i, j := range jobs {
+			results[i] = bench(prepares[j.Pkg], j.Inputs, j.Rounds)
+		}
+		out = results
+	}
+	b, _ := json.Marshal(out)
+	if err := os.WriteFile(os.Args[3], b, 0o644); err != nil {
+		panic(err)
+	}
+	os.Exit(0) // 超时的 goroutine 还在跑
+}
+
+func check(p prepareFunc, args []json.RawMessage, timeout time.Duration) checkResult {
+	done := make(chan checkResult, 1)
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				done <- checkResult{Error: fmt.Sprint("panic: ", e)}
+			}
+		}()
+		run, out, err := p(args)
+		if err != nil {
+			done <- checkResult{Error: "参数错误: " + err.Error()}
+			return
+		}
+		run()
+		b, err := json.Marshal(out())
+		if err != nil {
+			done <- checkResult{Error: err.Error()}
+			return
+		}
+		done <- checkResult{Output: b}
+	}()
+	select {
+	case r := <-done:
+		return r
+	case <-time.After(timeout):
+		return checkResult{Error: "超时"}
+	}
+}
+
+// bench 用 testing.Benchmark 跑 rounds 轮，每轮耗时取中位数；分配次数和字节数取 testing 统计的每轮平均，
+// 解码参数时停表，不算在内。自己读 MemStats 会把运行时的零碎分配也算进来，出现“0 次分配 12 字节”这种数
+func bench(p prepareFunc, inputs [][]json.RawMessage, rounds int) benchResult {
+	rounds = max(rounds, 1)
+	flag.Set("test.benchtime", fmt.Sprintf("%dx", rounds))
+	// 计时的时候 append 不能扩容，不然也算成题解的分配
+	ns := make([]int64, 0, rounds)
+	var failed string
+	r := testing.Benchmark(func(b *testing.B) {
+		// 基准函数在 testing 自己的 goroutine 里跑，panic 要在这里接住
+		defer func() {
+			if e := recover(); e != nil {
+				failed = fmt.Sprint("panic: ", e)
+			}
+		}()
+		// testing 先跑 1 轮再跑 rounds 轮，只留最后一次的耗时
+		ns = ns[:0]
+		for range b.N {
+			b.StopTimer()
+			runs := make([]func(), len(inputs))
+			for i, in := range inputs {
+				run, _, err := p(in)
+				if err != nil {
+					failed = err.Error()
+					return
+				}
+				runs[i] = run
+			}
+			b.StartTimer()
+			start := time.Now()
+			for _, run := range runs {
+				run()
+			}
+			ns = append(ns, time.Since(start).Nanoseconds())
+		}
+	})
+	if failed != "" {
+		return benchResult{Error: failed}
+	}
+	slices.Sort(ns)
+	return benchResult{Ns: ns[len(ns)/2], Allocs: r.AllocsPerOp(), Bytes: r.AllocedBytesPerOp()}
+}
+`)
+}
@@ -0,0 +1,27 @@
+package lc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate go run ../../copygen(拷贝生成)/main.go -- ../../readme(README题解验证)/lc/lc.go
+
+// 设计类题目用的函数，只有 diff(多作者对拍) 用；lc.go 和 ring.go 是 go generate 拷贝的
+
+// Param 设计类题目第 i 个参数，缺参数时报错，不能让 null 解码成零值
+func Param[T any](params []json.RawMessage, i int, dec func(json.RawMessage) (T, error)) (T, error) {
+	if i >= len(params) {
+		var zero T
+		return zero, fmt.Errorf("缺少第 %d 个参数", i+1)
+	}
+	return dec(params[i])
+}
+
+func ArgCount(got, want int) error {
+	return fmt.Errorf("需要 %d 个参数，实际是 %d 个", want, got)
+}
+
+func UnknownOp(op string) error {
+	return fmt.Errorf("没有方法 %s", op)
+}
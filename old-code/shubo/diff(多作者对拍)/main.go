@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../storyboard(分镜生成)/meta.go ../coverage(覆盖矩阵)/resolve.go ../coverage(覆盖矩阵)/scan.go ../judge(本地判题服务)/parse.go ../readme(README题解验证)/work.go ../readme(README题解验证)/block.go
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../queue(环形队列)/ring.go
+
+// 同一道题有好几份实现的，用同样的输入（题目示例 + 随机生成）全跑一遍，列出输出不一致的输入，再比速度和内存分配
+// go run $(ls *.go | grep -v _test) 155 207
+// go run $(ls *.go | grep -v _test) -side 146
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	opt := DefaultOptions
+	flag.StringVar(&opt.Root, "root", "../..", "old-code 目录，下面每个子目录是一个作者")
+	flag.StringVar(&opt.Meta, "meta", "../../../docs/leetcode-hot-100.json", "题目元数据")
+	work := flag.String("work", "", "在这个目录下新建一个目录放生成的代码，跑完保留；默认用系统临时目录，跑完删除")
+	format := flag.String("format", "text", "输出格式：text、md 或 json")
+	flag.Int64Var(&opt.Seed, "seed", opt.Seed, "随机种子")
+	flag.IntVar(&opt.MaxN, "n", opt.MaxN, "生成输入的最大规模")
+	flag.IntVar(&opt.PerN, "per", opt.PerN, "每个规模生成几组输入")
+	flag.IntVar(&opt.BenchN, "size", opt.BenchN, "测速输入的规模")
+	flag.IntVar(&opt.Rounds, "rounds", opt.Rounds, "测速跑几轮")
+	flag.DurationVar(&opt.Timeout, "timeout", opt.Timeout, "每组输入的超时")
+	flag.IntVar(&opt.Show, "show", opt.Show, "每道题最多列出几组不一致的输入")
+	side := flag.Bool("side", false, "text 格式下并排显示各个实现的代码")
+	width := flag.Int("width", 60, "并排显示时每列的宽度")
+	flag.Parse()
+	opt.IDs = flag.Args()
+
+	if *work != "" {
+		if err := os.MkdirAll(*work, 0o755); err != nil {
+			return err
+		}
+	}
+	dir, err := os.MkdirTemp(*work, "diff")
+	if err != nil {
+		return err
+	}
+	if *work == "" {
+		defer os.RemoveAll(dir)
+	} else {
+		fmt.Fprintln(os.Stderr, "生成的代码在", dir)
+	}
+	opt.Work = dir
+	reports, err := Run(opt)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "json":
+		b, _ := json.MarshalIndent(reports, "", "  ")
+		fmt.Println(string(b))
+	case "md":
+		fmt.Print(Markdown(reports))
+	default:
+		PrintText(os.Stdout, reports, *side, *width)
+	}
+	return nil
+}
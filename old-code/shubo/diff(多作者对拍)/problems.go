@@ -0,0 +1,466 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// 对拍的题目：参数、输出怎么比较、LeetCode 的示例、随机输入生成
+// 设计类题目的输入是两个数组：操作名和每个操作的参数，输出是每个操作的返回值，和 LeetCode 的格式一样
+
+// Order 输出里哪些顺序无关紧要，比较前先排好序
+type Order int
+
+const (
+	Exact     Order = iota
+	SortInner       // 1. 两数之和：[i, j] 和 [j, i] 都对
+	SortOuter       // 46. 全排列：排列之间的顺序不要求
+	SortBoth        // 15. 三数之和：三元组内部和三元组之间都不要求
+)
+
+type Example struct {
+	Input    string // LeetCode 格式，和判题服务的用例一样
+	Expected string
+}
+
+type Spec struct {
+	ID       string
+	Func     string // 函数名，设计类题目是类名
+	Class    bool
+	Params   []string
+	Order    Order
+	Examples []Example
+	// Gen 生成规模为 n 的一组参数，每个参数单独 json 编码
+	Gen func(r *rand.Rand, n int) []any
+	// MaxN 正确性对拍的最大规模，BenchN 测速度用的规模，0 表示用默认值
+	MaxN, BenchN int
+}
+
+var classParams = []string{"ops", "args"}
+
+var specs = []*Spec{
+	{
+		ID: "1", Func: "twoSum", Params: []string{"nums", "target"}, Order: SortInner,
+		Examples: []Example{
+			{"nums = [2,7,11,15], target = 9", "[0,1]"},
+			{"nums = [3,2,4], target = 6", "[1,2]"},
+			{"nums = [3,3], target = 6", "[0,1]"},
+		},
+		Gen: genTwoSum,
+	},
+	{
+		ID: "15", Func: "threeSum", Params: []string{"nums"}, Order: SortBoth,
+		Examples: []Example{
+			{"nums = [-1,0,1,2,-1,-4]", "[[-1,-1,2],[-1,0,1]]"},
+			{"nums = [0,1,1]", "[]"},
+			{"nums = [0,0,0]", "[[0,0,0]]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randInts(r, max(n, 3), -n/2-2, n/2+2)}
+		},
+		BenchN: 2000,
+	},
+	{
+		ID: "20", Func: "isValid", Params: []string{"s"},
+		Examples: []Example{
+			{`s = "()"`, "true"},
+			{`s = "()[]{}"`, "true"},
+			{`s = "(]"`, "false"},
+			{`s = "([])"`, "true"},
+		},
+		Gen: genBrackets,
+	},
+	{
+		ID: "21", Func: "mergeTwoLists", Params: []string{"list1", "list2"},
+		Examples: []Example{
+			{"list1 = [1,2,4], list2 = [1,3,4]", "[1,1,2,3,4,4]"},
+			{"list1 = [], list2 = []", "[]"},
+			{"list1 = [], list2 = [0]", "[0]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			a, b := randInts(r, r.Intn(n+1), -n, n), randInts(r, r.Intn(n+1), -n, n)
+			sort.Ints(a)
+			sort.Ints(b)
+			return []any{a, b}
+		},
+	},
+	{
+		ID: "46", Func: "permute", Params: []string{"nums"}, Order: SortOuter,
+		Examples: []Example{
+			{"nums = [1,2,3]", "[[1,2,3],[1,3,2],[2,1,3],[2,3,1],[3,1,2],[3,2,1]]"},
+			{"nums = [0,1]", "[[0,1],[1,0]]"},
+			{"nums = [1]", "[[1]]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{distinctInts(r, max(n, 1), -10, 10)}
+		},
+		MaxN: 6, BenchN: 8,
+	},
+	{
+		ID: "56", Func: "merge", Params: []string{"intervals"}, Order: SortOuter,
+		Examples: []Example{
+			{"intervals = [[1,3],[2,6],[8,10],[15,18]]", "[[1,6],[8,10],[15,18]]"},
+			{"intervals = [[1,4],[4,5]]", "[[1,5]]"},
+			{"intervals = [[4,7],[1,4]]", "[[1,7]]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			iv := make([][]int, max(n, 1))
+			for i := range iv {
+				a := r.Intn(3*len(iv) + 1)
+				iv[i] = []int{a, a + r.Intn(4)}
+			}
+			return []any{iv}
+		},
+	},
+	{
+		ID: "70", Func: "climbStairs", Params: []string{"n"},
+		Examples: []Example{
+			{"n = 2", "2"},
+			{"n = 3", "3"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{1 + r.Intn(min(n, 44)+1)}
+		},
+		BenchN: 44,
+	},
+	{
+		ID: "104", Func: "maxDepth", Params: []string{"root"},
+		Examples: []Example{
+			{"root = [3,9,20,null,null,15,7]", "3"},
+			{"root = [1,null,2]", "2"},
+			{"root = []", "0"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randTree(r, r.Intn(n+1))}
+		},
+	},
+	{
+		ID: "121", Func: "maxProfit", Params: []string{"prices"},
+		Examples: []Example{
+			{"prices = [7,1,5,3,6,4]", "5"},
+			{"prices = [7,6,4,3,1]", "0"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randInts(r, max(n, 1), 0, 2*n+1)}
+		},
+	},
+	{
+		ID: "128", Func: "longestConsecutive", Params: []string{"nums"},
+		Examples: []Example{
+			{"nums = [100,4,200,1,3,2]", "4"},
+			{"nums = [0,3,7,2,5,8,4,6,0,1]", "9"},
+			{"nums = [1,0,1,2]", "3"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randInts(r, n, -2*n, 2*n)}
+		},
+		BenchN: 100000,
+	},
+	{
+		ID: "136", Func: "singleNumber", Params: []string{"nums"},
+		Examples: []Example{
+			{"nums = [2,2,1]", "1"},
+			{"nums = [4,1,2,1,2]", "4"},
+			{"nums = [1]", "1"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			vals := distinctInts(r, n/2+1, -3*n-3, 3*n+3)
+			nums := append([]int{vals[0]}, vals[1:]...)
+			nums = append(nums, vals[1:]...)
+			r.Shuffle(len(nums), func(i, j int) { nums[i], nums[j] = nums[j], nums[i] })
+			return []any{nums}
+		},
+	},
+	{
+		ID: "146", Func: "LRUCache", Class: true, Params: classParams,
+		Examples: []Example{
+			{`["LRUCache","put","put","get","put","get","put","get","get","get"], [[2],[1,1],[2,2],[1],[3,3],[2],[4,4],[1],[3],[4]]`, "[null,null,null,1,null,-1,null,-1,3,4]"},
+		},
+		Gen: genLRU,
+	},
+	{
+		ID: "155", Func: "MinStack", Class: true, Params: classParams,
+		Examples: []Example{
+			{`["MinStack","push","push","push","getMin","pop","top","getMin"], [[],[-2],[0],[-3],[],[],[],[]]`, "[null,null,null,null,-3,null,0,-2]"},
+		},
+		Gen: genMinStack,
+	},
+	{
+		ID: "169", Func: "majorityElement", Params: []string{"nums"},
+		Examples: []Example{
+			{"nums = [3,2,3]", "3"},
+			{"nums = [2,2,1,1,1,2,2]", "2"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			n = max(n, 1)
+			m := r.Intn(2*n+1) - n
+			nums := make([]int, n)
+			for i := range nums {
+				nums[i] = m
+				if i >= n/2+1 {
+					nums[i] = r.Intn(2*n+1) - n
+				}
+			}
+			r.Shuffle(n, func(i, j int) { nums[i], nums[j] = nums[j], nums[i] })
+			return []any{nums}
+		},
+	},
+	{
+		ID: "206", Func: "reverseList", Params: []string{"head"},
+		Examples: []Example{
+			{"head = [1,2,3,4,5]", "[5,4,3,2,1]"},
+			{"head = [1,2]", "[2,1]"},
+			{"head = []", "[]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randInts(r, n, -n, n)}
+		},
+	},
+	{
+		ID: "207", Func: "canFinish", Params: []string{"numCourses", "prerequisites"},
+		Examples: []Example{
+			{"numCourses = 2, prerequisites = [[1,0]]", "true"},
+			{"numCourses = 2, prerequisites = [[1,0],[0,1]]", "false"},
+		},
+		Gen:    genCourses,
+		BenchN: 2000,
+	},
+	{
+		ID: "226", Func: "invertTree", Params: []string{"root"},
+		Examples: []Example{
+			{"root = [4,2,7,1,3,6,9]", "[4,7,2,9,6,3,1]"},
+			{"root = [2,1,3]", "[2,3,1]"},
+			{"root = []", "[]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randTree(r, r.Intn(n+1))}
+		},
+	},
+	{
+		ID: "283", Func: "moveZeroes", Params: []string{"nums"},
+		Examples: []Example{
+			{"nums = [0,1,0,3,12]", "[1,3,12,0,0]"},
+			{"nums = [0]", "[0]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randInts(r, max(n, 1), -2, 3)}
+		},
+	},
+	{
+		ID: "739", Func: "dailyTemperatures", Params: []string{"temperatures"},
+		Examples: []Example{
+			{"temperatures = [73,74,75,71,69,72,76,73]", "[1,1,4,2,1,1,0,0]"},
+			{"temperatures = [30,40,50,60]", "[1,1,1,0]"},
+			{"temperatures = [30,60,90]", "[1,1,0]"},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			return []any{randInts(r, max(n, 1), 30, 100)}
+		},
+	},
+}
+
+func specByID(id string) *Spec {
+	for _, s := range specs {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// randInts n 个 [lo, hi] 里的数，可能重复
+func randInts(r *rand.Rand, n, lo, hi int) []int {
+	ret := make([]int, n)
+	for i := range ret {
+		ret[i] = lo + r.Intn(hi-lo+1)
+	}
+	return ret
+}
+
+// distinctInts n 个不重复的数，区间不够大时往外扩
+func distinctInts(r *rand.Rand, n, lo, hi int) []int {
+	if hi-lo+1 < 2*n {
+		hi = lo + 2*n
+	}
+	seen := map[int]bool{}
+	ret := make([]int, 0, n)
+	for len(ret) < n {
+		v := lo + r.Intn(hi-lo+1)
+		if !seen[v] {
+			seen[v] = true
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// genTwoSum 保证只有一组答案，答案不唯一时各人返回哪一组都算对，没法比
+func genTwoSum(r *rand.Rand, n int) []any {
+	n = max(n, 2)
+	if n > 50 {
+		// 规模大时随机很难碰上唯一解：其余的数都是 4 的倍数，答案是两个模 4 余 1 的数，和模 4 余 2 的只有这一对
+		nums := distinctInts(r, n, -n, n)
+		for k := range nums {
+			nums[k] *= 4
+		}
+		i, j := r.Intn(n), r.Intn(n-1)
+		if j >= i {
+			j++
+		}
+		nums[i], nums[j] = nums[i]+1, nums[j]+1
+		return []any{nums, nums[i] + nums[j]}
+	}
+	for {
+		nums := randInts(r, n, -n, n)
+		i, j := r.Intn(n), r.Intn(n-1)
+		if j >= i {
+			j++
+		}
+		target := nums[i] + nums[j]
+		pairs := 0
+		for a := range nums {
+			for b := a + 1; b < n; b++ {
+				if nums[a]+nums[b] == target {
+					pairs++
+				}
+			}
+		}
+		if pairs == 1 {
+			return []any{nums, target}
+		}
+	}
+}
+
+// genBrackets 一半是合法的括号串，另一半随机；题目保证字符串不为空
+func genBrackets(r *rand.Rand, n int) []any {
+	const open, close = "([{", ")]}"
+	n = max(n, 1)
+	b := make([]byte, 0, n)
+	if r.Intn(2) == 0 {
+		var stack []byte
+		for len(b)+len(stack) < n || len(stack) > 0 {
+			if len(stack) > 0 && (len(b)+len(stack) >= n || r.Intn(2) == 0) {
+				b = append(b, stack[len(stack)-1])
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			k := r.Intn(3)
+			b = append(b, open[k])
+			stack = append(stack, close[k])
+		}
+		return []any{string(b)}
+	}
+	for range n {
+		b = append(b, (open + close)[r.Intn(6)])
+	}
+	return []any{string(b)}
+}
+
+// randTree n 个节点的随机形状，输出 LeetCode 的层序格式
+func randTree(r *rand.Rand, n int) []any {
+	type node struct {
+		val         int
+		left, right int // 子节点下标，-1 表示空
+	}
+	if n == 0 {
+		return []any{}
+	}
+	nodes := []node{{r.Intn(201) - 100, -1, -1}}
+	for len(nodes) < n {
+		cur := 0
+		for {
+			child := &nodes[cur].left
+			if r.Intn(2) == 1 {
+				child = &nodes[cur].right
+			}
+			if *child == -1 {
+				*child = len(nodes)
+				nodes = append(nodes, node{r.Intn(201) - 100, -1, -1})
+				break
+			}
+			cur = *child
+		}
+	}
+	var ret []any
+	queue := NewQueue[int](2*n + 1)
+	queue.Push(0)
+	for queue.Len() > 0 {
+		i := queue.Pop()
+		if i == -1 {
+			ret = append(ret, nil)
+			continue
+		}
+		ret = append(ret, nodes[i].val)
+		queue.Push(nodes[i].left)
+		queue.Push(nodes[i].right)
+	}
+	for ret[len(ret)-1] == nil {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
+// genLRU key 的范围是容量的两倍，保证有淘汰也有命中
+func genLRU(r *rand.Rand, n int) []any {
+	capacity := 1 + r.Intn(n/4+1)
+	ops, args := []string{"LRUCache"}, []any{[]int{capacity}}
+	for range n {
+		k := r.Intn(2*capacity + 1)
+		if r.Intn(2) == 0 {
+			ops, args = append(ops, "put"), append(args, []int{k, r.Intn(100)})
+		} else {
+			ops, args = append(ops, "get"), append(args, []int{k})
+		}
+	}
+	return []any{ops, args}
+}
+
+// genMinStack 栈空的时候只 push，值的范围小一点，最小值重复的情况多
+func genMinStack(r *rand.Rand, n int) []any {
+	ops, args := []string{"MinStack"}, []any{[]int{}}
+	size := 0
+	for range n {
+		op := "push"
+		if size > 0 {
+			op = []string{"push", "push", "pop", "top", "getMin"}[r.Intn(5)]
+		}
+		switch op {
+		case "push":
+			size++
+			args = append(args, []int{r.Intn(21) - 10})
+		case "pop":
+			size--
+			args = append(args, []int{})
+		default:
+			args = append(args, []int{})
+		}
+		ops = append(ops, op)
+	}
+	return []any{ops, args}
+}
+
+// genCourses 先按一个随机顺序生成无环的依赖，再有一半的概率加一条可能成环的边
+func genCourses(r *rand.Rand, n int) []any {
+	n = max(n, 1)
+	order := r.Perm(n)
+	seen := map[[2]int]bool{}
+	pre := [][]int{}
+	add := func(a, b int) {
+		if a != b && !seen[[2]int{a, b}] {
+			seen[[2]int{a, b}] = true
+			pre = append(pre, []int{a, b})
+		}
+	}
+	for range r.Intn(2*n + 1) {
+		i, j := r.Intn(n), r.Intn(n)
+		if i > j {
+			i, j = j, i
+		}
+		// 排在后面的课依赖排在前面的课
+		add(order[j], order[i])
+	}
+	if r.Intn(2) == 0 {
+		add(r.Intn(n), r.Intn(n))
+	}
+	return []any{n, pre}
+}
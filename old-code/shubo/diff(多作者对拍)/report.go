@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 显示时输入、输出最多这么多字节，测速的输入动辄几十 KB
+const maxShow = 200
+
+func shorten(s string) string {
+	if len(s) <= maxShow {
+		return s
+	}
+	cut := maxShow
+	for !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s…（共 %d 字节）", s[:cut], len(s))
+}
+
+func inputLabel(in Input) string {
+	if in.Kind == KindExample {
+		return in.Kind
+	}
+	return fmt.Sprintf("%s n=%d", in.Kind, in.N)
+}
+
+func inputSummary(rep *Report) string {
+	return fmt.Sprintf("输入 %d 组（示例 %d，生成 %d，测速 %d 组 n=%d）",
+		rep.Inputs[KindExample]+rep.Inputs[KindGen]+rep.Inputs[KindBench],
+		rep.Inputs[KindExample], rep.Inputs[KindGen], rep.Inputs[KindBench], rep.BenchN)
+}
+
+// PrintText 每道题：实现列表、不一致的输入、速度，side 为 true 时最后并排显示代码，每列 width 个字符宽
+func PrintText(w io.Writer, reports []*Report, side bool, width int) {
+	for _, rep := range reports {
+		fmt.Fprintf(w, "%s. %s\n", rep.ID, rep.Title)
+		if len(rep.Impls) == 0 {
+			fmt.Fprintln(w, "  没有找到实现")
+			fmt.Fprintln(w)
+			continue
+		}
+		for _, r := range rep.Impls {
+			fmt.Fprintf(w, "  %-24s %s  %s", r.Name, r.Status, r.Dir)
+			if r.Status == StatusOK {
+				fmt.Fprintf(w, "  示例错 %d，出错 %d，少数派 %d", r.Wrong, r.Errors, r.Minority)
+			}
+			fmt.Fprintln(w)
+			if r.Error != "" && r.Status != StatusOK {
+				fmt.Fprintf(w, "    %s\n", strings.ReplaceAll(r.Error, "\n", "\n    "))
+			}
+		}
+		if rep.Inputs[KindExample]+rep.Inputs[KindGen] > 0 {
+			fmt.Fprintf(w, "  %s，不一致 %d 组\n", inputSummary(rep), rep.Disagree)
+		}
+		for _, d := range rep.Disagreements {
+			fmt.Fprintf(w, "  [%s] %s\n", inputLabel(d.Input), shorten(d.Text))
+			if d.Expected != "" {
+				fmt.Fprintf(w, "    期望: %s\n", d.Expected)
+			}
+			for _, g := range d.Groups {
+				fmt.Fprintf(w, "    %s: %s\n", strings.Join(g.Impls, ", "), shorten(g.Output))
+			}
+		}
+		if hasBench(rep) {
+			fmt.Fprintf(w, "  速度（测速输入跑 %d 轮取中位数）\n", rep.Rounds)
+			for _, r := range rep.Impls {
+				if b := r.Bench; b != nil {
+					if b.Error != "" {
+						fmt.Fprintf(w, "    %-24s %s\n", r.Name, b.Error)
+						continue
+					}
+					fmt.Fprintf(w, "    %-24s %12s  %6.2fx  %8d 次分配  %10d 字节\n", r.Name, time.Duration(b.Ns), b.Relative, b.Allocs, b.Bytes)
+				}
+			}
+		}
+		if side {
+			var titles, sources []string
+			for _, r := range rep.Impls {
+				if r.Source != "" {
+					titles, sources = append(titles, r.Name), append(sources, r.Source)
+				}
+			}
+			if len(sources) > 0 {
+				fmt.Fprintln(w)
+				SideBySide(w, titles, sources, width)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func hasBench(rep *Report) bool {
+	for _, r := range rep.Impls {
+		if r.Bench != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// SideBySide 代码按列并排，超过列宽的行截断，制表符换成 4 个空格
+func SideBySide(w io.Writer, titles, sources []string, width int) {
+	cols := make([][]string, len(sources))
+	rows := 0
+	for i, src := range sources {
+		cols[i] = strings.Split(strings.ReplaceAll(src, "\t", "    "), "\n")
+		rows = max(rows, len(cols[i]))
+	}
+	line := func(cells []string) {
+		var b strings.Builder
+		for i, c := range cells {
+			if i > 0 {
+				b.WriteString(" │ ")
+			}
+			c = truncate(c, width)
+			b.WriteString(c)
+			if i < len(cells)-1 {
+				b.WriteString(strings.Repeat(" ", width-displayWidth(c)))
+			}
+		}
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+	}
+	line(titles)
+	sep := make([]string, len(titles))
+	for i := range sep {
+		sep[i] = strings.Repeat("─", width)
+	}
+	fmt.Fprintln(w, strings.Join(sep, "─┼─"))
+	for r := range rows {
+		cells := make([]string, len(cols))
+		for i, col := range cols {
+			if r < len(col) {
+				cells[i] = col[r]
+			}
+		}
+		line(cells)
+	}
+}
+
+// displayWidth 终端里的显示宽度，中文和全角符号占两格
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+func runeWidth(r rune) int {
+	if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) ||
+		r >= 0x3000 && r <= 0x303f || r >= 0xff00 && r <= 0xff60 || r >= 0xffe0 && r <= 0xffe6 {
+		return 2
+	}
+	return 1
+}
+
+func truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	n := 0
+	for i, r := range s {
+		if n+runeWidth(r) > width-1 {
+			return s[:i] + "…" + strings.Repeat(" ", width-1-n)
+		}
+		n += runeWidth(r)
+	}
+	return s
+}
+
+// Markdown 和文本一样的内容，代码用 HTML 表格并排
+func Markdown(reports []*Report) string {
+	var b strings.Builder
+	b.WriteString("# 多作者对拍\n")
+	for _, rep := range reports {
+		fmt.Fprintf(&b, "\n## %s. %s\n\n", rep.ID, rep.Title)
+		if len(rep.Impls) == 0 {
+			b.WriteString("没有找到实现。\n")
+			continue
+		}
+		b.WriteString("| 实现 | 目录 | 状态 | 示例错 | 出错 | 少数派 | 每轮耗时 | 相对 | 分配次数 | 分配字节 |\n")
+		b.WriteString("|---|---|---|---|---|---|---|---|---|---|\n")
+		for _, r := range rep.Impls {
+			fmt.Fprintf(&b, "| %s | %s | %s |", r.Name, r.Dir, r.Status)
+			if r.Status == StatusOK {
+				fmt.Fprintf(&b, " %d | %d | %d |", r.Wrong, r.Errors, r.Minority)
+			} else {
+				b.WriteString(" | | |")
+			}
+			if bn := r.Bench; bn != nil && bn.Error == "" {
+				fmt.Fprintf(&b, " %s | %.2fx | %d | %d |\n", time.Duration(bn.Ns), bn.Relative, bn.Allocs, bn.Bytes)
+			} else {
+				b.WriteString(" | | | |\n")
+			}
+		}
+		for _, r := range rep.Impls {
+			if r.Error != "" && r.Status != StatusOK {
+				fmt.Fprintf(&b, "\n%s %s：\n\n```\n%s\n```\n", r.Name, r.Status, r.Error)
+			}
+		}
+		if rep.Inputs[KindExample]+rep.Inputs[KindGen] > 0 {
+			fmt.Fprintf(&b, "\n%s，不一致 %d 组。\n", inputSummary(rep), rep.Disagree)
+		}
+		for _, d := range rep.Disagreements {
+			fmt.Fprintf(&b, "\n**%s** `%s`\n\n", inputLabel(d.Input), shorten(d.Text))
+			if d.Expected != "" {
+				fmt.Fprintf(&b, "- 期望：`%s`\n", d.Expected)
+			}
+			for _, g := range d.Groups {
+				fmt.Fprintf(&b, "- %s：`%s`\n", strings.Join(g.Impls, "、"), shorten(g.Output))
+			}
+		}
+		var heads, cells strings.Builder
+		for _, r := range rep.Impls {
+			if r.Source == "" {
+				continue
+			}
+			fmt.Fprintf(&heads, "<th>%s</th>", html.EscapeString(r.Name))
+			fmt.Fprintf(&cells, "<td valign=\"top\"><pre>%s</pre></td>", html.EscapeString(r.Source))
+		}
+		if heads.Len() > 0 {
+			fmt.Fprintf(&b, "\n<table>\n<tr>%s</tr>\n<tr>%s</tr>\n</table>\n", heads.String(), cells.String())
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package dsu
+
+// 并查集，元素是 0 ~ n-1
+// 按秩合并 + 路径压缩，Find 均摊接近 O(1)；同时维护每个集合的大小和集合个数。
+
+type DSU struct {
+	parent []int
+	rank   []int
+	size   []int
+	count  int
+}
+
+func New(n int) *DSU {
+	d := &DSU{parent: make([]int, n), rank: make([]int, n), size: make([]int, n), count: n}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+// Find 返回 x 所在集合的代表元，顺路把经过的节点都直接挂到根上
+func (d *DSU) Find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+// Union 合并两个集合，本来就在一个集合里时返回 false
+func (d *DSU) Union(x, y int) bool {
+	x, y = d.Find(x), d.Find(y)
+	if x == y {
+		return false
+	}
+	if d.rank[x] < d.rank[y] {
+		x, y = y, x
+	}
+	d.parent[y] = x
+	d.size[x] += d.size[y]
+	if d.rank[x] == d.rank[y] {
+		d.rank[x]++
+	}
+	d.count--
+	return true
+}
+
+func (d *DSU) Connected(x, y int) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+// Size x 所在集合的元素个数
+func (d *DSU) Size(x int) int {
+	return d.size[d.Find(x)]
+}
+
+// Count 集合个数
+func (d *DSU) Count() int {
+	return d.count
+}
+
+func (d *DSU) Len() int {
+	return len(d.parent)
+}
+
+// Add 新增一个单独成集合的元素，返回它的编号
+func (d *DSU) Add() int {
+	x := len(d.parent)
+	d.parent = append(d.parent, x)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	return x
+}
+
+// Groups 按集合分组，组内和组间都按元素编号从小到大
+func (d *DSU) Groups() [][]int {
+	index := map[int]int{}
+	var ret [][]int
+	for x := range d.parent {
+		root := d.Find(x)
+		i, ok := index[root]
+		if !ok {
+			i = len(ret)
+			index[root] = i
+			ret = append(ret, nil)
+		}
+		ret[i] = append(ret[i], x)
+	}
+	return ret
+}
@@ -0,0 +1,137 @@
+package dsu
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// naive 用染色数组对拍：合并时把一种颜色全改成另一种
+type naive []int
+
+func (c naive) union(x, y int) {
+	from, to := c[y], c[x]
+	for i := range c {
+		if c[i] == from {
+			c[i] = to
+		}
+	}
+}
+
+func (c naive) count() int {
+	seen := map[int]bool{}
+	for _, v := range c {
+		seen[v] = true
+	}
+	return len(seen)
+}
+
+func (c naive) size(x int) int {
+	n := 0
+	for _, v := range c {
+		if v == c[x] {
+			n++
+		}
+	}
+	return n
+}
+
+func newNaive(n int) naive {
+	c := make(naive, n)
+	for i := range c {
+		c[i] = i
+	}
+	return c
+}
+
+func TestDSU(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const n = 50
+	d, want := New(n), newNaive(n)
+	for i := 0; i < 2000; i++ {
+		x, y := r.Intn(n), r.Intn(n)
+		if d.Union(x, y) != (want[x] != want[y]) {
+			t.Fatalf("Union(%d, %d)", x, y)
+		}
+		want.union(x, y)
+		a, b := r.Intn(n), r.Intn(n)
+		if d.Connected(a, b) != (want[a] == want[b]) || d.Size(a) != want.size(a) || d.Count() != want.count() {
+			t.Fatalf("第 %d 步不一致", i)
+		}
+	}
+}
+
+func TestGroups(t *testing.T) {
+	d := New(6)
+	d.Union(4, 1)
+	d.Union(5, 0)
+	d.Union(1, 3)
+	if got := d.Groups(); !reflect.DeepEqual(got, [][]int{{0, 5}, {1, 3, 4}, {2}}) {
+		t.Fatal(got)
+	}
+	x := d.Add()
+	d.Union(x, 2)
+	if d.Count() != 3 || d.Size(2) != 2 {
+		t.Fatal(d.Count(), d.Size(2))
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	k := NewKeyed[string]()
+	k.Union("a", "b")
+	k.Union("c", "d")
+	k.Add("e")
+	if !k.Connected("a", "b") || k.Connected("a", "c") || k.Count() != 3 || k.Len() != 5 {
+		t.Fatal("字符串 key")
+	}
+	if k.Connected("x", "y") || !k.Connected("x", "x") || k.Has("x") || k.Size("x") != 0 {
+		t.Fatal("没出现过的 key 不应该被加进来")
+	}
+	k.Union("b", "d")
+	if k.Size("a") != 4 || k.Find("c") != k.Find("a") {
+		t.Fatal(k.Size("a"))
+	}
+	if got := k.Groups(); !reflect.DeepEqual(got, [][]string{{"a", "b", "c", "d"}, {"e"}}) {
+		t.Fatal(got)
+	}
+
+	grid := NewKeyed[[2]int]()
+	grid.Union([2]int{0, 0}, [2]int{0, 1})
+	if !grid.Connected([2]int{0, 1}, [2]int{0, 0}) {
+		t.Fatal("坐标 key")
+	}
+}
+
+// 随机 Union，随机回到之前的快照，每一步和从头重放历史的结果比较
+func TestRollback(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	const n = 30
+	d := NewRollback(n)
+	var ops [][2]int       // 当前状态对应的 Union 序列
+	var snapshots [][2]int // {Snapshot(), len(ops)}
+	for i := 0; i < 3000; i++ {
+		switch r.Intn(4) {
+		case 0:
+			snapshots = append(snapshots, [2]int{d.Snapshot(), len(ops)})
+		case 1:
+			if len(snapshots) > 0 {
+				j := r.Intn(len(snapshots))
+				d.Rollback(snapshots[j][0])
+				ops = ops[:snapshots[j][1]]
+				snapshots = snapshots[:j]
+			}
+		default:
+			x, y := r.Intn(n), r.Intn(n)
+			d.Union(x, y)
+			ops = append(ops, [2]int{x, y})
+		}
+		want := newNaive(n)
+		for _, op := range ops {
+			want.union(op[0], op[1])
+		}
+		a, b := r.Intn(n), r.Intn(n)
+		if d.Connected(a, b) != (want[a] == want[b]) || d.Size(a) != want.size(a) || d.Count() != want.count() {
+			t.Fatalf("第 %d 步不一致", i)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package dsu
+
+// Keyed 元素是任意 comparable 的并查集，比如字符串、坐标
+// 第一次出现的 key 自动加进来，单独成一个集合
+
+type Keyed[K comparable] struct {
+	d    *DSU
+	ids  map[K]int
+	keys []K
+}
+
+func NewKeyed[K comparable]() *Keyed[K] {
+	return &Keyed[K]{d: New(0), ids: map[K]int{}}
+}
+
+// Add 加入 key，已经有了就什么都不做
+func (k *Keyed[K]) Add(key K) {
+	k.id(key)
+}
+
+func (k *Keyed[K]) id(key K) int {
+	if id, ok := k.ids[key]; ok {
+		return id
+	}
+	id := k.d.Add()
+	k.ids[key] = id
+	k.keys = append(k.keys, key)
+	return id
+}
+
+func (k *Keyed[K]) Has(key K) bool {
+	_, ok := k.ids[key]
+	return ok
+}
+
+// Find 返回 key 所在集合的代表元
+func (k *Keyed[K]) Find(key K) K {
+	return k.keys[k.d.Find(k.id(key))]
+}
+
+func (k *Keyed[K]) Union(a, b K) bool {
+	return k.d.Union(k.id(a), k.id(b))
+}
+
+// Connected 没出现过的 key 只和自己连通
+func (k *Keyed[K]) Connected(a, b K) bool {
+	x, ok1 := k.ids[a]
+	y, ok2 := k.ids[b]
+	if !ok1 || !ok2 {
+		return a == b
+	}
+	return k.d.Connected(x, y)
+}
+
+func (k *Keyed[K]) Size(key K) int {
+	id, ok := k.ids[key]
+	if !ok {
+		return 0
+	}
+	return k.d.Size(id)
+}
+
+func (k *Keyed[K]) Count() int {
+	return k.d.Count()
+}
+
+func (k *Keyed[K]) Len() int {
+	return k.d.Len()
+}
+
+// Groups 按集合分组，组内和组间都按加入的顺序
+func (k *Keyed[K]) Groups() [][]K {
+	var ret [][]K
+	for _, g := range k.d.Groups() {
+		keys := make([]K, len(g))
+		for i, id := range g {
+			keys[i] = k.keys[id]
+		}
+		ret = append(ret, keys)
+	}
+	return ret
+}
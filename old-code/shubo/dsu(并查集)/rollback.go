@@ -0,0 +1,72 @@
+package dsu
+
+// Rollback 可以撤销的并查集，用在离线分治、回溯搜索里
+// 路径压缩会改很多节点，没法便宜地撤销，所以只按大小合并，Find 是 O(log n)。
+// 每次成功的 Union 记一条历史，Rollback 按相反顺序撤销到某个快照。
+
+type Rollback struct {
+	parent  []int
+	size    []int
+	count   int
+	history []int // 被挂到别的根下面的那个根
+}
+
+func NewRollback(n int) *Rollback {
+	d := &Rollback{parent: make([]int, n), size: make([]int, n), count: n}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
+
+func (d *Rollback) Find(x int) int {
+	for d.parent[x] != x {
+		x = d.parent[x]
+	}
+	return x
+}
+
+func (d *Rollback) Union(x, y int) bool {
+	x, y = d.Find(x), d.Find(y)
+	if x == y {
+		return false
+	}
+	if d.size[x] < d.size[y] {
+		x, y = y, x
+	}
+	d.parent[y] = x
+	d.size[x] += d.size[y]
+	d.count--
+	d.history = append(d.history, y)
+	return true
+}
+
+func (d *Rollback) Connected(x, y int) bool {
+	return d.Find(x) == d.Find(y)
+}
+
+func (d *Rollback) Size(x int) int {
+	return d.size[d.Find(x)]
+}
+
+func (d *Rollback) Count() int {
+	return d.count
+}
+
+// Snapshot 当前状态的版本号，传给 Rollback 回到这个状态
+func (d *Rollback) Snapshot() int {
+	return len(d.history)
+}
+
+// Rollback 撤销 snapshot 之后的所有 Union
+func (d *Rollback) Rollback(snapshot int) {
+	for len(d.history) > snapshot {
+		y := d.history[len(d.history)-1]
+		d.history = d.history[:len(d.history)-1]
+		x := d.parent[y]
+		d.size[x] -= d.size[y]
+		d.parent[y] = y
+		d.count++
+	}
+}
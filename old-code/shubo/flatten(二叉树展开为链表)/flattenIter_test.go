@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -o traverse_test.go -- ../tree(二叉树遍历)/traverse.go
+
+// 先序遍历时把上一个节点的 Right 指向当前节点
+// PreOrderIter 在 yield 之前已经把孩子入栈，改 Left/Right 不影响后面的遍历
+func flattenIter(root *TreeNode) {
+	var prev *TreeNode
+	for n := range PreOrderIter(root) {
+		if prev != nil {
+			prev.Left, prev.Right = nil, n
+		}
+		prev = n
+	}
+	if prev != nil {
+		prev.Left, prev.Right = nil, nil
+	}
+}
+
+// randomTree n 个节点的随机形状，值在 [0, 10)
+func randomTree(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	k := r.Intn(n)
+	return &TreeNode{Val: r.Intn(10), Left: randomTree(r, k), Right: randomTree(r, n-1-k)}
+}
+
+// 展开后的链表，左孩子不是 nil 时返回 nil
+func rightList(root *TreeNode) []int {
+	ret := []int{}
+	for n := root; n != nil; n = n.Right {
+		if n.Left != nil {
+			return nil
+		}
+		ret = append(ret, n.Val)
+	}
+	return ret
+}
+
+func TestFlattenIter(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		a := randomTree(rand.New(rand.NewSource(seed)), int(seed%20))
+		b := randomTree(rand.New(rand.NewSource(seed)), int(seed%20))
+		flattenIter(a)
+		flatten(b)
+		if x, y := rightList(a), rightList(b); x == nil || !slices.Equal(x, y) {
+			t.Fatalf("seed=%d: %v != %v", seed, x, y)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package graph
+
+import "iter"
+
+// 邻接表表示的图，节点是 0 ~ n-1，边可以带权重
+// 无向图的每条边在两端各存一份。
+
+type Edge struct {
+	To     int
+	Weight float64
+}
+
+type Graph struct {
+	adj      [][]Edge
+	directed bool
+	edges    int
+}
+
+func NewDirected(n int) *Graph {
+	return &Graph{adj: make([][]Edge, n), directed: true}
+}
+
+func NewUndirected(n int) *Graph {
+	return &Graph{adj: make([][]Edge, n)}
+}
+
+// FromEdges 用 [[u, v], ...] 或 [[u, v, w], ...] 建图，没有第三个数时权重是 1
+func FromEdges(n int, edges [][]int, directed bool) *Graph {
+	g := &Graph{adj: make([][]Edge, n), directed: directed}
+	for _, e := range edges {
+		w := 1.0
+		if len(e) > 2 {
+			w = float64(e[2])
+		}
+		g.AddWeighted(e[0], e[1], w)
+	}
+	return g
+}
+
+// FromPrerequisites 课程表的 prerequisites，[a, b] 表示先学 b 才能学 a，建成 b -> a 的边
+func FromPrerequisites(numCourses int, prerequisites [][]int) *Graph {
+	g := NewDirected(numCourses)
+	for _, p := range prerequisites {
+		g.AddEdge(p[1], p[0])
+	}
+	return g
+}
+
+func (g *Graph) AddEdge(u, v int) {
+	g.AddWeighted(u, v, 1)
+}
+
+func (g *Graph) AddWeighted(u, v int, w float64) {
+	g.adj[u] = append(g.adj[u], Edge{To: v, Weight: w})
+	if !g.directed && u != v {
+		g.adj[v] = append(g.adj[v], Edge{To: u, Weight: w})
+	}
+	g.edges++
+}
+
+// AddNode 新增一个节点，返回编号
+func (g *Graph) AddNode() int {
+	g.adj = append(g.adj, nil)
+	return len(g.adj) - 1
+}
+
+func (g *Graph) Len() int {
+	return len(g.adj)
+}
+
+// Edges 边数，无向边算一条
+func (g *Graph) Edges() int {
+	return g.edges
+}
+
+func (g *Graph) Directed() bool {
+	return g.directed
+}
+
+// Neighbors u 的出边，按加边的顺序，不要修改
+func (g *Graph) Neighbors(u int) []Edge {
+	return g.adj[u]
+}
+
+// InDegrees 每个节点的入度
+func (g *Graph) InDegrees() []int {
+	deg := make([]int, len(g.adj))
+	for _, es := range g.adj {
+		for _, e := range es {
+			deg[e.To]++
+		}
+	}
+	return deg
+}
+
+// Reverse 所有边反向的图，无向图返回自己
+func (g *Graph) Reverse() *Graph {
+	if !g.directed {
+		return g
+	}
+	r := NewDirected(len(g.adj))
+	for u, es := range g.adj {
+		for _, e := range es {
+			r.AddWeighted(e.To, u, e.Weight)
+		}
+	}
+	return r
+}
+
+// BFS 从 src 开始广度优先遍历，依次产出节点和它到 src 的边数
+func (g *Graph) BFS(src int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		dist := make([]int, len(g.adj))
+		for i := range dist {
+			dist[i] = -1
+		}
+		dist[src] = 0
+		queue := []int{src}
+		for head := 0; head < len(queue); head++ {
+			u := queue[head]
+			if !yield(u, dist[u]) {
+				return
+			}
+			for _, e := range g.adj[u] {
+				if dist[e.To] < 0 {
+					dist[e.To] = dist[u] + 1
+					queue = append(queue, e.To)
+				}
+			}
+		}
+	}
+}
+
+// DFS 从 src 开始深度优先遍历，按先序产出节点，顺序和递归写法一样
+func (g *Graph) DFS(src int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		visited := make([]bool, len(g.adj))
+		type frame struct{ u, next int }
+		visited[src] = true
+		if !yield(src) {
+			return
+		}
+		stack := []frame{{src, 0}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(g.adj[top.u]) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			v := g.adj[top.u][top.next].To
+			top.next++
+			if visited[v] {
+				continue
+			}
+			visited[v] = true
+			if !yield(v) {
+				return
+			}
+			stack = append(stack, frame{v, 0})
+		}
+	}
+}
+
+// Names 字符串和节点编号互转，399 这种节点是变量名的题用
+type Names struct {
+	ids   map[string]int
+	names []string
+}
+
+func NewNames() *Names {
+	return &Names{ids: map[string]int{}}
+}
+
+// ID 返回 name 的编号，第一次出现时分配新编号
+func (n *Names) ID(name string) int {
+	if id, ok := n.ids[name]; ok {
+		return id
+	}
+	n.ids[name] = len(n.names)
+	n.names = append(n.names, name)
+	return len(n.names) - 1
+}
+
+// Lookup 只查不分配
+func (n *Names) Lookup(name string) (int, bool) {
+	id, ok := n.ids[name]
+	return id, ok
+}
+
+func (n *Names) Name(id int) string {
+	return n.names[id]
+}
+
+func (n *Names) Len() int {
+	return len(n.names)
+}
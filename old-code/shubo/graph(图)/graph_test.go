@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	//   0 -> 1 -> 3
+	//   |         ^
+	//   v         |
+	//   2 --------+
+	g := FromEdges(5, [][]int{{0, 1}, {0, 2}, {1, 3}, {2, 3}}, true)
+	var bfs [][2]int
+	for u, d := range g.BFS(0) {
+		bfs = append(bfs, [2]int{u, d})
+	}
+	if !reflect.DeepEqual(bfs, [][2]int{{0, 0}, {1, 1}, {2, 1}, {3, 2}}) {
+		t.Fatal(bfs)
+	}
+	if got := slices.Collect(g.DFS(0)); !reflect.DeepEqual(got, []int{0, 1, 3, 2}) {
+		t.Fatal(got)
+	}
+	// 提前退出
+	var first []int
+	for u := range g.DFS(0) {
+		first = append(first, u)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(first, []int{0, 1}) {
+		t.Fatal(first)
+	}
+	u := NewUndirected(3)
+	u.AddEdge(2, 1)
+	if got := slices.Collect(u.DFS(1)); !reflect.DeepEqual(got, []int{1, 2}) || u.Edges() != 1 {
+		t.Fatal(got)
+	}
+}
+
+// checkOrder 检查 order 是 g 的一个拓扑序
+func checkOrder(g *Graph, order []int) bool {
+	if len(order) != g.Len() {
+		return false
+	}
+	pos := make([]int, g.Len())
+	for i, u := range order {
+		pos[u] = i
+	}
+	for u := 0; u < g.Len(); u++ {
+		for _, e := range g.Neighbors(u) {
+			if pos[u] >= pos[e.To] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkCycle 检查环上每条边都存在
+func checkCycle(g *Graph, cycle []int) bool {
+	if len(cycle) == 0 {
+		return false
+	}
+	for i, u := range cycle {
+		v := cycle[(i+1)%len(cycle)]
+		found := false
+		for _, e := range g.Neighbors(u) {
+			if e.To == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTopo(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		n := 1 + r.Intn(12)
+		perm := r.Perm(n)
+		g := NewDirected(n)
+		// 按 perm 的顺序只加前向边，一定无环；一半的图再随机加一条边，可能成环
+		for k := 0; k < r.Intn(2*n); k++ {
+			a, b := r.Intn(n), r.Intn(n)
+			if a > b {
+				a, b = b, a
+			}
+			if a != b {
+				g.AddEdge(perm[a], perm[b])
+			}
+		}
+		if i%2 == 0 {
+			g.AddEdge(r.Intn(n), r.Intn(n))
+		}
+		k, errK := g.TopoKahn()
+		d, errD := g.TopoDFS()
+		if (errK == nil) != (errD == nil) {
+			t.Fatalf("两种拓扑排序结论不同: %v %v", errK, errD)
+		}
+		if errK == nil {
+			if !checkOrder(g, k) || !checkOrder(g, d) {
+				t.Fatalf("不是拓扑序: %v %v", k, d)
+			}
+			continue
+		}
+		for _, err := range []error{errK, errD} {
+			var ce *CycleError
+			if !errors.As(err, &ce) || !checkCycle(g, ce.Cycle) {
+				t.Fatalf("环不对: %v", err)
+			}
+		}
+	}
+	if _, err := NewUndirected(2).TopoKahn(); err != ErrUndirected {
+		t.Fatal(err)
+	}
+}
+
+func TestCycleError(t *testing.T) {
+	g := FromEdges(3, [][]int{{0, 1}, {1, 2}, {2, 1}}, true)
+	_, err := g.TopoDFS()
+	if err == nil || err.Error() != "graph: 有环 1 -> 2 -> 1" {
+		t.Fatal(err)
+	}
+}
+
+// 10^5 个节点的链，递归 DFS 会很深，显式栈不受影响
+func TestLongChain(t *testing.T) {
+	const n = 100000
+	g := NewDirected(n)
+	for i := n - 1; i > 0; i-- {
+		g.AddEdge(i, i-1)
+	}
+	order, err := g.TopoDFS()
+	if err != nil || order[0] != n-1 || order[n-1] != 0 {
+		t.Fatal(err)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		n := 1 + r.Intn(10)
+		g := NewDirected(n)
+		if i%2 == 1 {
+			g = NewUndirected(n)
+		}
+		// Floyd 对拍
+		floyd := make([][]float64, n)
+		for a := range floyd {
+			floyd[a] = make([]float64, n)
+			for b := range floyd[a] {
+				floyd[a][b] = math.Inf(1)
+			}
+			floyd[a][a] = 0
+		}
+		for k := 0; k < r.Intn(3*n); k++ {
+			a, b, w := r.Intn(n), r.Intn(n), float64(r.Intn(10))
+			g.AddWeighted(a, b, w)
+			floyd[a][b] = math.Min(floyd[a][b], w)
+			if !g.Directed() {
+				floyd[b][a] = math.Min(floyd[b][a], w)
+			}
+		}
+		for k := 0; k < n; k++ {
+			for a := 0; a < n; a++ {
+				for b := 0; b < n; b++ {
+					floyd[a][b] = math.Min(floyd[a][b], floyd[a][k]+floyd[k][b])
+				}
+			}
+		}
+		src := r.Intn(n)
+		dist, prev := g.Dijkstra(src)
+		for v := 0; v < n; v++ {
+			if dist[v] != floyd[src][v] {
+				t.Fatalf("dist[%d] = %v, 期望 %v", v, dist[v], floyd[src][v])
+			}
+			path := PathTo(prev, src, v)
+			if math.IsInf(dist[v], 1) != (path == nil) {
+				t.Fatalf("PathTo(%d) = %v", v, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// 用 Graph 写的 210、399，题解目录里都没有；207. 课程表的写法在 canFinish(课程表) 里，用生成的 graph.go、topo.go
+
+// 210. 课程表 II：有环时返回空数组
+func findOrder(numCourses int, prerequisites [][]int) []int {
+	order, err := FromPrerequisites(numCourses, prerequisites).TopoKahn()
+	if err != nil {
+		return []int{}
+	}
+	return order
+}
+
+// 399. 除法求值：a / b = k 建成 a -> b 权重 k、b -> a 权重 1/k 的图，
+// 从被除数出发遍历，沿路把权重乘起来，走到除数就是答案
+func calcEquation(equations [][]string, values []float64, queries [][]string) []float64 {
+	names := NewNames()
+	for _, e := range equations {
+		names.ID(e[0])
+		names.ID(e[1])
+	}
+	g := NewDirected(names.Len())
+	for i, e := range equations {
+		a, b := names.ID(e[0]), names.ID(e[1])
+		g.AddWeighted(a, b, values[i])
+		g.AddWeighted(b, a, 1/values[i])
+	}
+	ans := make([]float64, len(queries))
+	for i, q := range queries {
+		ans[i] = -1
+		a, ok1 := names.Lookup(q[0])
+		b, ok2 := names.Lookup(q[1])
+		if !ok1 || !ok2 {
+			continue
+		}
+		ratio := map[int]float64{a: 1}
+		queue := []int{a}
+		for head := 0; head < len(queue); head++ {
+			u := queue[head]
+			for _, e := range g.Neighbors(u) {
+				if _, ok := ratio[e.To]; !ok {
+					ratio[e.To] = ratio[u] * e.Weight
+					queue = append(queue, e.To)
+				}
+			}
+		}
+		if r, ok := ratio[b]; ok {
+			ans[i] = r
+		}
+	}
+	return ans
+}
+
+func TestCourseSchedule(t *testing.T) {
+	if got := findOrder(4, [][]int{{1, 0}, {2, 0}, {3, 1}, {3, 2}}); !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Fatal(got)
+	}
+	if got := findOrder(2, [][]int{{0, 1}, {1, 0}}); len(got) != 0 {
+		t.Fatal(got)
+	}
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		n := 1 + r.Intn(10)
+		var pre [][]int
+		for k := 0; k < r.Intn(2*n); k++ {
+			pre = append(pre, []int{r.Intn(n), r.Intn(n)})
+		}
+		order := findOrder(n, pre)
+		want := len(order) == n
+		// 能完成时每门课都排在它的先修课后面
+		pos := make([]int, n)
+		for i, v := range order {
+			pos[v] = i
+		}
+		for _, p := range pre {
+			if want && pos[p[1]] >= pos[p[0]] {
+				t.Fatalf("%d %v: %v", n, pre, order)
+			}
+		}
+		// 不能完成时，环上的每门课都是下一门课的先修课
+		_, err := FromPrerequisites(n, pre).TopoDFS()
+		var ce *CycleError
+		if want != (err == nil) || (err != nil && !errors.As(err, &ce)) {
+			t.Fatalf("%d %v: %v", n, pre, err)
+		}
+	}
+}
+
+func TestCalcEquation(t *testing.T) {
+	got := calcEquation(
+		[][]string{{"a", "b"}, {"b", "c"}},
+		[]float64{2.0, 3.0},
+		[][]string{{"a", "c"}, {"b", "a"}, {"a", "e"}, {"a", "a"}, {"x", "x"}},
+	)
+	want := []float64{6.0, 0.5, -1.0, 1.0, -1.0}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("%v", got)
+		}
+	}
+	got = calcEquation(
+		[][]string{{"a", "b"}, {"b", "c"}, {"bc", "cd"}},
+		[]float64{1.5, 2.5, 5.0},
+		[][]string{{"a", "c"}, {"c", "b"}, {"bc", "cd"}, {"cd", "bc"}},
+	)
+	want = []float64{3.75, 0.4, 5.0, 0.2}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("%v", got)
+		}
+	}
+}
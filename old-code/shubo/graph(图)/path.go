@@ -0,0 +1,68 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// 带权最短路，权重不能是负数
+
+// Dijkstra 返回 src 到每个节点的最短距离和最短路上的前驱，到不了的距离是 +Inf、前驱是 -1
+func (g *Graph) Dijkstra(src int) (dist []float64, prev []int) {
+	n := len(g.adj)
+	dist = make([]float64, n)
+	prev = make([]int, n)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	dist[src] = 0
+	h := &distHeap{{src, 0}}
+	for h.Len() > 0 {
+		cur := heap.Pop(h).(item)
+		if cur.dist > dist[cur.u] {
+			continue // 过期的记录
+		}
+		for _, e := range g.adj[cur.u] {
+			if d := cur.dist + e.Weight; d < dist[e.To] {
+				dist[e.To] = d
+				prev[e.To] = cur.u
+				heap.Push(h, item{e.To, d})
+			}
+		}
+	}
+	return dist, prev
+}
+
+// PathTo 根据前驱数组还原 src 到 v 的路径，到不了返回 nil
+func PathTo(prev []int, src, v int) []int {
+	var path []int
+	for ; v != -1; v = prev[v] {
+		path = append(path, v)
+		if v == src {
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+	}
+	return nil
+}
+
+type item struct {
+	u    int
+	dist float64
+}
+
+type distHeap []item
+
+func (h distHeap) Len() int            { return len(h) }
+func (h distHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h distHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x interface{}) { *h = append(*h, x.(item)) }
+func (h *distHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 拓扑排序，有环时返回 *CycleError，里面是一个真实存在的环
+
+var ErrUndirected = errors.New("graph: 无向图没有拓扑序")
+
+// CycleError Cycle 是环上的节点，Cycle[i] -> Cycle[i+1] 都是图里的边，最后一个节点连回第一个
+type CycleError struct {
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	s := make([]string, len(e.Cycle)+1)
+	for i, v := range e.Cycle {
+		s[i] = fmt.Sprint(v)
+	}
+	s[len(e.Cycle)] = fmt.Sprint(e.Cycle[0])
+	return "graph: 有环 " + strings.Join(s, " -> ")
+}
+
+// TopoKahn 每次取入度为 0 的节点，队列先进先出，一开始入度为 0 的按编号顺序入队
+// 取不完说明剩下的节点每个都有来自剩余节点的入边，沿着入边往回走一定会走回来，走出的就是环
+func (g *Graph) TopoKahn() ([]int, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	deg := g.InDegrees()
+	order := make([]int, 0, len(g.adj))
+	for u, d := range deg {
+		if d == 0 {
+			order = append(order, u)
+		}
+	}
+	for head := 0; head < len(order); head++ {
+		for _, e := range g.adj[order[head]] {
+			deg[e.To]--
+			if deg[e.To] == 0 {
+				order = append(order, e.To)
+			}
+		}
+	}
+	if len(order) == len(g.adj) {
+		return order, nil
+	}
+
+	// 剩下的节点 deg > 0，从任意一个开始沿着同样 deg > 0 的前驱往回走
+	rev := g.Reverse()
+	pos := make([]int, len(g.adj))
+	for i := range pos {
+		pos[i] = -1
+	}
+	var path []int
+	u := 0
+	for deg[u] == 0 {
+		u++
+	}
+	for pos[u] < 0 {
+		pos[u] = len(path)
+		path = append(path, u)
+		for _, e := range rev.adj[u] {
+			if deg[e.To] > 0 {
+				u = e.To
+				break
+			}
+		}
+	}
+	// path[pos[u]:] 是反向图上的环，倒过来就是原图上的环
+	cycle := path[pos[u]:]
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return nil, &CycleError{Cycle: cycle}
+}
+
+// TopoDFS 后序的逆序就是拓扑序；遇到还在栈上的节点说明找到了环，栈上从它到当前节点的一段就是环
+// 用显式栈实现，链很长的图也不会爆栈
+func (g *Graph) TopoDFS() ([]int, error) {
+	if !g.directed {
+		return nil, ErrUndirected
+	}
+	const (
+		white = iota // 没访问
+		gray         // 在栈上
+		black        // 已经完成
+	)
+	color := make([]int, len(g.adj))
+	post := make([]int, 0, len(g.adj))
+	type frame struct{ u, next int }
+	for s := range g.adj {
+		if color[s] != white {
+			continue
+		}
+		color[s] = gray
+		stack := []frame{{s, 0}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(g.adj[top.u]) {
+				color[top.u] = black
+				post = append(post, top.u)
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			v := g.adj[top.u][top.next].To
+			top.next++
+			switch color[v] {
+			case white:
+				color[v] = gray
+				stack = append(stack, frame{v, 0})
+			case gray:
+				i := len(stack) - 1
+				for stack[i].u != v {
+					i--
+				}
+				cycle := make([]int, 0, len(stack)-i)
+				for _, f := range stack[i:] {
+					cycle = append(cycle, f.u)
+				}
+				return nil, &CycleError{Cycle: cycle}
+			}
+		}
+	}
+	for i, j := 0, len(post)-1; i < j; i, j = i+1, j-1 {
+		post[i], post[j] = post[j], post[i]
+	}
+	return post, nil
+}
@@ -0,0 +1,21 @@
+package main
+
+// 埋点事件的双语文案，key 是 Event.Message，{name} 引用 Event.Args 里的参数
+// 命令行播放器直接用；storyboard(分镜生成) 用 copygen 拷一份，作为分镜的内置文案
+// 加了新的埋点在这里写文案，再到 storyboard 下 go generate
+
+type Caption struct {
+	Zh string `json:"zh"`
+	En string `json:"en"`
+}
+
+var captions = map[string]Caption{
+	"trap.init":      {Zh: "左指针 left={left} 指向开头，右指针 right={right} 指向结尾", En: "left={left} starts at the head, right={right} at the tail"},
+	"trap.moveLeft":  {Zh: "height[left]={h} 较矮，左边最高 {leftMax}，接水 {water}，left 右移", En: "height[left]={h} is lower, leftMax={leftMax}, collect {water}, move left"},
+	"trap.moveRight": {Zh: "height[right]={h} 较矮，右边最高 {rightMax}，接水 {water}，right 左移", En: "height[right]={h} is lower, rightMax={rightMax}, collect {water}, move right"},
+	"trap.result":    {Zh: "两指针相遇，一共接了 {result} 个单位的雨水", En: "pointers meet, {result} units of water trapped"},
+	"daily.init":     {Zh: "准备一个空的单调栈，栈里存下标，温度从栈底到栈顶递减", En: "start with an empty stack of indices, temperatures decreasing from bottom to top"},
+	"daily.push":     {Zh: "第 {i} 天 {t} 度入栈，等待更高的温度", En: "day {i} ({t}) is pushed, waiting for a warmer day"},
+	"daily.pop":      {Zh: "第 {i} 天 {ti} 度比栈顶第 {j} 天 {tj} 度高，第 {j} 天出栈，等了 {days} 天", En: "day {i} ({ti}) is warmer than day {j} ({tj}), pop day {j}: {days} days"},
+	"daily.result":   {Zh: "栈里剩下的日子之后都不会升温，答案是 {result}", En: "days left on the stack never get warmer, answer {result}"},
+}
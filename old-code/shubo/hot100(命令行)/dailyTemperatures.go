@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 739. 每日温度 单调栈解法，每次入栈、出栈埋一次点
+// 出栈是关键步骤：被挤出的那天找到了下一个更高的温度
+func traceDailyTemperatures(input string) (*Trace, error) {
+	var temperatures []int
+	if err := json.Unmarshal([]byte(input), &temperatures); err != nil {
+		return nil, fmt.Errorf("temperatures 应该是整数数组: %w", err)
+	}
+	r := NewRecorder("739", "单调栈", input)
+	ans := make([]int, len(temperatures))
+	var stack []int
+	state := func(i int) map[string]interface{} {
+		return map[string]interface{}{
+			"temperatures": temperatures,
+			"stack":        append([]int{}, stack...),
+			"answer":       append([]int{}, ans...),
+			"i":            i,
+		}
+	}
+
+	r.Emit(KindInit, "daily.init", false, map[string]interface{}{"n": len(temperatures)}, state(0), "temperatures", "stack")
+	s := NewDecreasing[int]()
+	s.Hooks.OnPop = func(popped, by Entry[int]) {
+		ans[popped.Index] = by.Index - popped.Index
+	}
+	s.Hooks.OnOp = func(op Op[int], entries []Entry[int]) {
+		stack = stack[:0]
+		for _, e := range entries {
+			stack = append(stack, e.Index)
+		}
+		switch op.Kind {
+		case "pop":
+			r.Emit(KindStep, "daily.pop", true, map[string]interface{}{
+				"j": op.Entry.Index, "tj": op.Entry.Value, "i": op.By.Index, "ti": op.By.Value, "days": ans[op.Entry.Index],
+			}, state(op.By.Index), "stack", "answer")
+		case "push":
+			r.Emit(KindStep, "daily.push", false, map[string]interface{}{
+				"i": op.Entry.Index, "t": op.Entry.Value,
+			}, state(op.Entry.Index), "stack", "i")
+		}
+	}
+	for _, t := range temperatures {
+		s.Push(t)
+	}
+	result, _ := json.Marshal(ans)
+	r.Emit(KindResult, "daily.result", false, map[string]interface{}{"result": string(result)}, state(len(temperatures)))
+	return &r.Trace, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../monotonic(单调栈)/stack.go
+
+// 埋了点的题解，按 questionFrontendId 注册，输入是 LeetCode 格式的字符串
+var tracers = map[string]func(input string) (*Trace, error){
+	"42":  traceTrap,
+	"739": traceDailyTemperatures,
+}
+
+// hot100 命令行
+// go build -o hot100 $(ls *.go | grep -v _test)
+//
+//	hot100 play 42 '[0,1,0,2,1,0,1,3,2,1,2,1]'
+//	hot100 play -all 42 '[4,2,0,3,2,5]'
+//	hot100 play -all 739 '[73,74,75,71,69,72,76,73]'
+//	hot100 play -trace ../storyboard(分镜生成)/testdata/trap.json
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "play":
+		play(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "用法: hot100 play [-all] [-lang zh|en] [-no-color] <题号> <输入>")
+	fmt.Fprintln(os.Stderr, "      hot100 play [-all] -trace <trace.json>")
+}
+
+func play(args []string) {
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	all := fs.Bool("all", false, "非交互模式，打印每一帧")
+	lang := fs.String("lang", "zh", "文案语言：zh 或 en")
+	noColor := fs.Bool("no-color", false, "不输出 ANSI 颜色")
+	tracePath := fs.String("trace", "", "直接回放 trace 文件")
+	fs.Parse(args)
+
+	var tr *Trace
+	var err error
+	if *tracePath != "" {
+		tr, err = LoadTrace(*tracePath)
+	} else {
+		if fs.NArg() != 2 {
+			usage()
+			os.Exit(2)
+		}
+		tracer, ok := tracers[fs.Arg(0)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "题目 %s 还没有埋点\n", fs.Arg(0))
+			os.Exit(1)
+		}
+		tr, err = tracer(fs.Arg(1))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	p := NewPlayer(tr)
+	p.Lang = *lang
+	p.Color = !*noColor
+	if *all {
+		p.PrintAll(os.Stdout)
+		return
+	}
+	p.Run(os.Stdin, os.Stdout)
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 终端回放 trace：ANSI 画数组、指针，支持 前进/后退/跳转
+
+const (
+	ansiReset = "\033[0m"
+	ansiBar   = "\033[47m" // 柱子：白底
+	ansiWater = "\033[44m" // 雨水：蓝底
+	ansiPtr   = "\033[33m" // 指针：黄色
+	ansiClear = "\033[H\033[2J"
+)
+
+// 每道题的画面，没注册的题目按 key=value 打印 state
+var renderers = map[string]func(e Event) string{
+	"42": renderTrap,
+}
+
+type Player struct {
+	Trace *Trace
+	Lang  string // zh 或 en
+	Color bool
+	cur   int
+}
+
+func NewPlayer(tr *Trace) *Player {
+	return &Player{Trace: tr, Lang: "zh", Color: true}
+}
+
+func (p *Player) Len() int { return len(p.Trace.Events) }
+
+func (p *Player) Cur() int { return p.cur }
+
+func (p *Player) Next() {
+	if p.cur < p.Len()-1 {
+		p.cur++
+	}
+}
+
+func (p *Player) Prev() {
+	if p.cur > 0 {
+		p.cur--
+	}
+}
+
+// Jump 跳到第 step 步，越界时停在首尾
+func (p *Player) Jump(step int) {
+	if step < 0 {
+		step = 0
+	}
+	if step > p.Len()-1 {
+		step = p.Len() - 1
+	}
+	p.cur = step
+}
+
+// Frame 渲染当前这一步
+func (p *Player) Frame() string {
+	if p.Len() == 0 {
+		return "trace 为空\n"
+	}
+	e := p.Trace.Events[p.cur]
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%d/%d] %s\n", p.cur, p.Len()-1, e.Kind)
+	render, ok := renderers[p.Trace.QuestionID]
+	if !ok {
+		render = renderState
+	}
+	frame := render(e)
+	if !p.Color {
+		frame = stripColor(frame)
+	}
+	b.WriteString(frame)
+	b.WriteString(p.caption(e))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (p *Player) caption(e Event) string {
+	c, ok := captions[e.Message]
+	s := e.Message
+	if ok {
+		s = c.Zh
+		if p.Lang == "en" {
+			s = c.En
+		}
+	}
+	for k, v := range e.Args {
+		s = strings.ReplaceAll(s, "{"+k+"}", fmt.Sprint(v))
+	}
+	return s
+}
+
+// PrintAll 非交互模式，按顺序打印每一帧
+func (p *Player) PrintAll(w io.Writer) {
+	for i := 0; i < p.Len(); i++ {
+		p.cur = i
+		fmt.Fprintln(w, p.Frame())
+	}
+}
+
+// Run 交互模式，一行一个命令：
+// 回车/n 下一步，p 上一步，g N 跳到第 N 步，q 退出
+func (p *Player) Run(in io.Reader, out io.Writer) {
+	sc := bufio.NewScanner(in)
+	for {
+		if p.Color {
+			fmt.Fprint(out, ansiClear)
+		}
+		fmt.Fprint(out, p.Frame())
+		fmt.Fprint(out, "[回车/n] 下一步 [p] 上一步 [g N] 跳转 [q] 退出 > ")
+		if !sc.Scan() {
+			return
+		}
+		cmd := strings.Fields(sc.Text())
+		if len(cmd) == 0 {
+			p.Next()
+			continue
+		}
+		switch cmd[0] {
+		case "n":
+			p.Next()
+		case "p", "b":
+			p.Prev()
+		case "g":
+			if len(cmd) > 1 {
+				if n, err := strconv.Atoi(cmd[1]); err == nil {
+					p.Jump(n)
+				}
+			}
+		case "q":
+			return
+		}
+	}
+}
+
+// 接雨水：柱子 + 雨水 + 左右指针
+func renderTrap(e Event) string {
+	height := toInts(e.State["height"])
+	filled := toInts(e.State["filled"])
+	left, right := toInt(e.State["left"]), toInt(e.State["right"])
+	top := 0
+	for i, h := range height {
+		if i < len(filled) {
+			h += filled[i]
+		}
+		if h > top {
+			top = h
+		}
+	}
+	var b strings.Builder
+	for row := top; row > 0; row-- {
+		for i, h := range height {
+			w := 0
+			if i < len(filled) {
+				w = filled[i]
+			}
+			switch {
+			case row <= h:
+				b.WriteString(ansiBar + "  " + ansiReset)
+			case row <= h+w:
+				b.WriteString(ansiWater + "  " + ansiReset)
+			default:
+				b.WriteString("  ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	for i := range height {
+		switch {
+		case i == left && i == right:
+			b.WriteString(ansiPtr + "LR" + ansiReset)
+		case i == left:
+			b.WriteString(ansiPtr + "L " + ansiReset)
+		case i == right:
+			b.WriteString(ansiPtr + "R " + ansiReset)
+		default:
+			b.WriteString("  ")
+		}
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "left=%d right=%d leftMax=%d rightMax=%d water=%d\n",
+		left, right, toInt(e.State["leftMax"]), toInt(e.State["rightMax"]), toInt(e.State["water"]))
+	return b.String()
+}
+
+func renderState(e Event) string {
+	keys := make([]string, 0, len(e.State))
+	for k := range e.State {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%v\n", k, e.State[k])
+	}
+	return b.String()
+}
+
+// state 可能来自内存（int）也可能来自 JSON 文件（float64）
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+func toInts(v interface{}) []int {
+	switch arr := v.(type) {
+	case []int:
+		return arr
+	case []interface{}:
+		ret := make([]int, len(arr))
+		for i, x := range arr {
+			ret[i] = toInt(x)
+		}
+		return ret
+	}
+	return nil
+}
+
+func stripColor(s string) string {
+	for _, c := range []string{ansiReset, ansiPtr} {
+		s = strings.ReplaceAll(s, c, "")
+	}
+	// 没有颜色时用字符区分柱子和雨水
+	s = strings.ReplaceAll(s, ansiBar+"  ", "##")
+	s = strings.ReplaceAll(s, ansiWater+"  ", "~~")
+	return s
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTraceTrap(t *testing.T) {
+	tr, err := traceTrap("[0,1,0,2,1,0,1,3,2,1,2,1]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := tr.Events[len(tr.Events)-1]
+	if last.Kind != KindResult || last.Args["result"] != 6 {
+		t.Fatalf("%+v", last)
+	}
+	if _, err := traceTrap("[1,2"); err == nil {
+		t.Fatal("非法输入应该报错")
+	}
+}
+
+func TestPlayerControls(t *testing.T) {
+	tr, _ := traceTrap("[4,2,0,3,2,5]")
+	p := NewPlayer(tr)
+	p.Prev()
+	if p.Cur() != 0 {
+		t.Fatal(p.Cur())
+	}
+	p.Next()
+	p.Next()
+	p.Prev()
+	if p.Cur() != 1 {
+		t.Fatal(p.Cur())
+	}
+	p.Jump(100)
+	if p.Cur() != p.Len()-1 {
+		t.Fatal(p.Cur())
+	}
+	p.Jump(-1)
+	if p.Cur() != 0 {
+		t.Fatal(p.Cur())
+	}
+}
+
+func TestFrame(t *testing.T) {
+	tr, _ := traceTrap("[4,2,0,3,2,5]")
+	p := NewPlayer(tr)
+	p.Color = false
+	p.Jump(p.Len() - 1)
+	want := "" +
+		"[6/6] result\n" +
+		"          ##\n" +
+		"##~~~~~~~~##\n" +
+		"##~~~~##~~##\n" +
+		"####~~######\n" +
+		"####~~######\n" +
+		"          LR\n" +
+		"left=5 right=5 leftMax=4 rightMax=0 water=9\n" +
+		"两指针相遇，一共接了 9 个单位的雨水\n"
+	if got := p.Frame(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	p.Lang = "en"
+	if !strings.HasSuffix(p.Frame(), "pointers meet, 9 units of water trapped\n") {
+		t.Fatal(p.Frame())
+	}
+}
+
+// 从 JSON 文件读出来的 trace，数字都是 float64，画面要和内存里的一致
+func TestFrameFromJSON(t *testing.T) {
+	tr, _ := traceTrap("[0,1,0,2,1,0,1,3,2,1,2,1]")
+	b, _ := json.Marshal(tr)
+	var decoded Trace
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	p1, p2 := NewPlayer(tr), NewPlayer(&decoded)
+	for i := 0; i < p1.Len(); i++ {
+		p1.Jump(i)
+		p2.Jump(i)
+		if p1.Frame() != p2.Frame() {
+			t.Fatalf("第 %d 帧不一致\n%s\n%s", i, p1.Frame(), p2.Frame())
+		}
+	}
+}
+
+// storyboard 的 testdata 也是 traceTrap 生成的，命令行用 -trace 播放时画面要和现场跑的一样，积水靠 State["filled"]
+func TestStoryboardTestdata(t *testing.T) {
+	tr, err := LoadTrace("../storyboard(分镜生成)/testdata/trap.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := traceTrap(tr.Input)
+	p1, p2 := NewPlayer(want), NewPlayer(tr)
+	if p1.Len() != p2.Len() {
+		t.Fatal(p1.Len(), p2.Len())
+	}
+	for i := 0; i < p1.Len(); i++ {
+		if _, ok := tr.Events[i].State["filled"]; !ok {
+			t.Fatalf("第 %d 步没有 filled", i)
+		}
+		p1.Jump(i)
+		p2.Jump(i)
+		if p1.Frame() != p2.Frame() {
+			t.Fatalf("第 %d 帧不一致\n%s\n%s", i, p1.Frame(), p2.Frame())
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	tr, _ := traceTrap("[4,2,0,3,2,5]")
+	p := NewPlayer(tr)
+	p.Color = false
+	var out strings.Builder
+	p.Run(strings.NewReader("\nn\ng 5\np\nq\n"), &out)
+	if p.Cur() != 4 {
+		t.Fatal(p.Cur())
+	}
+}
+
+func TestTraceDailyTemperatures(t *testing.T) {
+	tr, err := traceDailyTemperatures("[73,74,75,71,69,72,76,73]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pops := 0
+	for _, e := range tr.Events {
+		if e.Message == "daily.pop" {
+			pops++
+		}
+	}
+	// 除了最后留在栈里的 76、73，每天都出栈一次
+	if pops != 6 {
+		t.Fatal(pops)
+	}
+	last := tr.Events[len(tr.Events)-1]
+	if last.Args["result"] != "[1,1,4,2,1,1,0,0]" || !reflect.DeepEqual(last.State["stack"], []int{6, 7}) {
+		t.Fatalf("%+v", last)
+	}
+	p := NewPlayer(tr)
+	p.Color = false
+	p.Jump(2)
+	if !strings.Contains(p.Frame(), "第 1 天 74 度比栈顶第 0 天 73 度高，第 0 天出栈，等了 1 天") {
+		t.Fatal(p.Frame())
+	}
+}
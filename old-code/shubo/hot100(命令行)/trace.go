@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// 题解执行轨迹（trace）
+// 题解在关键位置埋点，每个埋点产生一个 Event，整个执行过程就是一个 Trace。
+// 网页动画和分镜脚本都从同一份 trace 出发，每道题只需要埋一次点。
+// judge、wasm、storyboard 里的 trace.go 是从这里 go generate 拷贝的，改完要重新生成。
+
+// 事件类型
+const (
+	KindInit   = "init"   // 初始化：建数组、放指针、建栈等
+	KindStep   = "step"   // 一次迭代/递归
+	KindResult = "result" // 返回结果
+)
+
+type Trace struct {
+	QuestionID string  `json:"questionId"` // 对应 leetcode-hot-100.json 里的 questionFrontendId
+	Variant    string  `json:"variant"`    // 解法名称，如 双指针、dp、单调栈
+	Input      string  `json:"input"`      // LeetCode 格式的输入
+	Events     []Event `json:"events"`
+}
+
+type Event struct {
+	Step    int                    `json:"step"`
+	Kind    string                 `json:"kind"`
+	Message string                 `json:"message"`           // 文案模板的 key，如 trap.moveLeft
+	Key     bool                   `json:"key,omitempty"`     // 关键步骤，单独成镜
+	Args    map[string]interface{} `json:"args,omitempty"`    // 填充文案模板的参数
+	State   map[string]interface{} `json:"state,omitempty"`   // 当前数据快照：数组、指针、栈等
+	Changed []string               `json:"changed,omitempty"` // 本步发生变化的 state key，作为视觉焦点
+}
+
+func LoadTrace(path string) (*Trace, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t Trace
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Recorder 题解里用来埋点
+type Recorder struct {
+	Trace
+}
+
+func NewRecorder(questionID, variant, input string) *Recorder {
+	return &Recorder{Trace{QuestionID: questionID, Variant: variant, Input: input}}
+}
+
+func (r *Recorder) Emit(kind, message string, key bool, args, state map[string]interface{}, changed ...string) {
+	r.Events = append(r.Events, Event{
+		Step:    len(r.Events),
+		Kind:    kind,
+		Message: message,
+		Key:     key,
+		Args:    args,
+		State:   state,
+		Changed: changed,
+	})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 42. 接雨水 双指针解法，每移动一次指针埋一次点
+// 谁矮移动谁：矮的一边的积水只取决于这一边的最大高度
+// judge、wasm、storyboard 里的 trap.go 是从这里 go generate 拷贝的
+func traceTrap(input string) (*Trace, error) {
+	var height []int
+	if err := json.Unmarshal([]byte(input), &height); err != nil {
+		return nil, fmt.Errorf("height 应该是整数数组: %w", err)
+	}
+	r := NewRecorder("42", "双指针", input)
+	left, right := 0, len(height)-1
+	leftMax, rightMax, ans := 0, 0, 0
+	filled := make([]int, len(height)) // 每一列接到的水
+	state := func() map[string]interface{} {
+		return map[string]interface{}{
+			"height":   height,
+			"filled":   append([]int(nil), filled...),
+			"left":     left,
+			"right":    right,
+			"leftMax":  leftMax,
+			"rightMax": rightMax,
+			"water":    ans,
+		}
+	}
+
+	r.Emit(KindInit, "trap.init", false, map[string]interface{}{"left": left, "right": right}, state(), "height", "left", "right")
+	for left < right {
+		if height[left] < height[right] {
+			leftMax = max(leftMax, height[left])
+			w := leftMax - height[left]
+			filled[left] = w
+			ans += w
+			h := height[left]
+			left++
+			r.Emit(KindStep, "trap.moveLeft", w > 0, map[string]interface{}{"h": h, "leftMax": leftMax, "water": w}, state(), "left", "leftMax", "water")
+		} else {
+			rightMax = max(rightMax, height[right])
+			w := rightMax - height[right]
+			filled[right] = w
+			ans += w
+			h := height[right]
+			right--
+			r.Emit(KindStep, "trap.moveRight", w > 0, map[string]interface{}{"h": h, "rightMax": rightMax, "water": w}, state(), "right", "rightMax", "water")
+		}
+	}
+	r.Emit(KindResult, "trap.result", false, map[string]interface{}{"result": ans}, state())
+	return &r.Trace, nil
+}
+
+func max(a, b int) int {
+	if a < b {
+		return b
+	}
+	return a
+}
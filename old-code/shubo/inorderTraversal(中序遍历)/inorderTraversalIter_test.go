@@ -0,0 +1,37 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -o traverse_test.go -- ../tree(二叉树遍历)/traverse.go
+
+// Morris 遍历不用栈也不递归，额外空间 O(1)
+func inorderTraversalIter(root *TreeNode) []int {
+	ret := []int{}
+	for n := range Morris(root) {
+		ret = append(ret, n.Val)
+	}
+	return ret
+}
+
+// randomTree n 个节点的随机形状，值在 [0, 10)
+func randomTree(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	k := r.Intn(n)
+	return &TreeNode{Val: r.Intn(10), Left: randomTree(r, k), Right: randomTree(r, n-1-k)}
+}
+
+func TestInorderTraversalIter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		root := randomTree(r, r.Intn(20))
+		if a, b := inorderTraversalIter(root), inorderTraversal(root); !slices.Equal(a, b) {
+			t.Fatalf("%v != %v", a, b)
+		}
+	}
+}
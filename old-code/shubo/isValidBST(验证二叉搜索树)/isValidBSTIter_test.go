@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -o traverse_test.go -- ../tree(二叉树遍历)/traverse.go
+
+// 中序严格递增，遇到逆序就提前返回，Morris 会在退出前把树还原
+func isValidBSTIter(root *TreeNode) bool {
+	prev := math.MinInt64
+	for n := range Morris(root) {
+		if n.Val <= prev {
+			return false
+		}
+		prev = n.Val
+	}
+	return true
+}
+
+// randomBST n 个节点的随机形状，按中序递增赋值，相邻两个有一定概率相等，这时不是二叉搜索树
+func randomBST(r *rand.Rand, n int) *TreeNode {
+	v := 0
+	var build func(n int) *TreeNode
+	build = func(n int) *TreeNode {
+		if n == 0 {
+			return nil
+		}
+		k := r.Intn(n)
+		node := &TreeNode{Left: build(k)}
+		v += r.Intn(20)
+		node.Val = v
+		node.Right = build(n - 1 - k)
+		return node
+	}
+	return build(n)
+}
+
+func TestIsValidBSTIter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	valid := 0
+	for i := 0; i < 500; i++ {
+		root := randomBST(r, r.Intn(20))
+		want := isValidBST(root)
+		if isValidBSTIter(root) != want {
+			t.Fatalf("第 %d 棵结论不同", i)
+		}
+		// 提前返回后树要还原，原来的写法再跑一次结论不变
+		if isValidBST(root) != want {
+			t.Fatalf("第 %d 棵被改了", i)
+		}
+		if want {
+			valid++
+		}
+	}
+	if valid == 0 || valid == 500 {
+		t.Fatal("用例全是一种结论", valid)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+// 判题用例，输入和输出都是 LeetCode 格式
+
+type Case struct {
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+}
+
+var cases = map[string][]Case{
+	"1": {
+		{`nums = [2,7,11,15], target = 9`, `[0,1]`},
+		{`nums = [3,2,4], target = 6`, `[1,2]`},
+		{`nums = [3,3], target = 6`, `[0,1]`},
+	},
+	"20": {
+		{`s = "()"`, `true`},
+		{`s = "()[]{}"`, `true`},
+		{`s = "(]"`, `false`},
+		{`s = "([])"`, `true`},
+		{`s = "("`, `false`},
+		{`s = "]"`, `false`},
+	},
+	"42": {
+		{`height = [0,1,0,2,1,0,1,3,2,1,2,1]`, `6`},
+		{`height = [4,2,0,3,2,5]`, `9`},
+		{`height = [4,2,3]`, `1`},
+		{`height = [1]`, `0`},
+	},
+	"70": {
+		{`n = 1`, `1`},
+		{`n = 2`, `2`},
+		{`n = 3`, `3`},
+		{`n = 45`, `1836311903`},
+	},
+	"121": {
+		{`prices = [7,1,5,3,6,4]`, `5`},
+		{`prices = [7,6,4,3,1]`, `0`},
+	},
+	"128": {
+		{`nums = [100,4,200,1,3,2]`, `4`},
+		{`nums = [0,3,7,2,5,8,4,6,0,1]`, `9`},
+		{`nums = [1,0,1,2]`, `3`},
+		{`nums = []`, `0`},
+	},
+	"136": {
+		{`nums = [2,2,1]`, `1`},
+		{`nums = [4,1,2,1,2]`, `4`},
+		{`nums = [1]`, `1`},
+	},
+	"200": {
+		{`grid = [["1","1","1","1","0"],["1","1","0","1","0"],["1","1","0","0","0"],["0","0","0","0","0"]]`, `1`},
+		{`grid = [["1","1","0","0","0"],["1","1","0","0","0"],["0","0","1","0","0"],["0","0","0","1","1"]]`, `3`},
+		{`grid = [["1","0","1"],["0","1","0"],["1","0","1"]]`, `5`},
+	},
+	"739": {
+		{`temperatures = [73,74,75,71,69,72,76,73]`, `[1,1,4,2,1,1,0,0]`},
+		{`temperatures = [30,40,50,60]`, `[1,1,1,0]`},
+		{`temperatures = [30,60,90]`, `[1,1,0]`},
+	},
+}
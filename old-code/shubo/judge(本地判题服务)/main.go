@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../storyboard(分镜生成)/meta.go ../hot100(命令行)/trace.go ../hot100(命令行)/trap.go
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../dsu(并查集)/dsu.go
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../longestConsecutive(最长连续序列)/longestConsecutiveDsu.go ../../songzhibin97/岛屿数量/numIslandsDsu.go
+//go:generate go run ../copygen(拷贝生成)/main.go -o numIslands.go -- ../../songzhibin97/岛屿数量/main.go
+
+// 本地判题服务，只监听 localhost，开发时给 React 页面用
+// go run $(ls *.go | grep -v _test) -addr 127.0.0.1:8100
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8100", "监听地址")
+	metaPath := flag.String("meta", "../../../docs/leetcode-hot-100.json", "题目元数据，读不到时题目列表不带标签")
+	origin := flag.String("origin", "http://localhost:3000", "允许跨域访问的前端地址，为空则不允许")
+	timeout := flag.Duration("timeout", 5*time.Second, "每个用例的运行时间上限，超过算 TLE")
+	flag.Parse()
+
+	problems, err := LoadProblems(*metaPath)
+	if err != nil {
+		log.Printf("读取题目元数据失败: %v", err)
+	}
+	log.Printf("判题服务启动: http://%s/problems", *addr)
+	log.Fatal(http.ListenAndServe(*addr, NewServer(problems, *origin, *timeout)))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// 解析 LeetCode 格式的输入
+// 支持 `nums = [2,7,11,15], target = 9` 和省略参数名的 `[2,7,11,15], 9` 两种写法，
+// 参数名存在时按名字对应，否则按顺序对应。
+// readme、diff、wasm 里的 parse.go 是从这里 go generate 拷贝的
+
+func parseArgs(input string, params []string) ([]json.RawMessage, error) {
+	parts := splitTopLevel(input)
+	if len(parts) != len(params) {
+		return nil, fmt.Errorf("需要 %d 个参数 %v，实际是 %d 个", len(params), params, len(parts))
+	}
+	args := make([]json.RawMessage, len(params))
+	for i, part := range parts {
+		name, value := "", part
+		if eq := strings.Index(part, "="); eq > 0 && !strings.ContainsAny(part[:eq], "[\"{") {
+			name, value = strings.TrimSpace(part[:eq]), strings.TrimSpace(part[eq+1:])
+		}
+		idx := i
+		if name != "" {
+			idx = indexOf(params, name)
+			if idx < 0 {
+				return nil, fmt.Errorf("未知参数 %s，参数列表是 %v", name, params)
+			}
+		}
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("参数 %s 不是合法的 JSON: %s", params[idx], value)
+		}
+		args[idx] = json.RawMessage(value)
+	}
+	return args, nil
+}
+
+// splitTopLevel 按不在括号、引号里的逗号切分
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	inStr := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case inStr:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inStr = false
+			}
+		case c == '"':
+			inStr = true
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" || len(parts) > 0 {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+func indexOf(arr []string, s string) int {
+	for i, v := range arr {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func decode[T any](raw json.RawMessage) (T, error) {
+	var v T
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// 种子语料是所有判题用例的输入
+func addCaseInputs(f *testing.F) {
+	ids := make([]string, 0, len(cases))
+	for id := range cases {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		for _, c := range cases[id] {
+			f.Add(c.Input)
+		}
+	}
+}
+
+func sortedSolutions() []Solution {
+	ids := make([]string, 0, len(solutions))
+	for id := range solutions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	ret := make([]Solution, len(ids))
+	for i, id := range ids {
+		ret[i] = solutions[id]
+	}
+	return ret
+}
+
+// go test -fuzz FuzzParseArgs
+// 解析成功时：参数个数对、每个参数是合法的 JSON，按 name = value 拼回去再解析结果不变
+func FuzzParseArgs(f *testing.F) {
+	addCaseInputs(f)
+	f.Add(`[2,7,11,15], 9`)
+	f.Add(`target = 9, nums = [2,7,11,15]`)
+	f.Add(`s = "a,b=c]"`)
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, s := range sortedSolutions() {
+			args, err := parseArgs(input, s.Params)
+			if err != nil {
+				continue
+			}
+			if len(args) != len(s.Params) {
+				t.Fatalf("%s %q: %d 个参数", s.Name, input, len(args))
+			}
+			parts := make([]string, len(args))
+			for i, a := range args {
+				if !json.Valid(a) {
+					t.Fatalf("%s %q: 参数 %s 不是 JSON: %s", s.Name, input, s.Params[i], a)
+				}
+				parts[i] = s.Params[i] + " = " + string(a)
+			}
+			again, err := parseArgs(strings.Join(parts, ", "), s.Params)
+			if err != nil {
+				t.Fatalf("%s %q -> %q: %v", s.Name, input, strings.Join(parts, ", "), err)
+			}
+			for i := range args {
+				if string(again[i]) != string(args[i]) {
+					t.Fatalf("%s %q: 参数 %s %s -> %s", s.Name, input, s.Params[i], args[i], again[i])
+				}
+			}
+		}
+	})
+}
+
+// go test -fuzz FuzzRun
+// 参数适配（数组、字符矩阵、嵌套数组）要把不合法的输入挡在外面，不能让题解 panic
+// 这里直接调用 Variant.Run，绕过 Run 里的 recover
+func FuzzRun(f *testing.F) {
+	addCaseInputs(f)
+	f.Add(`grid = [["1","0"],["1"]]`)
+	f.Add(`grid = [["10"]]`)
+	f.Add(`nums = [1.5]`)
+	f.Fuzz(func(t *testing.T, input string) {
+		if len(input) > 200 {
+			return
+		}
+		for _, s := range sortedSolutions() {
+			args, err := parseArgs(input, s.Params)
+			if err != nil {
+				continue
+			}
+			for _, v := range s.Variants {
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							t.Fatalf("%s %s %q panic: %v", s.Name, v.Name, input, r)
+						}
+					}()
+					v.Run(args)
+				}()
+			}
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 判题服务能运行的题解，按 questionFrontendId 注册
+// 一道题可以有多种解法，Variants 的第一个是默认解法
+// 默认解法大多在 solutions.go 里，和 wasm 共用；200 的 DFS 和 128、200 的并查集解法是从题解目录生成的，见 main.go
+
+type Variant struct {
+	Name string
+	Run  RunFunc
+}
+
+type Solution struct {
+	Name      string
+	Params    []string
+	Variants  []Variant
+	Unordered bool // 答案可以按任意顺序返回，比较前先排序
+}
+
+func (s Solution) Variant(name string) (Variant, bool) {
+	if name == "" {
+		return s.Variants[0], true
+	}
+	for _, v := range s.Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+func (s Solution) VariantNames() []string {
+	names := make([]string, len(s.Variants))
+	for i, v := range s.Variants {
+		names[i] = v.Name
+	}
+	return names
+}
+
+var solutions = map[string]Solution{
+	"1": {Name: "twoSum", Params: []string{"nums", "target"}, Unordered: true, Variants: []Variant{
+		{"哈希表", ints2(twoSum)},
+		{"暴力", ints2(twoSumBaoli)},
+	}},
+	"20": {Name: "isValid", Params: []string{"s"}, Variants: []Variant{
+		{"栈", runIsValid},
+	}},
+	"42": {Name: "trap", Params: []string{"height"}, Variants: []Variant{
+		{"双指针", runTrap},
+		{"dp", ints(trapDp)},
+	}},
+	"70": {Name: "climbStairs", Params: []string{"n"}, Variants: []Variant{
+		{"dp", runClimbStairs},
+	}},
+	"121": {Name: "maxProfit", Params: []string{"prices"}, Variants: []Variant{
+		{"一次遍历", ints(maxProfit)},
+	}},
+	"128": {Name: "longestConsecutive", Params: []string{"nums"}, Variants: []Variant{
+		{"哈希表", ints(longestConsecutive)},
+		{"并查集", ints(longestConsecutiveDsu)},
+	}},
+	"136": {Name: "singleNumber", Params: []string{"nums"}, Variants: []Variant{
+		{"异或", ints(singleNumber)},
+	}},
+	"200": {Name: "numIslands", Params: []string{"grid"}, Variants: []Variant{
+		{"DFS", grid(numIslands)},
+		{"并查集", grid(numIslandsDsu)},
+	}},
+	"739": {Name: "dailyTemperatures", Params: []string{"temperatures"}, Variants: []Variant{
+		{"单调栈", ints(dailyTemperatures)},
+		{"暴力", ints(dailyTemperaturesBaoli)},
+	}},
+}
+
+// grid 适配参数是字符矩阵的题解，LeetCode 里写成 [["1","0"],["0","1"]]，每一行要一样长
+func grid[R any](f func([][]byte) R) RunFunc {
+	return func(args []json.RawMessage) (interface{}, *Trace, error) {
+		rows, err := decode[[][]string](args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		g := make([][]byte, len(rows))
+		for i, row := range rows {
+			if len(row) != len(rows[0]) {
+				return nil, nil, fmt.Errorf("grid 第 %d 行有 %d 列，第 0 行有 %d 列", i, len(row), len(rows[0]))
+			}
+			g[i] = make([]byte, len(row))
+			for j, c := range row {
+				if len(c) != 1 {
+					return nil, nil, fmt.Errorf("grid[%d][%d] 应该是单个字符: %q", i, j, c)
+				}
+				g[i][j] = c[0]
+			}
+		}
+		return f(g), nil, nil
+	}
+}
+
+func twoSumBaoli(nums []int, target int) []int {
+	for i := 0; i < len(nums); i++ {
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i]+nums[j] == target {
+				return []int{i, j}
+			}
+		}
+	}
+	return []int{}
+}
+
+// 每一列能接的水 = min(左边最高, 右边最高) - 自己的高度
+func trapDp(height []int) int {
+	n := len(height)
+	if n == 0 {
+		return 0
+	}
+	leftMax, rightMax := make([]int, n), make([]int, n)
+	leftMax[0], rightMax[n-1] = height[0], height[n-1]
+	for i := 1; i < n; i++ {
+		leftMax[i] = max(leftMax[i-1], height[i])
+	}
+	for i := n - 2; i >= 0; i-- {
+		rightMax[i] = max(rightMax[i+1], height[i])
+	}
+	ans := 0
+	for i := range height {
+		ans += min(leftMax[i], rightMax[i]) - height[i]
+	}
+	return ans
+}
+
+func dailyTemperaturesBaoli(temperatures []int) []int {
+	ans := make([]int, len(temperatures))
+	for i := 0; i < len(temperatures)-1; i++ {
+		for j := i + 1; j < len(temperatures); j++ {
+			if temperatures[i] < temperatures[j] {
+				ans[i] = j - i
+				break
+			}
+		}
+	}
+	return ans
+}
+
+func longestConsecutive(nums []int) int {
+	numSet := map[int]bool{}
+	for _, num := range nums {
+		numSet[num] = true
+	}
+	longest := 0
+	for num := range numSet {
+		if numSet[num-1] {
+			continue
+		}
+		cnt := 1
+		for numSet[num+cnt] {
+			cnt++
+		}
+		longest = max(longest, cnt)
+	}
+	return longest
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// 判题结果
+const (
+	VerdictAC  = "AC"  // 答案正确
+	VerdictWA  = "WA"  // 答案错误
+	VerdictRE  = "RE"  // 运行出错：输入不合法、panic
+	VerdictTLE = "TLE" // 超时
+)
+
+type Result struct {
+	Output    json.RawMessage `json:"output,omitempty"`
+	Expected  string          `json:"expected,omitempty"`
+	Verdict   string          `json:"verdict,omitempty"` // 没给期望输出时为空
+	Trace     *Trace          `json:"trace,omitempty"`
+	ElapsedNs int64           `json:"elapsedNs"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Run 用指定解法运行一次，expected 不为空时给出判题结果；超过 timeout 没有返回算超时
+func Run(s Solution, variant, input, expected string, timeout time.Duration) (ret Result) {
+	ret.Expected = expected
+	v, ok := s.Variant(variant)
+	if !ok {
+		ret.Verdict, ret.Error = VerdictRE, fmt.Sprintf("%s 没有 %q 解法，可选 %v", s.Name, variant, s.VariantNames())
+		return
+	}
+	args, err := parseArgs(input, s.Params)
+	if err != nil {
+		ret.Verdict, ret.Error = VerdictRE, err.Error()
+		return
+	}
+	type result struct {
+		out interface{}
+		tr  *Trace
+		err error
+	}
+	done := make(chan result, 1)
+	start := time.Now()
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("运行出错: %v", r)}
+			}
+		}()
+		out, tr, err := v.Run(args)
+		done <- result{out, tr, err}
+	}()
+	var r result
+	select {
+	case r = <-done:
+	case <-time.After(timeout):
+		// 死循环的解法停不下来，只能丢下那个 goroutine
+		ret.Verdict, ret.Error = VerdictTLE, fmt.Sprintf("超过 %v 没有返回", timeout)
+		return
+	}
+	ret.ElapsedNs = time.Since(start).Nanoseconds()
+	if r.err != nil {
+		ret.Verdict, ret.Error = VerdictRE, r.err.Error()
+		return
+	}
+	ret.Output, _ = json.Marshal(r.out)
+	ret.Trace = r.tr
+	if expected != "" {
+		ret.Verdict = VerdictWA
+		if sameJSON(ret.Output, []byte(expected), s.Unordered) {
+			ret.Verdict = VerdictAC
+		}
+	}
+	return
+}
+
+// sameJSON 忽略空白和数字写法的差异，unordered 时最外层数组不管顺序
+func sameJSON(a, b []byte, unordered bool) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	if unordered {
+		x, y = sortedJSON(x), sortedJSON(y)
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// sortedJSON 把数组按元素的 JSON 写法排序，不是数组原样返回
+func sortedJSON(v interface{}) interface{} {
+	a, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+	keys := make([]string, len(a))
+	for i, e := range a {
+		b, _ := json.Marshal(e)
+		keys[i] = string(b)
+	}
+	sort.Strings(keys)
+	ret := make([]interface{}, len(a))
+	for i, k := range keys {
+		json.Unmarshal([]byte(k), &ret[i])
+	}
+	return ret
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 判题服务的 JSON API
+//
+//	GET  /problems               题目列表，带标签和可选解法
+//	POST /problems/{id}/run      {"input": "...", "variant": "...", "expected": "...", "trace": true}
+//	POST /problems/{id}/judge    {"variant": "..."}，用 Server-Sent Events 逐个返回用例结果
+
+type ProblemInfo struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Title           string   `json:"title,omitempty"`
+	TranslatedTitle string   `json:"translatedTitle,omitempty"`
+	Difficulty      string   `json:"difficulty,omitempty"`
+	Tags            []string `json:"tags"`
+	Params          []string `json:"params"`
+	Variants        []string `json:"variants"`
+	Cases           int      `json:"cases"`
+}
+
+type runRequest struct {
+	Input    string `json:"input"`
+	Variant  string `json:"variant"`
+	Expected string `json:"expected"`
+	Trace    bool   `json:"trace"` // 默认不返回执行轨迹，轨迹可能很大
+}
+
+type CaseResult struct {
+	Index int    `json:"index"`
+	Input string `json:"input"`
+	Result
+}
+
+type Summary struct {
+	Passed int `json:"passed"`
+	Total  int `json:"total"`
+}
+
+type Server struct {
+	problems map[string]Problem // 题目元数据，可以为空
+	origin   string             // 允许跨域访问的前端地址
+	timeout  time.Duration      // 每个用例的运行时间上限
+	mux      *http.ServeMux
+}
+
+func NewServer(problems map[string]Problem, origin string, timeout time.Duration) *Server {
+	s := &Server{problems: problems, origin: origin, timeout: timeout, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /problems", s.list)
+	s.mux.HandleFunc("POST /problems/{id}/run", s.run)
+	s.mux.HandleFunc("POST /problems/{id}/judge", s.judge)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.origin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", s.origin)
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+	}
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	ret := make([]ProblemInfo, 0, len(solutions))
+	for id, sol := range solutions {
+		info := ProblemInfo{
+			ID:       id,
+			Name:     sol.Name,
+			Tags:     []string{},
+			Params:   sol.Params,
+			Variants: sol.VariantNames(),
+			Cases:    len(cases[id]),
+		}
+		if p, ok := s.problems[id]; ok {
+			info.Title = p.Title
+			info.TranslatedTitle = p.TranslatedTitle
+			info.Difficulty = p.Difficulty
+			for _, tag := range p.TopicTags {
+				info.Tags = append(info.Tags, tag.Name)
+			}
+		}
+		ret = append(ret, info)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, _ := strconv.Atoi(ret[i].ID)
+		b, _ := strconv.Atoi(ret[j].ID)
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) run(w http.ResponseWriter, r *http.Request) {
+	sol, ok := solutions[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "题目 %s 还不能运行", r.PathValue("id"))
+		return
+	}
+	var req runRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "请求体不是合法的 JSON: %v", err)
+		return
+	}
+	ret := Run(sol, req.Variant, req.Input, req.Expected, s.timeout)
+	if !req.Trace {
+		ret.Trace = nil
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) judge(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	sol, ok := solutions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "题目 %s 还不能运行", id)
+		return
+	}
+	var req runRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "请求体不是合法的 JSON: %v", err)
+			return
+		}
+	}
+	if _, ok := sol.Variant(req.Variant); !ok {
+		writeError(w, http.StatusBadRequest, "%s 没有 %q 解法，可选 %v", sol.Name, req.Variant, sol.VariantNames())
+		return
+	}
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	sum := Summary{Total: len(cases[id])}
+	for i, c := range cases[id] {
+		if r.Context().Err() != nil {
+			// 前端断开了就不用再跑了
+			return
+		}
+		ret := Run(sol, req.Variant, c.Input, c.Expected, s.timeout)
+		ret.Trace = nil
+		if ret.Verdict == VerdictAC {
+			sum.Passed++
+		}
+		writeEvent(w, "case", CaseResult{Index: i, Input: c.Input, Result: ret})
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	writeEvent(w, "done", sum)
+}
+
+func writeEvent(w http.ResponseWriter, event string, v interface{}) {
+	b, _ := json.Marshal(v)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	writeJSON(w, code, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
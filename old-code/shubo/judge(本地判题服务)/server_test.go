@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	problems, err := LoadProblems("../../../docs/leetcode-hot-100.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(NewServer(problems, "http://localhost:3000", time.Second))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func post(t *testing.T, url, body string) *http.Response {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestProblems(t *testing.T) {
+	ts := newTestServer(t)
+	resp, err := http.Get(ts.URL + "/problems")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Access-Control-Allow-Origin") != "http://localhost:3000" {
+		t.Fatal(resp.Header)
+	}
+	var list []ProblemInfo
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != len(solutions) || list[0].ID != "1" {
+		t.Fatalf("%+v", list)
+	}
+	for _, p := range list {
+		if p.ID == "42" {
+			if p.TranslatedTitle != "接雨水" || len(p.Tags) == 0 || strings.Join(p.Variants, ",") != "双指针,dp" {
+				t.Fatalf("%+v", p)
+			}
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	ts := newTestServer(t)
+	cases := []struct {
+		id, body string
+		code     int
+		verdict  string
+		output   string
+	}{
+		{"42", `{"input": "height = [4,2,0,3,2,5]", "expected": "9"}`, 200, VerdictAC, "9"},
+		{"42", `{"input": "[4,2,0,3,2,5]", "variant": "dp", "expected": "8"}`, 200, VerdictWA, "9"},
+		{"42", `{"input": "[4,2,0,3,2,5]"}`, 200, "", "9"},
+		{"42", `{"input": "[4,2"}`, 200, VerdictRE, ""},
+		{"42", `{"input": "[1]", "variant": "单调栈"}`, 200, VerdictRE, ""},
+		// 两数之和的下标可以按任意顺序返回
+		{"1", `{"input": "nums = [3,3], target = 6", "expected": "[1,0]"}`, 200, VerdictAC, "[0,1]"},
+		{"1", `{"input": "nums = [3,3], target = 6", "expected": "[1,1]"}`, 200, VerdictWA, "[0,1]"},
+		{"9999", `{"input": "[1]"}`, 404, "", ""},
+		{"42", `{`, 400, "", ""},
+	}
+	for _, c := range cases {
+		resp := post(t, ts.URL+"/problems/"+c.id+"/run", c.body)
+		if resp.StatusCode != c.code {
+			t.Fatalf("%s %s: %d", c.id, c.body, resp.StatusCode)
+		}
+		if c.code != 200 {
+			continue
+		}
+		var ret Result
+		json.NewDecoder(resp.Body).Decode(&ret)
+		if ret.Verdict != c.verdict || string(ret.Output) != c.output {
+			t.Fatalf("%s %s: %+v", c.id, c.body, ret)
+		}
+		if ret.Trace != nil {
+			t.Fatal("没要求时不返回 trace")
+		}
+	}
+	var ret Result
+	json.NewDecoder(post(t, ts.URL+"/problems/42/run", `{"input": "[4,2,0,3,2,5]", "trace": true}`).Body).Decode(&ret)
+	if ret.Trace == nil || len(ret.Trace.Events) == 0 {
+		t.Fatal("应该返回 trace")
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	loop := Solution{Name: "loop", Params: []string{"n"}, Variants: []Variant{
+		{"死循环", func(args []json.RawMessage) (interface{}, *Trace, error) {
+			for {
+				time.Sleep(time.Millisecond)
+			}
+		}},
+	}}
+	ret := Run(loop, "", "1", "1", 50*time.Millisecond)
+	if ret.Verdict != VerdictTLE {
+		t.Fatalf("%+v", ret)
+	}
+}
+
+// 读 SSE 流，返回 (事件名, data) 列表
+func readEvents(t *testing.T, resp *http.Response) (events []string, data []string) {
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			events = append(events, strings.TrimPrefix(line, "event: "))
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	return
+}
+
+func TestJudge(t *testing.T) {
+	ts := newTestServer(t)
+	for id, cs := range cases {
+		sol := solutions[id]
+		for _, variant := range sol.VariantNames() {
+			resp := post(t, ts.URL+"/problems/"+id+"/judge", `{"variant": "`+variant+`"}`)
+			if resp.Header.Get("Content-Type") != "text/event-stream" {
+				t.Fatal(resp.Header)
+			}
+			events, data := readEvents(t, resp)
+			if len(events) != len(cs)+1 || events[len(events)-1] != "done" {
+				t.Fatalf("%s %s: %v", id, variant, events)
+			}
+			for i, d := range data[:len(cs)] {
+				var ret CaseResult
+				json.Unmarshal([]byte(d), &ret)
+				if ret.Index != i || ret.Verdict != VerdictAC {
+					t.Fatalf("%s %s: %s", id, variant, d)
+				}
+			}
+			var sum Summary
+			json.Unmarshal([]byte(data[len(data)-1]), &sum)
+			if sum.Passed != len(cs) || sum.Total != len(cs) {
+				t.Fatalf("%s %s: %+v", id, variant, sum)
+			}
+		}
+	}
+	// 不传请求体时用默认解法
+	resp, err := http.Post(ts.URL+"/problems/70/judge", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if events, _ := readEvents(t, resp); len(events) != len(cases["70"])+1 {
+		t.Fatal(events)
+	}
+	if resp := post(t, ts.URL+"/problems/70/judge", `{"variant": "递归"}`); resp.StatusCode != 400 {
+		t.Fatal(resp.StatusCode)
+	}
+}
+
+func TestEveryProblemHasCases(t *testing.T) {
+	for id := range solutions {
+		if len(cases[id]) == 0 {
+			t.Fatalf("%s 没有判题用例", id)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 判题服务和 wasm 共用的题解和参数适配，wasm 里的 solutions.go 是从这里 go generate 拷贝的，
+// 改完要在 wasm(WebAssembly运行器) 下重新生成。只有判题服务用的解法写在 registry.go 里。
+
+// RunFunc 解析好的参数进去，返回结果和执行轨迹，没有埋点的题解 trace 为 nil
+type RunFunc func(args []json.RawMessage) (interface{}, *Trace, error)
+
+// ints 适配参数是一个 []int 的题解
+func ints[R any](f func([]int) R) RunFunc {
+	return func(args []json.RawMessage) (interface{}, *Trace, error) {
+		nums, err := decode[[]int](args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		return f(nums), nil, nil
+	}
+}
+
+// ints2 适配参数是 ([]int, int) 的题解
+func ints2[R any](f func([]int, int) R) RunFunc {
+	return func(args []json.RawMessage) (interface{}, *Trace, error) {
+		nums, err := decode[[]int](args[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		k, err := decode[int](args[1])
+		if err != nil {
+			return nil, nil, err
+		}
+		return f(nums, k), nil, nil
+	}
+}
+
+func runIsValid(args []json.RawMessage) (interface{}, *Trace, error) {
+	s, err := decode[string](args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return isValid(s), nil, nil
+}
+
+func runTrap(args []json.RawMessage) (interface{}, *Trace, error) {
+	tr, err := traceTrap(string(args[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tr.Events[len(tr.Events)-1].Args["result"], tr, nil
+}
+
+func runClimbStairs(args []json.RawMessage) (interface{}, *Trace, error) {
+	n, err := decode[int](args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	if n < 1 || n > 45 {
+		return nil, nil, fmt.Errorf("n 的范围是 [1, 45]")
+	}
+	return climbStairs(n), nil, nil
+}
+
+func twoSum(nums []int, target int) []int {
+	m := map[int]int{}
+	for i, v := range nums {
+		if j, ok := m[target-v]; ok {
+			return []int{j, i}
+		}
+		m[v] = i
+	}
+	return []int{}
+}
+
+func isValid(s string) bool {
+	pair := map[byte]byte{')': '(', ']': '[', '}': '{'}
+	var stack []byte
+	for i := 0; i < len(s); i++ {
+		if open, ok := pair[s[i]]; ok {
+			if len(stack) == 0 || stack[len(stack)-1] != open {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+		} else {
+			stack = append(stack, s[i])
+		}
+	}
+	return len(stack) == 0
+}
+
+func climbStairs(n int) int {
+	a, b := 1, 1
+	for i := 2; i <= n; i++ {
+		a, b = b, a+b
+	}
+	return b
+}
+
+func maxProfit(prices []int) int {
+	ans := 0
+	for i, low := 0, int(^uint(0)>>1); i < len(prices); i++ {
+		low = min(low, prices[i])
+		ans = max(ans, prices[i]-low)
+	}
+	return ans
+}
+
+func singleNumber(nums []int) int {
+	ans := 0
+	for _, v := range nums {
+		ans ^= v
+	}
+	return ans
+}
+
+func dailyTemperatures(temperatures []int) []int {
+	ans := make([]int, len(temperatures))
+	var stack []int
+	for i, t := range temperatures {
+		for len(stack) > 0 && temperatures[stack[len(stack)-1]] < t {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			ans[top] = i - top
+		}
+		stack = append(stack, i)
+	}
+	return ans
+}
+
+func min(a, b int) int {
+	if a > b {
+		return b
+	}
+	return a
+}
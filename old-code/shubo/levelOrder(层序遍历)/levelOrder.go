@@ -1,28 +1,29 @@
 package main
 
 import (
-	"container/list"
 	"fmt"
 )
 
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../queue(环形队列)/ring.go
+
 func levelOrder(root *TreeNode) [][]int {
 	if root == nil {
 		return [][]int{}
 	}
-	var queue = []*TreeNode{root}
+	var queue Queue[*TreeNode]
+	queue.Push(root)
 	var ret [][]int
-	for len(queue) > 0 {
-		var curLen = len(queue)
+	for queue.Len() > 0 {
+		var curLen = queue.Len()
 		var curLevel []int
 		for curLen > 0 {
-			curNode := queue[0]
-			queue = queue[1:]
+			curNode := queue.Pop()
 			curLevel = append(curLevel, curNode.Val)
 			if curNode.Left != nil {
-				queue = append(queue, curNode.Left)
+				queue.Push(curNode.Left)
 			}
 			if curNode.Right != nil {
-				queue = append(queue, curNode.Right)
+				queue.Push(curNode.Right)
 			}
 			curLen--
 		}
@@ -33,9 +34,18 @@ func levelOrder(root *TreeNode) [][]int {
 	return ret
 }
 func main() {
-	fmt.Println(levelOrder(Array2Tree([]*int{intPtr(3), intPtr(9), intPtr(20), nil, nil, intPtr(15), intPtr(7)})))
-	fmt.Println(levelOrder(Array2Tree([]*int{intPtr(1), intPtr(2), intPtr(3), intPtr(4), nil, nil, intPtr(5)})))
-	fmt.Println(levelOrder(Array2Tree([]*int{intPtr(3), intPtr(2), intPtr(3), intPtr(4), nil, nil, intPtr(5)})))
+	for _, arr := range [][]*int{
+		{intPtr(3), intPtr(9), intPtr(20), nil, nil, intPtr(15), intPtr(7)},
+		{intPtr(1), intPtr(2), intPtr(3), intPtr(4), nil, nil, intPtr(5)},
+		{intPtr(3), intPtr(2), intPtr(3), intPtr(4), nil, nil, intPtr(5)},
+	} {
+		root, err := Array2Tree(arr)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(levelOrder(root))
+	}
 }
 
 // 构造 二叉数 输入输出
@@ -46,8 +56,24 @@ type TreeNode struct {
 	Right *TreeNode
 }
 
-// Array2Tree 数组转树
-func Array2Tree(bfsArr []*int) (ret *TreeNode) {
+// Array2Tree 数组转树，[] 是空树；根是 null 或者有元素找不到父节点时报错
+func Array2Tree(bfsArr []*int) (ret *TreeNode, err error) {
+	if len(bfsArr) == 0 {
+		return nil, nil
+	}
+	if bfsArr[0] == nil {
+		return nil, fmt.Errorf("根节点不能是 null")
+	}
+	// 第 k 个非空节点的孩子在 2k+1、2k+2，所以第 p 个元素的父节点是第 (p-1)/2 个非空节点
+	seen := 0
+	for p, v := range bfsArr {
+		if p > 0 && (p-1)/2 >= seen {
+			return nil, fmt.Errorf("第 %d 个元素没有父节点", p)
+		}
+		if v != nil {
+			seen++
+		}
+	}
 	var nodes []*TreeNode
 	for _, i := range bfsArr {
 		if i == nil {
@@ -69,7 +95,7 @@ func Array2Tree(bfsArr []*int) (ret *TreeNode) {
 		}
 		idx++
 	}
-	return nodes[0]
+	return nodes[0], nil
 }
 
 func intPtr(val int) *int {
@@ -78,31 +104,22 @@ func intPtr(val int) *int {
 
 // 树转数组
 func Tree2Array(root *TreeNode, ret *[]*int) {
-	q := list.New()
+	var q Queue[*TreeNode]
 	if root == nil {
-		*ret = append(*ret, nil)
+		// 空树是 []，和 LeetCode 一样；以前输出 [null]，Array2Tree 读回来会报根节点是 null
 		return
 	}
-	q.PushFront(root)
+	q.Push(root)
 	for q.Len() != 0 {
-		curr := q.Back()
-		if curr.Value == nil {
+		curr := q.Pop()
+		if curr == nil {
 			*ret = append(*ret, nil)
-			q.Remove(curr)
 			continue
 		}
-		q.Remove(curr)
-		*ret = append(*ret, intPtr(curr.Value.(*TreeNode).Val))
-		if curr.Value.(*TreeNode).Left != nil {
-			q.PushFront(curr.Value.(*TreeNode).Left)
-		} else {
-			q.PushFront(nil)
-		}
-		if curr.Value.(*TreeNode).Right != nil {
-			q.PushFront(curr.Value.(*TreeNode).Right)
-		} else {
-			q.PushFront(nil)
-		}
+		*ret = append(*ret, intPtr(curr.Val))
+		// 空孩子也入队，输出里占一个 null
+		q.Push(curr.Left)
+		q.Push(curr.Right)
 	}
 	// 清理尾部多余的nil
 	i := len(*ret) - 1
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -o traverse_test.go -- ../tree(二叉树遍历)/traverse.go
+
+// Levels 每次给出一整层，只剩把节点换成值
+func levelOrderIter(root *TreeNode) [][]int {
+	ret := [][]int{}
+	for level := range Levels(root) {
+		vals := make([]int, len(level))
+		for i, n := range level {
+			vals[i] = n.Val
+		}
+		ret = append(ret, vals)
+	}
+	return ret
+}
+
+// randomTree n 个节点的随机形状，值在 [0, 10)
+func randomTree(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	k := r.Intn(n)
+	return &TreeNode{Val: r.Intn(10), Left: randomTree(r, k), Right: randomTree(r, n-1-k)}
+}
+
+func TestLevelOrderIter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		root := randomTree(r, r.Intn(20))
+		if a, b := levelOrderIter(root), levelOrder(root); !reflect.DeepEqual(a, b) {
+			t.Fatalf("%v != %v", a, b)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// go test -fuzz FuzzArray2Tree
+// Array2Tree 不报错时，Tree2Array 要原样打印回来（末尾的 null 除外），层序遍历要覆盖所有非 null 元素
+func FuzzArray2Tree(f *testing.F) {
+	for _, s := range []string{"[]", "[null]", "[1,null,2]", "[1,null,null,2]",
+		"[3,9,20,null,null,15,7]", "[1,2,3,4,null,null,5]", "[3,2,3,4,null,null,5]"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var arr []*int
+		if json.Unmarshal([]byte(s), &arr) != nil {
+			return
+		}
+		root, err := Array2Tree(arr)
+		if err != nil {
+			return
+		}
+		for len(arr) > 0 && arr[len(arr)-1] == nil {
+			arr = arr[:len(arr)-1]
+		}
+		var out []*int
+		Tree2Array(root, &out)
+		if toJSON(out) != toJSON(arr) {
+			t.Fatalf("%s -> %s", s, toJSON(out))
+		}
+		n := 0
+		for _, level := range levelOrder(root) {
+			n += len(level)
+		}
+		want := 0
+		for _, v := range arr {
+			if v != nil {
+				want++
+			}
+		}
+		if n != want {
+			t.Fatalf("%s 有 %d 个节点，层序遍历出 %d 个", s, want, n)
+		}
+	})
+}
+
+func toJSON(arr []*int) string {
+	if arr == nil {
+		arr = []*int{}
+	}
+	b, _ := json.Marshal(arr)
+	return string(b)
+}
+
+// levelOrderSlice 换成环形队列之前的 levelOrder，原样保留，和现在的版本对拍、比分配
+func levelOrderSlice(root *TreeNode) [][]int {
+	if root == nil {
+		return [][]int{}
+	}
+	var queue = []*TreeNode{root}
+	var ret [][]int
+	for len(queue) > 0 {
+		var curLen = len(queue)
+		var curLevel []int
+		for curLen > 0 {
+			curNode := queue[0]
+			queue = queue[1:]
+			curLevel = append(curLevel, curNode.Val)
+			if curNode.Left != nil {
+				queue = append(queue, curNode.Left)
+			}
+			if curNode.Right != nil {
+				queue = append(queue, curNode.Right)
+			}
+			curLen--
+		}
+		if len(curLevel) != 0 {
+			ret = append(ret, curLevel)
+		}
+	}
+	return ret
+}
+
+// completeTree n 个节点的完全二叉树，值是层序下标
+func completeTree(n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]*TreeNode, n)
+	for i := range nodes {
+		nodes[i] = &TreeNode{Val: i}
+	}
+	for i := range nodes {
+		if 2*i+1 < n {
+			nodes[i].Left = nodes[2*i+1]
+		}
+		if 2*i+2 < n {
+			nodes[i].Right = nodes[2*i+2]
+		}
+	}
+	return nodes[0]
+}
+
+func TestLevelOrderSlice(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 100, 1000} {
+		root := completeTree(n)
+		if a, b := levelOrder(root), levelOrderSlice(root); !reflect.DeepEqual(a, b) {
+			t.Fatalf("n=%d", n)
+		}
+	}
+}
+
+// 10^5 个节点，-benchmem 看分配次数和字节数
+// go test -bench . -benchmem *.go
+func BenchmarkLevelOrder(b *testing.B) {
+	root := completeTree(100000)
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			levelOrderSlice(root)
+		}
+	})
+	b.Run("ring", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			levelOrder(root)
+		}
+	})
+}
+
+// 空树输出 []，不是以前的 [null]，这样 Array2Tree 能原样读回来
+func TestTree2ArrayEmpty(t *testing.T) {
+	var out []*int
+	Tree2Array(nil, &out)
+	if len(out) != 0 {
+		t.Fatalf("空树输出 %s", toJSON(out))
+	}
+	if root, err := Array2Tree(out); root != nil || err != nil {
+		t.Fatal(root, err)
+	}
+}
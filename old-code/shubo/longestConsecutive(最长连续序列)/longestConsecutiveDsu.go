@@ -0,0 +1,24 @@
+package main
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../dsu(并查集)/dsu.go
+
+// 并查集解法：去重后每个数和 num+1 合并，答案是最大的集合
+func longestConsecutiveDsu(nums []int) int {
+	index := make(map[int]int, len(nums))
+	for _, num := range nums {
+		if _, ok := index[num]; !ok {
+			index[num] = len(index)
+		}
+	}
+	d := New(len(index))
+	for num, i := range index {
+		if j, ok := index[num+1]; ok {
+			d.Union(i, j)
+		}
+	}
+	ans := 0
+	for _, i := range index {
+		ans = max(ans, d.Size(i))
+	}
+	return ans
+}
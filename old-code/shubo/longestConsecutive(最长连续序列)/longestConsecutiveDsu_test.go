@@ -0,0 +1,52 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLongestConsecutiveDsu(t *testing.T) {
+	for _, c := range []struct {
+		nums []int
+		want int
+	}{
+		{[]int{100, 4, 200, 1, 3, 2}, 4},
+		{[]int{0, 3, 7, 2, 5, 8, 4, 6, 0, 1}, 9},
+		{[]int{1, 0, 1, 2}, 3},
+		{nil, 0},
+	} {
+		if got := longestConsecutiveDsu(c.nums); got != c.want {
+			t.Fatalf("%v: %d", c.nums, got)
+		}
+	}
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 300; i++ {
+		nums := make([]int, r.Intn(40))
+		for j := range nums {
+			nums[j] = r.Intn(60) - 30
+		}
+		if a, b := longestConsecutiveDsu(nums), longestConsecutive(nums); a != b {
+			t.Fatalf("%v: %d != %d", nums, a, b)
+		}
+	}
+}
+
+// go test -bench . -benchmem *.go
+func BenchmarkLongestConsecutive(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	nums := make([]int, 100000)
+	for i := range nums {
+		nums[i] = r.Intn(200000)
+	}
+	for _, impl := range []struct {
+		name string
+		f    func([]int) int
+	}{{"hash", longestConsecutive}, {"dsu", longestConsecutiveDsu}} {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				impl.f(nums)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"reflect"
 	"testing"
 )
 
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../queue(环形队列)/ring.go
+
 // 236. 二叉树的最近公共祖先
 // 记录每个节点的祖先路径
 // 所有 Node.val 互不相同 。
@@ -32,7 +35,114 @@ func TestLowestCommonAncestor(t *testing.T) {
 	//t.Log(ret.Val)
 
 	//ret = lowestCommonAncestor1(root, 113, 114)
-	t.Log(ret.Val)
+	root := example()
+	mp := getTreeValMap(root)
+	for _, c := range []struct{ p, q, want int }{{5, 1, 3}, {5, 4, 5}, {6, 4, 5}, {7, 8, 3}} {
+		if ret = lowestCommonAncestor(root, mp[c.p], mp[c.q]); ret.Val != c.want {
+			t.Fatalf("lowestCommonAncestor(%d, %d) = %d, 期望 %d", c.p, c.q, ret.Val, c.want)
+		}
+		if ret = lowestCommonAncestorRescur(root, mp[c.p], mp[c.q]); ret.Val != c.want {
+			t.Fatalf("lowestCommonAncestorRescur(%d, %d) = %d, 期望 %d", c.p, c.q, ret.Val, c.want)
+		}
+	}
+}
+
+// example [3,5,1,6,2,0,8,null,null,7,4]
+func example() *TreeNode {
+	return &TreeNode{Val: 3,
+		Left: &TreeNode{Val: 5,
+			Left:  &TreeNode{Val: 6},
+			Right: &TreeNode{Val: 2, Left: &TreeNode{Val: 7}, Right: &TreeNode{Val: 4}}},
+		Right: &TreeNode{Val: 1, Left: &TreeNode{Val: 0}, Right: &TreeNode{Val: 8}},
+	}
+}
+
+// completeTree n 个节点的完全二叉树，值是层序下标
+func completeTree(n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	nodes := make([]*TreeNode, n)
+	for i := range nodes {
+		nodes[i] = &TreeNode{Val: i}
+	}
+	for i := range nodes {
+		if 2*i+1 < n {
+			nodes[i].Left = nodes[2*i+1]
+		}
+		if 2*i+2 < n {
+			nodes[i].Right = nodes[2*i+2]
+		}
+	}
+	return nodes[0]
+}
+
+// processSlice 换成环形队列之前的 process，原样保留，和现在的版本对拍、比分配
+func processSlice(root *TreeNode) map[int]*[]*TreeNode {
+	var ret = make(map[int]*[]*TreeNode)
+	var stack []*TreeNode
+	if root == nil {
+		return ret
+	}
+	ret[root.Val] = &[]*TreeNode{root}
+	stack = append(stack, root)
+	for len(stack) > 0 {
+		var curLen = len(stack)
+		for curLen > 0 {
+			par := stack[0]
+			stack = stack[1:]
+			if par.Left != nil {
+				if ret[par.Left.Val] == nil {
+					ret[par.Left.Val] = &[]*TreeNode{}
+				}
+				*ret[par.Left.Val] = append(*ret[par.Left.Val], *ret[par.Val]...)
+				*ret[par.Left.Val] = append(*ret[par.Left.Val], par.Left)
+				stack = append(stack, par.Left)
+			}
+			if par.Right != nil {
+				if ret[par.Right.Val] == nil {
+					ret[par.Right.Val] = &[]*TreeNode{}
+				}
+				*ret[par.Right.Val] = append(*ret[par.Right.Val], *ret[par.Val]...)
+				*ret[par.Right.Val] = append(*ret[par.Right.Val], par.Right)
+				stack = append(stack, par.Right)
+			}
+			curLen--
+		}
+
+	}
+	return ret
+
+}
+
+func TestProcessSlice(t *testing.T) {
+	path := *process(example())[7]
+	if len(path) != 4 || path[0].Val != 3 || path[1].Val != 5 || path[2].Val != 2 || path[3].Val != 7 {
+		t.Fatalf("7 的祖先路径 %v", path)
+	}
+	for _, root := range []*TreeNode{nil, example(), completeTree(1), completeTree(100), completeTree(1000)} {
+		if a, b := process(root), processSlice(root); !reflect.DeepEqual(a, b) {
+			t.Fatal("和原来的 process 不一样")
+		}
+	}
+}
+
+// 10^5 个节点，-benchmem 看分配次数和字节数
+// go test -bench . -benchmem *.go
+func BenchmarkProcess(b *testing.B) {
+	root := completeTree(100000)
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			processSlice(root)
+		}
+	})
+	b.Run("ring", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			process(root)
+		}
+	})
 }
 
 type TreeNode struct {
@@ -95,24 +205,23 @@ func getFromMap(mp map[int]*[]*TreeNode, key int) (ret []*TreeNode) {
 }
 func process(root *TreeNode) map[int]*[]*TreeNode {
 	var ret = make(map[int]*[]*TreeNode)
-	var stack []*TreeNode
+	var stack Queue[*TreeNode]
 	if root == nil {
 		return ret
 	}
 	ret[root.Val] = &[]*TreeNode{root}
-	stack = append(stack, root)
-	for len(stack) > 0 {
-		var curLen = len(stack)
+	stack.Push(root)
+	for stack.Len() > 0 {
+		var curLen = stack.Len()
 		for curLen > 0 {
-			par := stack[0]
-			stack = stack[1:]
+			par := stack.Pop()
 			if par.Left != nil {
 				if ret[par.Left.Val] == nil {
 					ret[par.Left.Val] = &[]*TreeNode{}
 				}
 				*ret[par.Left.Val] = append(*ret[par.Left.Val], *ret[par.Val]...)
 				*ret[par.Left.Val] = append(*ret[par.Left.Val], par.Left)
-				stack = append(stack, par.Left)
+				stack.Push(par.Left)
 			}
 			if par.Right != nil {
 				if ret[par.Right.Val] == nil {
@@ -120,7 +229,7 @@ func process(root *TreeNode) map[int]*[]*TreeNode {
 				}
 				*ret[par.Right.Val] = append(*ret[par.Right.Val], *ret[par.Val]...)
 				*ret[par.Right.Val] = append(*ret[par.Right.Val], par.Right)
-				stack = append(stack, par.Right)
+				stack.Push(par.Right)
 			}
 			curLen--
 		}
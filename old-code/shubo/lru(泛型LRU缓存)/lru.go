@@ -0,0 +1,225 @@
+// Package lru 泛型、并发安全的 LRU 缓存
+// 从 LRUCache(LRU缓存) 演化而来，还是 map + 带哨兵的双向链表，Get/Put 都是 O(1)：
+// head 后面是最久未使用的，tail 前面是最近使用的。
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+type entry[K comparable, V any] struct {
+	key        K
+	val        V
+	expire     time.Time // 零值表示不过期
+	prev, next *entry[K, V]
+}
+
+type Options[K comparable, V any] struct {
+	// TTL 大于 0 时，写入超过 TTL 的条目视为不存在
+	TTL time.Duration
+	// OnEvict 容量不足被淘汰、或者过期被清理时回调，Remove 不回调
+	// 回调在释放锁之后执行，可以在回调里访问缓存
+	OnEvict func(key K, value V)
+	// Now 测试时替换时钟，默认 time.Now
+	Now func() time.Time
+}
+
+type Stats struct {
+	Hits        int64 `json:"hits"`
+	Misses      int64 `json:"misses"`
+	Evictions   int64 `json:"evictions"`   // 容量不足被淘汰
+	Expirations int64 `json:"expirations"` // 过期被清理
+}
+
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	m          map[K]*entry[K, V]
+	head, tail *entry[K, V]
+	cap        int
+	opt        Options[K, V]
+	stats      Stats
+}
+
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	return NewWithOptions(capacity, Options[K, V]{})
+}
+
+func NewWithOptions[K comparable, V any](capacity int, opt Options[K, V]) *Cache[K, V] {
+	if capacity <= 0 {
+		panic("lru: capacity 必须大于 0")
+	}
+	if opt.Now == nil {
+		opt.Now = time.Now
+	}
+	c := &Cache[K, V]{
+		m:    make(map[K]*entry[K, V], capacity),
+		head: &entry[K, V]{},
+		tail: &entry[K, V]{},
+		cap:  capacity,
+		opt:  opt,
+	}
+	c.head.next = c.tail
+	c.tail.prev = c.head
+	return c
+}
+
+// Get 命中时刷新到队尾
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var evicted []*entry[K, V]
+	defer func() { c.notify(evicted) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.m[key]
+	if ok && c.expired(e) {
+		c.remove(e)
+		c.stats.Expirations++
+		evicted = append(evicted, e)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.moveToTail(e)
+	return e.val, true
+}
+
+// Peek 只看不刷新，也不计入命中率
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.m[key]
+	if !ok || c.expired(e) {
+		var zero V
+		return zero, false
+	}
+	return e.val, true
+}
+
+// Put 已存在则更新值并刷新到队尾，不存在则插入，容量不足时淘汰队头
+// 返回是否淘汰了别的 key
+func (c *Cache[K, V]) Put(key K, value V) (evicted bool) {
+	var out []*entry[K, V]
+	defer func() { c.notify(out) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.m[key]; ok {
+		e.val = value
+		e.expire = c.expireAt()
+		c.moveToTail(e)
+		return false
+	}
+	e := &entry[K, V]{key: key, val: value, expire: c.expireAt()}
+	c.m[key] = e
+	c.appendToTail(e)
+	if len(c.m) > c.cap {
+		old := c.head.next
+		c.remove(old)
+		c.stats.Evictions++
+		out = append(out, old)
+		return true
+	}
+	return false
+}
+
+// Remove 删除 key，返回 key 之前是否存在
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.m[key]
+	if ok {
+		c.remove(e)
+	}
+	return ok
+}
+
+// Len 包括已过期但还没被清理的条目
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.m)
+}
+
+// Purge 清理所有过期的条目，返回清理的个数
+func (c *Cache[K, V]) Purge() int {
+	var out []*entry[K, V]
+	defer func() { c.notify(out) }()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.head.next; e != c.tail; {
+		next := e.next
+		if c.expired(e) {
+			c.remove(e)
+			c.stats.Expirations++
+			out = append(out, e)
+		}
+		e = next
+	}
+	return len(out)
+}
+
+// Keys 从最久未使用到最近使用
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, len(c.m))
+	for e := c.head.next; e != c.tail; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Cache[K, V]) expireAt() time.Time {
+	if c.opt.TTL <= 0 {
+		return time.Time{}
+	}
+	return c.opt.Now().Add(c.opt.TTL)
+}
+
+func (c *Cache[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expire.IsZero() && !c.opt.Now().Before(e.expire)
+}
+
+func (c *Cache[K, V]) notify(evicted []*entry[K, V]) {
+	if c.opt.OnEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		c.opt.OnEvict(e.key, e.val)
+	}
+}
+
+func (c *Cache[K, V]) remove(e *entry[K, V]) {
+	c.unlink(e)
+	delete(c.m, e.key)
+}
+
+func (c *Cache[K, V]) moveToTail(e *entry[K, V]) {
+	c.unlink(e)
+	c.appendToTail(e)
+}
+
+func (c *Cache[K, V]) appendToTail(e *entry[K, V]) {
+	e.prev = c.tail.prev
+	e.next = c.tail
+	c.tail.prev = e
+	e.prev.next = e
+}
+
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev, e.next = nil, nil
+}
@@ -0,0 +1,207 @@
+package lru
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// go test -race lru.go sharded.go lru_test.go original_test.go
+
+func TestLeetCodeExample(t *testing.T) {
+	c := New[int, int](2)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	var got []int
+	get := func(k int) {
+		v, ok := c.Get(k)
+		if !ok {
+			v = -1
+		}
+		got = append(got, v)
+	}
+	get(1)
+	c.Put(3, 3)
+	get(2)
+	c.Put(4, 4)
+	get(1)
+	get(3)
+	get(4)
+	if want := []int{1, -1, -1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("%v != %v", got, want)
+	}
+	if st := c.Stats(); st != (Stats{Hits: 3, Misses: 2, Evictions: 2}) {
+		t.Fatalf("%+v", st)
+	}
+}
+
+func TestPeekRemove(t *testing.T) {
+	c := New[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	// Peek 不刷新顺序，a 仍然是最久未使用的
+	if v, ok := c.Peek("a"); !ok || v != 1 {
+		t.Fatal(v, ok)
+	}
+	if !c.Put("c", 3) {
+		t.Fatal("应该淘汰了 a")
+	}
+	if _, ok := c.Peek("a"); ok {
+		t.Fatal("a 应该被淘汰了")
+	}
+	if !c.Remove("b") || c.Remove("b") || c.Len() != 1 {
+		t.Fatal("Remove 不对")
+	}
+	if st := c.Stats(); st.Hits != 0 || st.Misses != 0 {
+		t.Fatalf("Peek 不计入命中率: %+v", st)
+	}
+	// 更新已存在的 key 会刷新顺序
+	c.Put("d", 4)
+	c.Put("c", 30)
+	c.Put("e", 5)
+	if got := c.Keys(); !reflect.DeepEqual(got, []string{"c", "e"}) {
+		t.Fatal(got)
+	}
+}
+
+func TestOnEvict(t *testing.T) {
+	var evicted []string
+	var c *Cache[int, string]
+	c = NewWithOptions(2, Options[int, string]{OnEvict: func(k int, v string) {
+		evicted = append(evicted, fmt.Sprintf("%d=%s", k, v))
+		// 回调里访问缓存不能死锁
+		c.Len()
+	}})
+	c.Put(1, "a")
+	c.Put(2, "b")
+	c.Get(1)
+	c.Put(3, "c")
+	c.Put(4, "d")
+	c.Remove(4)
+	if want := []string{"2=b", "1=a"}; !reflect.DeepEqual(evicted, want) {
+		t.Fatalf("%v != %v", evicted, want)
+	}
+}
+
+type fakeClock struct{ now time.Time }
+
+func (f *fakeClock) Now() time.Time          { return f.now }
+func (f *fakeClock) Advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func TestTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var expired []int
+	c := NewWithOptions(10, Options[int, int]{
+		TTL:     time.Minute,
+		Now:     clock.Now,
+		OnEvict: func(k, v int) { expired = append(expired, k) },
+	})
+	c.Put(1, 1)
+	clock.Advance(30 * time.Second)
+	c.Put(2, 2)
+	clock.Advance(30 * time.Second)
+	// 1 正好到期，2 还剩 30 秒
+	if _, ok := c.Peek(1); ok {
+		t.Fatal("1 应该过期了")
+	}
+	if _, ok := c.Get(1); ok {
+		t.Fatal("1 应该过期了")
+	}
+	if v, ok := c.Get(2); !ok || v != 2 {
+		t.Fatal("2 还没过期")
+	}
+	// 重新写入会刷新过期时间
+	c.Put(2, 20)
+	c.Put(3, 3)
+	clock.Advance(59 * time.Second)
+	if v, ok := c.Get(2); !ok || v != 20 {
+		t.Fatal("2 的过期时间应该被刷新了")
+	}
+	clock.Advance(time.Second)
+	if n := c.Purge(); n != 2 || c.Len() != 0 {
+		t.Fatalf("Purge 清理了 %d 个，还剩 %d 个", n, c.Len())
+	}
+	// Purge 按从旧到新的顺序清理，Get(2) 之后 2 排在 3 后面
+	if !reflect.DeepEqual(expired, []int{1, 3, 2}) {
+		t.Fatal(expired)
+	}
+	if st := c.Stats(); st.Expirations != 3 || st.Evictions != 0 || st.Misses != 1 {
+		t.Fatalf("%+v", st)
+	}
+}
+
+func TestSharded(t *testing.T) {
+	s := NewSharded[int, int](4, 10, Options[int, int]{})
+	for i := 0; i < 100; i++ {
+		s.Put(i, i)
+	}
+	// 每个分片容量 2 或 3，加起来正好 10
+	if s.Len() != 10 {
+		t.Fatal(s.Len())
+	}
+	hits := 0
+	for i := 0; i < 100; i++ {
+		if v, ok := s.Get(i); ok {
+			if v != i {
+				t.Fatal(i, v)
+			}
+			hits++
+		}
+	}
+	if st := s.Stats(); hits != 10 || st.Hits != 10 || st.Misses != 90 || st.Evictions != 90 {
+		t.Fatalf("hits=%d %+v", hits, st)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	for name, f := range map[string]func(){
+		"capacity 0": func() { New[int, int](0) },
+		"分片比容量多":     func() { NewSharded[int, int](4, 3, Options[int, int]{}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("%s 应该 panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// 用 -race 跑，检查并发读写
+func TestConcurrent(t *testing.T) {
+	c := NewWithOptions(64, Options[int, int]{TTL: time.Millisecond})
+	s := NewSharded[int, int](8, 64, Options[int, int]{})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				k := (g*31 + i) % 128
+				c.Put(k, i)
+				c.Get(k + 1)
+				c.Peek(k)
+				s.Put(k, i)
+				s.Get(k + 1)
+				if i%100 == 0 {
+					c.Remove(k)
+					c.Purge()
+					c.Keys()
+					s.Stats()
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if c.Len() > 64 || s.Len() > 64 {
+		t.Fatal(c.Len(), s.Len())
+	}
+	st := c.Stats()
+	if st.Hits+st.Misses != 8*2000 {
+		t.Fatalf("%+v", st)
+	}
+}
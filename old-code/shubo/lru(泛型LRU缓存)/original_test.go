@@ -0,0 +1,208 @@
+package lru
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// 从 shubo/LRUCache(LRU缓存)/LRUCache.go 拷贝过来，代码没改，只去掉了注释和 main，
+// 和 int-only 的原版对拍、比速度
+
+type LRUCache struct {
+	m          map[int]*DeListNode
+	head, tail *DeListNode
+	cap        int
+	size       int
+}
+type DeListNode struct {
+	k, v       int
+	prev, next *DeListNode
+}
+
+func Constructor(capacity int) LRUCache {
+	v := LRUCache{
+		m:    map[int]*DeListNode{},
+		head: &DeListNode{},
+		tail: &DeListNode{},
+		cap:  capacity,
+	}
+	v.head.next = v.tail
+	v.tail.prev = v.head
+	return v
+}
+
+func (this *LRUCache) Get(key int) int {
+	node, ok := this.m[key]
+	if !ok {
+		return -1
+	}
+	this.moveToTail(node)
+	return node.v
+}
+func (this *LRUCache) moveToTail(node *DeListNode) {
+	this.deleteNode(node)
+	this.appendToTail(node)
+}
+
+func (this *LRUCache) appendToTail(node *DeListNode) {
+	if node == nil {
+		return
+	}
+	node.prev = this.tail.prev
+	node.next = this.tail
+	this.tail.prev = node
+	node.prev.next = node
+}
+
+func (this *LRUCache) deleteNode(node *DeListNode) {
+	if node == nil {
+		return
+	}
+	node.prev.next = node.next
+	node.next.prev = node.prev
+}
+func (this *LRUCache) releaseCache() {
+	if this.size > this.cap {
+		node := this.head.next
+		this.deleteNode(node)
+		delete(this.m, node.k)
+		this.size--
+	}
+}
+
+func (this *LRUCache) Put(key int, value int) {
+	if node, ok := this.m[key]; ok {
+		node.v = value
+		this.deleteNode(node)
+		this.moveToTail(node)
+	} else {
+		newNode := &DeListNode{k: key, v: value}
+		this.m[key] = newNode
+		this.appendToTail(newNode)
+		this.size++
+		this.releaseCache()
+	}
+}
+
+// 和原版随机对拍
+func TestSameAsOriginal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, capacity := range []int{1, 2, 5, 50} {
+		orig := Constructor(capacity)
+		c := New[int, int](capacity)
+		for i := 0; i < 10000; i++ {
+			k := r.Intn(3 * capacity)
+			if r.Intn(2) == 0 {
+				orig.Put(k, i)
+				c.Put(k, i)
+				continue
+			}
+			v, ok := c.Get(k)
+			if !ok {
+				v = -1
+			}
+			if want := orig.Get(k); v != want {
+				t.Fatalf("cap=%d 第 %d 步 Get(%d) = %d, 原版是 %d", capacity, i, k, v, want)
+			}
+		}
+	}
+}
+
+const benchCap = 1024
+
+// 一半读一半写，key 的范围是容量的 2 倍
+func benchKeys() []int {
+	r := rand.New(rand.NewSource(1))
+	keys := make([]int, 1<<16)
+	for i := range keys {
+		keys[i] = r.Intn(2 * benchCap)
+	}
+	return keys
+}
+
+func BenchmarkOriginal(b *testing.B) {
+	keys := benchKeys()
+	c := Constructor(benchCap)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(len(keys)-1)]
+		if i&1 == 0 {
+			c.Put(k, i)
+		} else {
+			c.Get(k)
+		}
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	keys := benchKeys()
+	c := New[int, int](benchCap)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i&(len(keys)-1)]
+		if i&1 == 0 {
+			c.Put(k, i)
+		} else {
+			c.Get(k)
+		}
+	}
+}
+
+// 原版不是并发安全的，并发对比时给它加一把全局锁
+func BenchmarkOriginalParallel(b *testing.B) {
+	keys := benchKeys()
+	c := Constructor(benchCap)
+	var mu sync.Mutex
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i&(len(keys)-1)]
+			mu.Lock()
+			if i&1 == 0 {
+				c.Put(k, i)
+			} else {
+				c.Get(k)
+			}
+			mu.Unlock()
+			i++
+		}
+	})
+}
+
+func BenchmarkCacheParallel(b *testing.B) {
+	keys := benchKeys()
+	c := New[int, int](benchCap)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i&(len(keys)-1)]
+			if i&1 == 0 {
+				c.Put(k, i)
+			} else {
+				c.Get(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedParallel(b *testing.B) {
+	keys := benchKeys()
+	s := NewSharded[int, int](16, benchCap, Options[int, int]{})
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := keys[i&(len(keys)-1)]
+			if i&1 == 0 {
+				s.Put(k, i)
+			} else {
+				s.Get(k)
+			}
+			i++
+		}
+	})
+}
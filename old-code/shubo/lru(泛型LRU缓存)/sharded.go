@@ -0,0 +1,67 @@
+package lru
+
+import "hash/maphash"
+
+// Sharded 按 key 的哈希分成多个 Cache，各自加锁，减少并发时的锁竞争
+// 每个分片单独做 LRU，所以淘汰顺序只在分片内是严格的
+type Sharded[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []*Cache[K, V]
+}
+
+// NewSharded 总容量 capacity 平均分到 shards 个分片上
+func NewSharded[K comparable, V any](shards, capacity int, opt Options[K, V]) *Sharded[K, V] {
+	if shards <= 0 || capacity < shards {
+		panic("lru: 分片数必须大于 0 且不超过容量")
+	}
+	s := &Sharded[K, V]{seed: maphash.MakeSeed(), shards: make([]*Cache[K, V], shards)}
+	for i := range s.shards {
+		c := capacity / shards
+		if i < capacity%shards {
+			c++
+		}
+		s.shards[i] = NewWithOptions(c, opt)
+	}
+	return s
+}
+
+func (s *Sharded[K, V]) shard(key K) *Cache[K, V] {
+	return s.shards[maphash.Comparable(s.seed, key)%uint64(len(s.shards))]
+}
+
+func (s *Sharded[K, V]) Get(key K) (V, bool) { return s.shard(key).Get(key) }
+
+func (s *Sharded[K, V]) Peek(key K) (V, bool) { return s.shard(key).Peek(key) }
+
+func (s *Sharded[K, V]) Put(key K, value V) bool { return s.shard(key).Put(key, value) }
+
+func (s *Sharded[K, V]) Remove(key K) bool { return s.shard(key).Remove(key) }
+
+func (s *Sharded[K, V]) Len() int {
+	n := 0
+	for _, c := range s.shards {
+		n += c.Len()
+	}
+	return n
+}
+
+func (s *Sharded[K, V]) Purge() int {
+	n := 0
+	for _, c := range s.shards {
+		n += c.Purge()
+	}
+	return n
+}
+
+// Stats 所有分片的计数之和
+func (s *Sharded[K, V]) Stats() Stats {
+	var sum Stats
+	for _, c := range s.shards {
+		st := c.Stats()
+		sum.Hits += st.Hits
+		sum.Misses += st.Misses
+		sum.Evictions += st.Evictions
+		sum.Expirations += st.Expirations
+	}
+	return sum
+}
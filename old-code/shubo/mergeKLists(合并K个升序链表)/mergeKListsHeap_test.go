@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../pq(优先队列)/heap.go
+
+// 堆里只放每条链表的当前节点，O(N log k)
+// 每次 Push 都要分配一个句柄，链表短的时候反而比排序慢，见 BenchmarkMergeKLists
+func mergeKListsHeap(lists []*ListNode) *ListNode {
+	h := New(func(a, b *ListNode) bool { return a.Val < b.Val })
+	for _, l := range lists {
+		if l != nil {
+			h.Push(l)
+		}
+	}
+	dummy := &ListNode{}
+	cur := dummy
+	for h.Len() > 0 {
+		node := h.Pop()
+		cur.Next = node
+		cur = node
+		if node.Next != nil {
+			h.Push(node.Next)
+		}
+	}
+	return dummy.Next
+}
+
+func buildList(nums []int) *ListNode {
+	dummy := &ListNode{}
+	cur := dummy
+	for _, v := range nums {
+		cur.Next = &ListNode{Val: v}
+		cur = cur.Next
+	}
+	return dummy.Next
+}
+
+func listValues(l *ListNode) []int {
+	var ret []int
+	for ; l != nil; l = l.Next {
+		ret = append(ret, l.Val)
+	}
+	return ret
+}
+
+func randomLists(r *rand.Rand, k, n int) []*ListNode {
+	lists := make([]*ListNode, k)
+	for i := range lists {
+		nums := make([]int, r.Intn(n+1))
+		for j := range nums {
+			nums[j] = r.Intn(1000)
+		}
+		sort.Ints(nums)
+		lists[i] = buildList(nums)
+	}
+	return lists
+}
+
+func TestMergeKListsHeap(t *testing.T) {
+	got := listValues(mergeKListsHeap([]*ListNode{buildList([]int{1, 4, 5}), buildList([]int{1, 3, 4}), buildList([]int{2, 6})}))
+	if !reflect.DeepEqual(got, []int{1, 1, 2, 3, 4, 4, 5, 6}) {
+		t.Fatal(got)
+	}
+	if mergeKListsHeap(nil) != nil || mergeKListsHeap([]*ListNode{nil}) != nil {
+		t.Fatal("空链表")
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		a := listValues(mergeKListsHeap(randomLists(rand.New(rand.NewSource(seed)), 8, 10)))
+		b := listValues(mergeKLists(randomLists(rand.New(rand.NewSource(seed)), 8, 10)))
+		if !reflect.DeepEqual(a, b) {
+			t.Fatalf("seed=%d\n%v\n%v", seed, a, b)
+		}
+	}
+}
+
+// go test -bench . -benchmem *.go
+func BenchmarkMergeKLists(b *testing.B) {
+	for _, k := range []int{10, 100, 1000} {
+		for _, impl := range []struct {
+			name string
+			f    func([]*ListNode) *ListNode
+		}{{"heap", mergeKListsHeap}, {"sort", mergeKLists}} {
+			b.Run(fmt.Sprintf("k=%d/%s", k, impl.name), func(b *testing.B) {
+				r := rand.New(rand.NewSource(1))
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					lists := randomLists(r, k, 100)
+					b.StartTimer()
+					impl.f(lists)
+				}
+			})
+		}
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"container/list"
+	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -53,8 +55,24 @@ func TestMergeTrees(t *testing.T) {
 
 }
 
-// 数组转树
-func Array2Tree(bfsArr []*int) (ret *TreeNode) {
+// 数组转树，[] 是空树；根是 null 或者有元素找不到父节点时报错
+func Array2Tree(bfsArr []*int) (ret *TreeNode, err error) {
+	if len(bfsArr) == 0 {
+		return nil, nil
+	}
+	if bfsArr[0] == nil {
+		return nil, fmt.Errorf("根节点不能是 null")
+	}
+	// 第 k 个非空节点的孩子在 2k+1、2k+2，所以第 p 个元素的父节点是第 (p-1)/2 个非空节点
+	seen := 0
+	for p, v := range bfsArr {
+		if p > 0 && (p-1)/2 >= seen {
+			return nil, fmt.Errorf("第 %d 个元素没有父节点", p)
+		}
+		if v != nil {
+			seen++
+		}
+	}
 	var nodes []*TreeNode
 	for _, i := range bfsArr {
 		if i == nil {
@@ -76,7 +94,7 @@ func Array2Tree(bfsArr []*int) (ret *TreeNode) {
 		}
 		idx++
 	}
-	return nodes[0]
+	return nodes[0], nil
 }
 
 func intPtr(val int) *int {
@@ -87,7 +105,7 @@ func intPtr(val int) *int {
 func Tree2Array(root *TreeNode, ret *[]*int) {
 	q := list.New()
 	if root == nil {
-		*ret = append(*ret, nil)
+		// 空树是 []，和 LeetCode 一样；以前输出 [null]，Array2Tree 读回来会报根节点是 null
 		return
 	}
 	q.PushFront(root)
@@ -133,9 +151,54 @@ func TestArray2Tree(t *testing.T) {
 	t.Log(1)
 	//root := Array2Tree([]*int{intPtr(1), intPtr(3), intPtr(2), intPtr(5)})
 
-	root := Array2Tree([]*int{intPtr(1), nil, intPtr(2), nil, intPtr(3)})
+	root, err := Array2Tree([]*int{intPtr(1), nil, intPtr(2), nil, intPtr(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
 	var ret = &[]*int{}
 	Tree2Array(root, ret)
 	t.Log(*ret)
 	t.Log(root)
 }
+
+// go test -fuzz FuzzArray2Tree
+// Array2Tree 不报错时，Tree2Array 要原样打印回来（末尾的 null 除外）
+func FuzzArray2Tree(f *testing.F) {
+	for _, s := range []string{"[]", "[null]", "[1,3,2,5]", "[2,1,3,null,4,null,7]", "[3,4,5,5,4,null,7]",
+		"[1,2,null,3]", "[1,null,2,null,3]"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		var arr []*int
+		if json.Unmarshal([]byte(s), &arr) != nil {
+			return
+		}
+		root, err := Array2Tree(arr)
+		if err != nil {
+			return
+		}
+		for len(arr) > 0 && arr[len(arr)-1] == nil {
+			arr = arr[:len(arr)-1]
+		}
+		out := []*int{}
+		Tree2Array(root, &out)
+		a, _ := json.Marshal(arr)
+		b, _ := json.Marshal(out)
+		if len(arr) > 0 && string(a) != string(b) || len(arr) == 0 && len(out) != 0 {
+			t.Fatalf("%s -> %s", s, b)
+		}
+	})
+}
+
+// 空树输出 []，不是以前的 [null]，这样 Array2Tree 能原样读回来
+func TestTree2ArrayEmpty(t *testing.T) {
+	var out []*int
+	Tree2Array(nil, &out)
+	if len(out) != 0 {
+		b, _ := json.Marshal(out)
+		t.Fatalf("空树输出 %s", b)
+	}
+	if root, err := Array2Tree(out); root != nil || err != nil {
+		t.Fatal(root, err)
+	}
+}
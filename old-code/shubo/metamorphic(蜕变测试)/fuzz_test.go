@@ -0,0 +1,66 @@
+package metamorphic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// go test -fuzz FuzzParseTree *.go
+// 解析成功时：每个非 null 元素都要变成一个节点，打印再解析得到同一个字符串
+func FuzzParseTree(f *testing.F) {
+	for _, s := range []string{"[]", "[1]", "[1,null,2]", "[3,9,20,null,null,15,7]", "[-1,2,3,null,4,null,5,6]",
+		"[null]", "[1,null,null,2]", "1"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		root, err := ParseTree(s)
+		if err != nil {
+			return
+		}
+		var vals []*int
+		json.Unmarshal([]byte(s), &vals)
+		want := 0
+		for _, v := range vals {
+			if v != nil {
+				want++
+			}
+		}
+		if got := len(inorder(root)); got != want {
+			t.Fatalf("%q 有 %d 个节点，解析出 %d 个", s, want, got)
+		}
+		roundTrip(t, s, FormatTree(root), ParseTree, FormatTree)
+	})
+}
+
+func FuzzParseList(f *testing.F) {
+	for _, s := range []string{"[]", "[1,2,3,4,5]", "[1]", "[1,2]", "null", "[1,null]"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if head, err := ParseList(s); err == nil {
+			roundTrip(t, s, FormatList(head), ParseList, FormatList)
+		}
+	})
+}
+
+// 嵌套数组：区间、三元组这类 [][]int
+func FuzzParseMatrix(f *testing.F) {
+	for _, s := range []string{"[]", "[[1,3],[2,6],[8,10],[15,18]]", "[[1,4],[4,5]]", "[[-1,0,1],[-1,-1,2]]", "[[]]", "[[1],null]"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		if m, err := parseJSON[[][]int](s); err == nil {
+			roundTrip(t, s, FormatMatrix(m), parseJSON[[][]int], FormatMatrix)
+		}
+	})
+}
+
+func roundTrip[T any](t *testing.T, in, out string, parse func(string) (T, error), format func(T) string) {
+	again, err := parse(out)
+	if err != nil {
+		t.Fatalf("%q -> %s 解析失败: %v", in, out, err)
+	}
+	if format(again) != out {
+		t.Fatalf("%q -> %s -> %s", in, out, format(again))
+	}
+}
@@ -0,0 +1,320 @@
+package metamorphic
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// 被测的解法，从各目录拷贝过来，只改了函数名，TestCopiesInSync 检查拷贝和原文件是不是还一样
+
+// 从 shubo/invertTree(翻转二叉树)/invertTree_test.go 拷贝过来
+func invertTreeShubo(root *TreeNode) *TreeNode {
+	dfsShubo(root)
+	return root
+}
+
+// 从 shubo/invertTree(翻转二叉树)/invertTree_test.go 拷贝过来
+func dfsShubo(root *TreeNode) {
+	if root == nil {
+		return
+	}
+	root.Left, root.Right = root.Right, root.Left
+	dfsShubo(root.Right)
+	dfsShubo(root.Left)
+	return
+}
+
+// 从 songzhibin97/翻转二叉树/main.go 拷贝过来
+func invertTreeSongzhibin97(root *TreeNode) *TreeNode {
+	var dfs func(root *TreeNode)
+	dfs = func(root *TreeNode) {
+		if root == nil {
+			return
+		}
+		dfs(root.Left)
+		dfs(root.Right)
+		root.Left, root.Right = root.Right, root.Left
+	}
+	dfs(root)
+	return root
+}
+
+// 从 shubo/mergeTrees(合并二叉树)/mergeTrees_test.go 拷贝过来
+func mergeTreesShubo(root1 *TreeNode, root2 *TreeNode) *TreeNode {
+	ret := &TreeNode{}
+	if root1 == nil {
+		return root2
+	}
+	if root2 == nil {
+		return root1
+	}
+	ret.Val = root1.Val + root2.Val
+	ret.Left = mergeTreesShubo(root1.Left, root2.Left)
+	ret.Right = mergeTreesShubo(root1.Right, root2.Right)
+	return ret
+}
+
+// 从 songzhibin97/合并二叉树/main.go 拷贝过来，会修改 root1
+func mergeTreesSongzhibin97(root1 *TreeNode, root2 *TreeNode) *TreeNode {
+	if root1 == nil && root2 == nil {
+		return nil
+	}
+	if root1 == nil {
+		return root2
+	}
+	if root2 == nil {
+		return root1
+	}
+	root1.Val += root2.Val
+	root1.Left = mergeTreesSongzhibin97(root1.Left, root2.Left)
+	root1.Right = mergeTreesSongzhibin97(root1.Right, root2.Right)
+	return root1
+}
+
+// 从 shubo/reverseList(反转链表)/reverseList.go 拷贝过来
+func reverseListShubo(head *ListNode) *ListNode {
+	if head == nil || head.Next == nil {
+		return head
+	}
+	t := reverseListShubo(head.Next)
+	head.Next.Next = head
+	head.Next = nil
+	return t
+}
+
+// 从 songzhibin97/反转链表/main.go 拷贝过来
+func reverseListSongzhibin97(head *ListNode) *ListNode {
+	var dummy *ListNode
+	for head != nil {
+		next := head.Next
+		head.Next = dummy
+		dummy = head
+		head = next
+	}
+	return dummy
+}
+
+// 从 shubo/maxProfit(买卖股票的最佳时机)/maxProfit.go 拷贝过来
+func maxProfitShubo(prices []int) int {
+	ret := 0
+	min := prices[0]
+	for i := 1; i < len(prices); i++ {
+		if prices[i] < min {
+			min = prices[i]
+		} else {
+			ret = max(ret, prices[i]-min)
+		}
+	}
+	return ret
+}
+
+// 从 songzhibin97/买卖股票的最佳时机/main.go 拷贝过来
+func maxProfitSongzhibin97(prices []int) int {
+	res := 0
+	m := prices[0]
+	for i := 1; i < len(prices); i++ {
+		m = min(m, prices[i])
+		res = max(res, prices[i]-m)
+	}
+	return res
+}
+
+// 从 shubo/merge(合并区间)/merge_test.go 拷贝过来
+func mergeShubo(intervals [][]int) [][]int {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i][0] < intervals[j][0]
+	})
+	var ans = [][]int{intervals[0]}
+	for i := 1; i < len(intervals); i++ {
+		if intervals[i][0] <= ans[len(ans)-1][1] {
+			ans[len(ans)-1][1] = max(intervals[i][1], ans[len(ans)-1][1])
+		} else {
+			ans = append(ans, intervals[i])
+		}
+	}
+	return ans
+}
+
+// 从 songzhibin97/合并区间/main.go 拷贝过来
+func mergeSongzhibin97(intervals [][]int) [][]int {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i][0] < intervals[j][0] || intervals[i][0] == intervals[j][0] && intervals[i][1] < intervals[j][1]
+	})
+	res := [][]int{}
+	v := intervals[0]
+	for i := 1; i < len(intervals); i++ {
+		if v[1] >= intervals[i][0] {
+			v[1] = max(v[1], intervals[i][1])
+		} else {
+			res = append(res, v)
+			v = intervals[i]
+		}
+	}
+	res = append(res, v)
+	return res
+}
+
+// 从 shubo/threeSum(三数之和)/threeSum.go 拷贝过来
+func threeSumShubo(nums []int) (ret [][]int) {
+	if len(nums) < 3 {
+		return [][]int{}
+	}
+	sort.Ints(nums)
+	k := 0
+	for ; k < len(nums); k++ {
+		if nums[k] > 0 {
+			break
+		}
+		if k > 0 && nums[k] == nums[k-1] {
+			continue
+		}
+		i := k + 1
+		j := len(nums) - 1
+		for i < j {
+			t := nums[k] + nums[i] + nums[j]
+			if t < 0 {
+				i++
+				for i < j && nums[i] == nums[i-1] {
+					i++
+				}
+			} else if t > 0 {
+				j--
+				for i < j && nums[j] == nums[j+1] {
+					j--
+				}
+			} else {
+				ret = append(ret, []int{nums[k], nums[i], nums[j]})
+				i++
+				j--
+				for i < j && nums[i] == nums[i-1] {
+					i++
+				}
+				for i < j && nums[j] == nums[j+1] {
+					j--
+				}
+			}
+		}
+	}
+	return
+}
+
+// 从 songzhibin97/三数之和/main.go 拷贝过来
+func threeSumSongzhibin97(nums []int) [][]int {
+	res := make([][]int, 0)
+	sort.Ints(nums)
+	for i, num := range nums {
+		if i != 0 && nums[i-1] == num {
+			continue
+		}
+		target := -num
+		left, right := i+1, len(nums)-1
+		for left < right {
+			for left < right && left > i+1 && nums[left] == nums[left-1] {
+				left++
+			}
+			for right > left && right < len(nums)-1 && nums[right] == nums[right+1] {
+				right--
+			}
+			if left >= right {
+				break
+			}
+			vs := nums[left] + nums[right]
+			if vs == target {
+				res = append(res, []int{num, nums[left], nums[right]})
+				left++
+				right--
+				continue
+			}
+			if vs > target {
+				right--
+			} else {
+				left++
+			}
+		}
+	}
+	return res
+}
+
+var copiedFrom = regexp.MustCompile(`^从 (\S+) 拷贝过来`)
+
+// TestCopiesInSync 拷贝和原文件里的函数除了函数名（包括递归调用）不能有别的差别，
+// 原文件改了这里要跟着改，不然测的就不是仓库里的代码了
+func TestCopiesInSync(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "impls_test.go", nil, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 原文件 -> 原函数名 -> 拷贝的函数名，同一个文件里拷过来的辅助函数也要跟着改名
+	type copied struct {
+		fd   *ast.FuncDecl
+		path string
+		name string
+	}
+	var copies []copied
+	renames := map[string]map[string]string{}
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Doc == nil {
+			continue
+		}
+		m := copiedFrom.FindStringSubmatch(fd.Doc.Text())
+		if m == nil {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimSuffix(fd.Name.Name, "Shubo"), "Songzhibin97")
+		copies = append(copies, copied{fd, m[1], name})
+		if renames[m[1]] == nil {
+			renames[m[1]] = map[string]string{}
+		}
+		renames[m[1]][name] = fd.Name.Name
+	}
+	if len(copies) != 13 {
+		t.Fatalf("找到 %d 个拷贝，应该是 13 个", len(copies))
+	}
+	for _, c := range copies {
+		src, err := parser.ParseFile(fset, "../../"+c.path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var orig *ast.FuncDecl
+		for _, d := range src.Decls {
+			if od, ok := d.(*ast.FuncDecl); ok && od.Recv == nil && od.Name.Name == c.name {
+				orig = od
+			}
+		}
+		if orig == nil {
+			t.Errorf("%s 里没有 %s 了", c.path, c.name)
+			continue
+		}
+		ast.Inspect(orig, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && renames[c.path][id.Name] != "" {
+				id.Name = renames[c.path][id.Name]
+			}
+			return true
+		})
+		if got, want := printFunc(fset, c.fd), printFunc(fset, orig); got != want {
+			t.Errorf("%s 和 %s 里的 %s 不一样了\n拷贝：\n%s\n原文件：\n%s", c.fd.Name.Name, c.path, c.name, got, want)
+		}
+	}
+}
+
+// printFunc 不带注释打印函数，空行和注释的差别不算
+func printFunc(fset *token.FileSet, fd *ast.FuncDecl) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, &ast.FuncDecl{Name: fd.Name, Type: fd.Type, Body: fd.Body})
+	var lines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,133 @@
+package metamorphic
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// 蜕变测试：不需要标准答案，只检查解法满足的代数关系
+// 比如翻转二叉树两次等于原树、合并区间是幂等的、三数之和和输入顺序无关。
+// 每道题声明自己的输入类型和若干关系，Check 用随机输入逐条验证，
+// 输入从小到大生成，报告的是最小的反例，输入输出都按 LeetCode 的格式打印。
+
+// Type 一种输入类型：怎么随机生成、怎么按 LeetCode 格式打印和解析
+// 解析(打印(x)) 就是一份深拷贝，每条关系拿到的都是新的输入，解法随便改输入也不会互相影响
+type Type[I any] struct {
+	Gen    func(r *rand.Rand, size int) I
+	Format func(I) string
+	Parse  func(string) (I, error)
+}
+
+func (t Type[I]) clone(in I) I {
+	out, err := t.Parse(t.Format(in))
+	if err != nil {
+		panic(fmt.Sprintf("metamorphic: %s 解析失败: %v", t.Format(in), err))
+	}
+	return out
+}
+
+// Relation 一条关系：对同一个输入算出两个应该相等的结果，都已经打印成字符串
+// Eval 拿到的 in 是一份拷贝，可以直接改
+type Relation[I any] struct {
+	Name string
+	Eval func(r *rand.Rand, in I) (got, want string)
+}
+
+// Identity f(x) == x
+func Identity[I any](t Type[I], name string, f func(I) I) Relation[I] {
+	return Relation[I]{Name: name, Eval: func(r *rand.Rand, in I) (string, string) {
+		return t.Format(f(t.clone(in))), t.Format(in)
+	}}
+}
+
+// Involution f(f(x)) == x
+func Involution[I any](t Type[I], name string, f func(I) I) Relation[I] {
+	return Identity(t, name, func(x I) I { return f(f(x)) })
+}
+
+// Idempotent f(f(x)) == f(x)
+func Idempotent[I any](t Type[I], name string, f func(I) I) Relation[I] {
+	return Relation[I]{Name: name, Eval: func(r *rand.Rand, in I) (string, string) {
+		return t.Format(f(f(t.clone(in)))), t.Format(f(t.clone(in)))
+	}}
+}
+
+// Invariant f(g(x)) == f(x)，g 是不影响答案的变换，比如打乱顺序、整体加一个常数
+func Invariant[I, O any](t Type[I], name string, f func(I) O, out func(O) string, g func(r *rand.Rand, in I) I) Relation[I] {
+	return Relation[I]{Name: name, Eval: func(r *rand.Rand, in I) (string, string) {
+		return out(f(g(r, t.clone(in)))), out(f(t.clone(in)))
+	}}
+}
+
+// Problem 一道题的一份解法和它要满足的关系
+type Problem[I any] struct {
+	ID        string // 题号
+	Name      string // 解法名，比如 shubo invertTree
+	Type      Type[I]
+	MinSize   int // 题目约束里输入的最小规模，比如 prices.length >= 1
+	Relations []Relation[I]
+}
+
+// Suite 不同输入类型的题目放进同一张表里
+type Suite interface {
+	Check(cfg Config) []Failure
+}
+
+type Config struct {
+	Seed    int64
+	MaxSize int // 输入规模 MinSize..MaxSize
+	PerSize int // 每个规模生成几个输入
+}
+
+var DefaultConfig = Config{Seed: 1, MaxSize: 30, PerSize: 20}
+
+type Failure struct {
+	Problem  string
+	Relation string
+	Input    string
+	Got      string
+	Want     string
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s %s\n输入：%s\n期望：%s\n实际：%s", f.Problem, f.Relation, f.Input, f.Want, f.Got)
+}
+
+// Check 每条关系最多报告一个反例：规模最小的那个
+func (p *Problem[I]) Check(cfg Config) []Failure {
+	r := rand.New(rand.NewSource(cfg.Seed))
+	failed := make([]bool, len(p.Relations))
+	var ret []Failure
+	for size := p.MinSize; size <= cfg.MaxSize; size++ {
+		for k := 0; k < cfg.PerSize; k++ {
+			in := p.Type.Gen(r, size)
+			for i, rel := range p.Relations {
+				if failed[i] {
+					continue
+				}
+				got, want := eval(rel, r, p.Type.clone(in))
+				if got != want {
+					failed[i] = true
+					ret = append(ret, Failure{
+						Problem:  p.ID + ". " + p.Name,
+						Relation: rel.Name,
+						Input:    p.Type.Format(in),
+						Got:      got,
+						Want:     want,
+					})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// eval 解法 panic 也算反例
+func eval[I any](rel Relation[I], r *rand.Rand, in I) (got, want string) {
+	defer func() {
+		if e := recover(); e != nil {
+			got, want = fmt.Sprint("panic: ", e), "不 panic"
+		}
+	}()
+	return rel.Eval(r, in)
+}
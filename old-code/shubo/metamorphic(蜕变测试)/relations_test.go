@@ -0,0 +1,212 @@
+package metamorphic
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 每道题的关系只声明一次，传入不同作者的解法
+
+func invertTree(name string, f func(*TreeNode) *TreeNode) Suite {
+	t := Tree(-100, 100)
+	return &Problem[*TreeNode]{ID: "226", Name: name, Type: t, Relations: []Relation[*TreeNode]{
+		Involution(t, "翻转两次等于原树", f),
+		{Name: "翻转后的中序遍历是原来的逆序", Eval: func(r *rand.Rand, in *TreeNode) (string, string) {
+			want := inorder(in)
+			slices.Reverse(want)
+			return FormatInts(inorder(f(in))), FormatInts(want)
+		}},
+	}}
+}
+
+func mergeTrees(name string, f func(*TreeNode, *TreeNode) *TreeNode) Suite {
+	t := Tree(-100, 100)
+	return &Problem[*TreeNode]{ID: "617", Name: name, Type: t, Relations: []Relation[*TreeNode]{
+		Identity(t, "和空树合并不变", func(x *TreeNode) *TreeNode { return f(x, nil) }),
+		Identity(t, "空树和它合并不变", func(x *TreeNode) *TreeNode { return f(nil, x) }),
+		{Name: "交换律", Eval: func(r *rand.Rand, in *TreeNode) (string, string) {
+			other := t.Gen(r, r.Intn(10))
+			return FormatTree(f(t.clone(in), t.clone(other))), FormatTree(f(t.clone(other), in))
+		}},
+	}}
+}
+
+func reverseList(name string, f func(*ListNode) *ListNode) Suite {
+	t := List(-5000, 5000)
+	return &Problem[*ListNode]{ID: "206", Name: name, Type: t, Relations: []Relation[*ListNode]{
+		Involution(t, "反转两次等于原链表", f),
+	}}
+}
+
+func maxProfit(name string, f func([]int) int) Suite {
+	t := Ints(0, 1000)
+	return &Problem[[]int]{ID: "121", Name: name, Type: t, MinSize: 1, Relations: []Relation[[]int]{
+		Invariant(t, "所有价格加同一个数答案不变", f, FormatInt, func(r *rand.Rand, prices []int) []int {
+			c := r.Intn(1000)
+			for i := range prices {
+				prices[i] += c
+			}
+			return prices
+		}),
+		// 倒过来看，低买高卖变成了高价 C-p 的镜像，利润一样
+		Invariant(t, "时间倒流并取 1000-价格答案不变", f, FormatInt, func(r *rand.Rand, prices []int) []int {
+			slices.Reverse(prices)
+			for i := range prices {
+				prices[i] = 1000 - prices[i]
+			}
+			return prices
+		}),
+	}}
+}
+
+func merge(name string, f func([][]int) [][]int) Suite {
+	t := Intervals(30)
+	return &Problem[[][]int]{ID: "56", Name: name, Type: t, MinSize: 1, Relations: []Relation[[][]int]{
+		// 题目不要求输出的区间有序，两边都排好序再比
+		{Name: "合并两次和合并一次一样", Eval: func(r *rand.Rand, in [][]int) (string, string) {
+			return FormatRows(f(f(t.clone(in)))), FormatRows(f(t.clone(in)))
+		}},
+		Invariant(t, "和区间的顺序无关", f, FormatRows, shuffle[[]int]),
+	}}
+}
+
+func threeSum(name string, f func([]int) [][]int) Suite {
+	t := Ints(-10, 10)
+	return &Problem[[]int]{ID: "15", Name: name, Type: t, MinSize: 3, Relations: []Relation[[]int]{
+		// 三元组和三元组里的数都可以按任意顺序输出
+		Invariant(t, "和输入的顺序无关", f, FormatSets, shuffle[int]),
+	}}
+}
+
+func shuffle[T any](r *rand.Rand, s []T) []T {
+	r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	return s
+}
+
+func inorder(root *TreeNode) []int {
+	var ret []int
+	var stack []*TreeNode
+	for root != nil || len(stack) > 0 {
+		for ; root != nil; root = root.Left {
+			stack = append(stack, root)
+		}
+		root = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		ret = append(ret, root.Val)
+		root = root.Right
+	}
+	return ret
+}
+
+var suites = []Suite{
+	invertTree("shubo invertTree", invertTreeShubo),
+	invertTree("songzhibin97 invertTree", invertTreeSongzhibin97),
+	mergeTrees("shubo mergeTrees", mergeTreesShubo),
+	mergeTrees("songzhibin97 mergeTrees", mergeTreesSongzhibin97),
+	reverseList("shubo reverseList", reverseListShubo),
+	reverseList("songzhibin97 reverseList", reverseListSongzhibin97),
+	maxProfit("shubo maxProfit", maxProfitShubo),
+	maxProfit("songzhibin97 maxProfit", maxProfitSongzhibin97),
+	merge("shubo merge", mergeShubo),
+	merge("songzhibin97 merge", mergeSongzhibin97),
+	threeSum("shubo threeSum", threeSumShubo),
+	threeSum("songzhibin97 threeSum", threeSumSongzhibin97),
+}
+
+func TestCorpus(t *testing.T) {
+	for _, s := range suites {
+		for _, f := range s.Check(DefaultConfig) {
+			t.Errorf("\n%s", f)
+		}
+	}
+}
+
+// 故意写错的解法，检查能找到最小的反例，并按 LeetCode 格式报告
+func TestFindsCounterexample(t *testing.T) {
+	// 只翻转了根节点的左右孩子
+	shallow := func(root *TreeNode) *TreeNode {
+		if root != nil {
+			root.Left, root.Right = root.Right, root.Left
+		}
+		return root
+	}
+	fs := invertTree("shallow", shallow).Check(DefaultConfig)
+	if len(fs) != 1 || fs[0].Relation != "翻转后的中序遍历是原来的逆序" {
+		t.Fatal(fs)
+	}
+	// 两次浅翻转能还原，第一条关系抓不到；第二条至少 3 个节点才有反例
+	if root, _ := ParseTree(fs[0].Input); len(inorder(root)) != 3 {
+		t.Fatalf("反例不是最小的: %s", fs[0].Input)
+	}
+	t.Logf("\n%s", fs[0])
+
+	// 价格为空时 panic，没写 MinSize 的话会被当成反例
+	p := maxProfit("shubo maxProfit", maxProfitShubo).(*Problem[[]int])
+	p.MinSize = 0
+	fs = p.Check(DefaultConfig)
+	if len(fs) != 2 || fs[0].Input != "[]" || !strings.HasPrefix(fs[0].Got, "panic: ") {
+		t.Fatal(fs)
+	}
+	t.Logf("\n%s", fs[0])
+
+	// 输出顺序随输入顺序变化的正确解法不能算反例：第一个数是奇数时倒过来输出
+	threeSumAnyOrder := func(nums []int) [][]int {
+		odd := nums[0]%2 != 0
+		ret := threeSumShubo(nums)
+		if odd {
+			slices.Reverse(ret)
+			for _, row := range ret {
+				slices.Reverse(row)
+			}
+		}
+		return ret
+	}
+	if fs := threeSum("threeSumAnyOrder", threeSumAnyOrder).Check(DefaultConfig); len(fs) != 0 {
+		t.Fatal(fs)
+	}
+	mergeAnyOrder := func(intervals [][]int) [][]int {
+		odd := intervals[0][0]%2 != 0
+		ret := mergeShubo(intervals)
+		if odd {
+			slices.Reverse(ret)
+		}
+		return ret
+	}
+	if fs := merge("mergeAnyOrder", mergeAnyOrder).Check(DefaultConfig); len(fs) != 0 {
+		t.Fatal(fs)
+	}
+}
+
+func TestTypes(t *testing.T) {
+	for _, s := range []string{"[]", "[1]", "[1,null,2]", "[3,9,20,null,null,15,7]", "[-1,2,3,null,4,null,5,6]"} {
+		root, err := ParseTree(s)
+		if err != nil || FormatTree(root) != s {
+			t.Fatalf("%s -> %s %v", s, FormatTree(root), err)
+		}
+	}
+	for _, s := range []string{"[null]", "[1,null,null,2]", "1"} {
+		if _, err := ParseTree(s); err == nil {
+			t.Fatalf("%s 应该报错", s)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	tr := Tree(0, 9)
+	for n := 0; n < 50; n++ {
+		root := tr.Gen(r, n)
+		if got := len(inorder(root)); got != n {
+			t.Fatalf("要 %d 个节点，生成了 %d 个", n, got)
+		}
+		if FormatTree(tr.clone(root)) != FormatTree(root) {
+			t.Fatal(FormatTree(root))
+		}
+	}
+	if FormatList(nil) != "[]" || FormatMatrix(nil) != "[]" || FormatSets(nil) != "[]" {
+		t.Fatal("空的答案应该打印成 []")
+	}
+	m := [][]int{{2, -1, -1}, {0, 1, -1}}
+	if FormatRows(m) != "[[0,1,-1],[2,-1,-1]]" || FormatSets(m) != "[[-1,-1,2],[-1,0,1]]" || FormatMatrix(m) != "[[2,-1,-1],[0,1,-1]]" {
+		t.Fatal(FormatRows(m), FormatSets(m), "不能改传入的矩阵")
+	}
+}
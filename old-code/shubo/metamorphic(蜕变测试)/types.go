@@ -0,0 +1,219 @@
+package metamorphic
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// Tree size 个节点的随机二叉树，值在 [lo, hi]
+func Tree(lo, hi int) Type[*TreeNode] {
+	return Type[*TreeNode]{
+		Gen: func(r *rand.Rand, size int) *TreeNode {
+			if size == 0 {
+				return nil
+			}
+			nodes := []*TreeNode{{Val: lo + r.Intn(hi-lo+1)}}
+			for len(nodes) < size {
+				p := nodes[r.Intn(len(nodes))]
+				c := &TreeNode{Val: lo + r.Intn(hi-lo+1)}
+				if p.Left == nil && (p.Right != nil || r.Intn(2) == 0) {
+					p.Left = c
+				} else if p.Right == nil {
+					p.Right = c
+				} else {
+					continue
+				}
+				nodes = append(nodes, c)
+			}
+			return nodes[0]
+		},
+		Format: FormatTree,
+		Parse:  ParseTree,
+	}
+}
+
+// List size 个节点的链表，值在 [lo, hi]
+func List(lo, hi int) Type[*ListNode] {
+	return Type[*ListNode]{
+		Gen: func(r *rand.Rand, size int) *ListNode {
+			dummy := &ListNode{}
+			cur := dummy
+			for i := 0; i < size; i++ {
+				cur.Next = &ListNode{Val: lo + r.Intn(hi-lo+1)}
+				cur = cur.Next
+			}
+			return dummy.Next
+		},
+		Format: FormatList,
+		Parse:  ParseList,
+	}
+}
+
+// Ints 长度为 size 的数组，值在 [lo, hi]
+func Ints(lo, hi int) Type[[]int] {
+	return Type[[]int]{
+		Gen: func(r *rand.Rand, size int) []int {
+			nums := make([]int, size)
+			for i := range nums {
+				nums[i] = lo + r.Intn(hi-lo+1)
+			}
+			return nums
+		},
+		Format: FormatInts,
+		Parse:  parseJSON[[]int],
+	}
+}
+
+// Intervals size 个区间 [start, end]，0 <= start <= end <= hi
+func Intervals(hi int) Type[[][]int] {
+	return Type[[][]int]{
+		Gen: func(r *rand.Rand, size int) [][]int {
+			ret := make([][]int, size)
+			for i := range ret {
+				a, b := r.Intn(hi+1), r.Intn(hi+1)
+				ret[i] = []int{min(a, b), max(a, b)}
+			}
+			return ret
+		},
+		Format: FormatMatrix,
+		Parse:  parseJSON[[][]int],
+	}
+}
+
+// FormatTree LeetCode 的层序格式，去掉末尾的 null
+func FormatTree(root *TreeNode) string {
+	if root == nil {
+		return "[]"
+	}
+	var items []string
+	queue := []*TreeNode{root}
+	for head := 0; head < len(queue); head++ {
+		n := queue[head]
+		if n == nil {
+			items = append(items, "null")
+			continue
+		}
+		items = append(items, strconv.Itoa(n.Val))
+		queue = append(queue, n.Left, n.Right)
+	}
+	for items[len(items)-1] == "null" {
+		items = items[:len(items)-1]
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+var errTree = errors.New("metamorphic: 不是合法的层序二叉树")
+
+func ParseTree(s string) (*TreeNode, error) {
+	var vals []*int
+	if err := json.Unmarshal([]byte(s), &vals); err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	if vals[0] == nil {
+		return nil, errTree
+	}
+	root := &TreeNode{Val: *vals[0]}
+	queue := []*TreeNode{root}
+	head := 0
+	for i := 1; i < len(vals); i++ {
+		if head == len(queue) {
+			return nil, errTree
+		}
+		var n *TreeNode
+		if vals[i] != nil {
+			n = &TreeNode{Val: *vals[i]}
+			queue = append(queue, n)
+		}
+		if i%2 == 1 {
+			queue[head].Left = n
+		} else {
+			queue[head].Right = n
+			head++
+		}
+	}
+	return root, nil
+}
+
+func FormatList(head *ListNode) string {
+	nums := []int{}
+	for ; head != nil; head = head.Next {
+		nums = append(nums, head.Val)
+	}
+	return FormatInts(nums)
+}
+
+func ParseList(s string) (*ListNode, error) {
+	nums, err := parseJSON[[]int](s)
+	if err != nil {
+		return nil, err
+	}
+	dummy := &ListNode{}
+	cur := dummy
+	for _, v := range nums {
+		cur.Next = &ListNode{Val: v}
+		cur = cur.Next
+	}
+	return dummy.Next, nil
+}
+
+// FormatInts / FormatMatrix nil 也打印成 []，和 LeetCode 一致
+func FormatInts(nums []int) string {
+	if nums == nil {
+		nums = []int{}
+	}
+	b, _ := json.Marshal(nums)
+	return string(b)
+}
+
+func FormatMatrix(m [][]int) string {
+	if m == nil {
+		m = [][]int{}
+	}
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// FormatRows 答案里行的顺序无关时用，比如合并区间：按行排好序再打印，不改传入的矩阵
+func FormatRows(m [][]int) string {
+	rows := slices.Clone(m)
+	slices.SortFunc(rows, slices.Compare)
+	return FormatMatrix(rows)
+}
+
+// FormatSets 行的顺序、行内的顺序都无关时用，比如三数之和的三元组
+func FormatSets(m [][]int) string {
+	rows := make([][]int, len(m))
+	for i, row := range m {
+		rows[i] = slices.Sorted(slices.Values(row))
+	}
+	return FormatRows(rows)
+}
+
+// FormatInt 单个数字的答案
+func FormatInt(v int) string {
+	return strconv.Itoa(v)
+}
+
+func parseJSON[T any](s string) (T, error) {
+	var v T
+	err := json.Unmarshal([]byte(s), &v)
+	return v, err
+}
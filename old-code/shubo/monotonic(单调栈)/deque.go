@@ -0,0 +1,93 @@
+package monotonic
+
+import "cmp"
+
+// 单调队列：队尾的规则和单调栈一样，另外可以从队头淘汰过期元素
+// 239. 滑动窗口最大值 用递减队列，队头就是窗口最大值
+
+type Deque[T any] struct {
+	Hooks Hooks[T]
+	stack *Stack[T]
+	head  int // entries[head:] 是队列里的元素，出队只移动 head
+}
+
+func NewDeque[T any](shouldPop func(top, x T) bool) *Deque[T] {
+	return &Deque[T]{stack: New(shouldPop)}
+}
+
+// NewMaxDeque 队头是最大值
+func NewMaxDeque[T cmp.Ordered]() *Deque[T] {
+	return NewDeque(func(top, x T) bool { return top < x })
+}
+
+// NewMinDeque 队头是最小值
+func NewMinDeque[T cmp.Ordered]() *Deque[T] {
+	return NewDeque(func(top, x T) bool { return top > x })
+}
+
+// Push 队尾入队，返回 v 的编号
+func (d *Deque[T]) Push(v T) int {
+	s := d.stack
+	e := Entry[T]{Index: s.next, Value: v}
+	s.next++
+	for len(s.entries) > d.head && s.shouldPop(s.entries[len(s.entries)-1].Value, v) {
+		top := s.entries[len(s.entries)-1]
+		s.entries = s.entries[:len(s.entries)-1]
+		if d.Hooks.OnPop != nil {
+			d.Hooks.OnPop(top, e)
+		}
+		if d.Hooks.OnOp != nil {
+			d.Hooks.OnOp(Op[T]{Kind: "pop", Entry: top, By: e}, s.entries[d.head:])
+		}
+	}
+	prev, ok := d.Back()
+	s.entries = append(s.entries, e)
+	if d.Hooks.OnPush != nil {
+		d.Hooks.OnPush(e, prev, ok)
+	}
+	if d.Hooks.OnOp != nil {
+		d.Hooks.OnOp(Op[T]{Kind: "push", Entry: e}, s.entries[d.head:])
+	}
+	return e.Index
+}
+
+// Expire 淘汰队头所有编号小于 index 的元素，滑动窗口左边界右移时调用
+func (d *Deque[T]) Expire(index int) {
+	s := d.stack
+	for d.head < len(s.entries) && s.entries[d.head].Index < index {
+		e := s.entries[d.head]
+		d.head++
+		if d.Hooks.OnOp != nil {
+			d.Hooks.OnOp(Op[T]{Kind: "expire", Entry: e}, s.entries[d.head:])
+		}
+	}
+	// 出队的元素超过一半时整体前移，避免切片只增不减
+	if d.head > len(s.entries)/2 {
+		n := copy(s.entries, s.entries[d.head:])
+		s.entries = s.entries[:n]
+		d.head = 0
+	}
+}
+
+func (d *Deque[T]) Front() (Entry[T], bool) {
+	if d.Len() == 0 {
+		return Entry[T]{}, false
+	}
+	return d.stack.entries[d.head], true
+}
+
+func (d *Deque[T]) Back() (Entry[T], bool) {
+	if d.Len() == 0 {
+		return Entry[T]{}, false
+	}
+	return d.stack.entries[len(d.stack.entries)-1], true
+}
+
+func (d *Deque[T]) Len() int {
+	return len(d.stack.entries) - d.head
+}
+
+// Entries 队列里的元素，从队头到队尾
+func (d *Deque[T]) Entries() []Entry[T] {
+	return append([]Entry[T](nil), d.stack.entries[d.head:]...)
+}
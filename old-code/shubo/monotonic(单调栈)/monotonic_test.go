@@ -0,0 +1,314 @@
+package monotonic
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// 739. 每日温度：递减栈，被挤出栈的那天遇到了更高的温度
+func dailyTemperatures(temperatures []int) []int {
+	ans := make([]int, len(temperatures))
+	s := NewDecreasing[int]()
+	s.Hooks.OnPop = func(popped, by Entry[int]) {
+		ans[popped.Index] = by.Index - popped.Index
+	}
+	for _, t := range temperatures {
+		s.Push(t)
+	}
+	return ans
+}
+
+// shubo/dailyTemperatures(每日温度)
+func dailyTemperaturesShubo(temperatures []int) []int {
+	var stack []int
+	var ans = make([]int, len(temperatures))
+	for i, t := range temperatures {
+		if len(stack) == 0 || temperatures[stack[len(stack)-1]] > t {
+			stack = append(stack, i)
+			continue
+		} else {
+			for len(stack) > 0 && temperatures[stack[len(stack)-1]] < t {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				ans[top] = i - top
+			}
+			stack = append(stack, i)
+		}
+
+	}
+
+	return ans
+}
+
+// songzhibin97/每日温度
+func dailyTemperaturesSongzhibin97(temperatures []int) []int {
+	res := make([]int, len(temperatures))
+	queue := []int{}
+	for idx, temperature := range temperatures {
+		for len(queue) != 0 && temperatures[queue[len(queue)-1]] < temperature {
+			v := queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+			res[v] = idx - v
+		}
+		queue = append(queue, idx)
+	}
+	return res
+}
+
+func dailyTemperaturesBaoli(temperatures []int) []int {
+	var ans = make([]int, len(temperatures))
+	for i := 0; i < len(temperatures)-1; i++ {
+		for j := i + 1; j < len(temperatures); j++ {
+			if temperatures[i] < temperatures[j] {
+				ans[i] = j - i
+				break
+			}
+		}
+	}
+	return ans
+}
+
+// 42. 接雨水 单调栈解法：按层接水，栈底被挤出时它和左右两边围成一个凹槽
+func trap(height []int) int {
+	ans := 0
+	s := NewDecreasing[int]()
+	s.Hooks.OnPop = func(bottom, right Entry[int]) {
+		left, ok := s.Top()
+		if !ok {
+			return
+		}
+		ans += (min(left.Value, right.Value) - bottom.Value) * (right.Index - left.Index - 1)
+	}
+	for _, h := range height {
+		s.Push(h)
+	}
+	return ans
+}
+
+// 84. 柱状图中最大的矩形：递增栈，被挤出的柱子左右两边第一个更矮的柱子就是矩形的边界
+// 最后补一个高度 0 的哨兵，把栈里剩下的柱子都挤出来
+func largestRectangleArea(heights []int) int {
+	ans := 0
+	s := NewIncreasing[int]()
+	s.Hooks.OnPop = func(popped, by Entry[int]) {
+		left := -1
+		if top, ok := s.Top(); ok {
+			left = top.Index
+		}
+		ans = max(ans, popped.Value*(by.Index-left-1))
+	}
+	for _, h := range heights {
+		s.Push(h)
+	}
+	s.Push(0)
+	return ans
+}
+
+// 85. 最大矩形：每一行往上累计高度，变成 84 题
+func maximalRectangle(matrix [][]byte) int {
+	if len(matrix) == 0 {
+		return 0
+	}
+	heights := make([]int, len(matrix[0]))
+	ans := 0
+	for _, row := range matrix {
+		for j, c := range row {
+			if c == '1' {
+				heights[j]++
+			} else {
+				heights[j] = 0
+			}
+		}
+		ans = max(ans, largestRectangleArea(heights))
+	}
+	return ans
+}
+
+// 239. 滑动窗口最大值：递减队列，队头过期就淘汰
+func maxSlidingWindow(nums []int, k int) []int {
+	d := NewMaxDeque[int]()
+	ans := make([]int, 0, len(nums)-k+1)
+	for i, v := range nums {
+		d.Push(v)
+		d.Expire(i - k + 1)
+		if i >= k-1 {
+			front, _ := d.Front()
+			ans = append(ans, front.Value)
+		}
+	}
+	return ans
+}
+
+func randomInts(r *rand.Rand, n, hi int) []int {
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = r.Intn(hi)
+	}
+	return nums
+}
+
+func TestDailyTemperatures(t *testing.T) {
+	cases := []struct{ in, want []int }{
+		{[]int{73, 74, 75, 71, 69, 72, 76, 73}, []int{1, 1, 4, 2, 1, 1, 0, 0}},
+		{[]int{30, 40, 50, 60}, []int{1, 1, 1, 0}},
+		{[]int{30, 60, 90}, []int{1, 1, 0}},
+		{[]int{73, 73, 73, 73}, []int{0, 0, 0, 0}},
+	}
+	for _, c := range cases {
+		if got := dailyTemperatures(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("%v: %v", c.in, got)
+		}
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		temps := randomInts(r, r.Intn(30), 10)
+		want := dailyTemperaturesBaoli(temps)
+		for name, f := range map[string]func([]int) []int{
+			"monotonic":    dailyTemperatures,
+			"shubo":        dailyTemperaturesShubo,
+			"songzhibin97": dailyTemperaturesSongzhibin97,
+		} {
+			if got := f(temps); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s %v: %v, 期望 %v", name, temps, got, want)
+			}
+		}
+	}
+}
+
+func TestTrap(t *testing.T) {
+	if got := trap([]int{0, 1, 0, 2, 1, 0, 1, 3, 2, 1, 2, 1}); got != 6 {
+		t.Fatal(got)
+	}
+	if got := trap([]int{4, 2, 0, 3, 2, 5}); got != 9 {
+		t.Fatal(got)
+	}
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 500; i++ {
+		height := randomInts(r, r.Intn(20), 6)
+		want := 0
+		for j := range height {
+			l, rr := 0, 0
+			for _, h := range height[:j+1] {
+				l = max(l, h)
+			}
+			for _, h := range height[j:] {
+				rr = max(rr, h)
+			}
+			want += min(l, rr) - height[j]
+		}
+		if got := trap(height); got != want {
+			t.Fatalf("%v: %d, 期望 %d", height, got, want)
+		}
+	}
+}
+
+func TestLargestRectangle(t *testing.T) {
+	if got := largestRectangleArea([]int{2, 1, 5, 6, 2, 3}); got != 10 {
+		t.Fatal(got)
+	}
+	if got := largestRectangleArea([]int{2, 4}); got != 4 {
+		t.Fatal(got)
+	}
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 500; i++ {
+		heights := randomInts(r, r.Intn(15), 5)
+		want := 0
+		for a := range heights {
+			h := heights[a]
+			for b := a; b < len(heights); b++ {
+				h = min(h, heights[b])
+				want = max(want, h*(b-a+1))
+			}
+		}
+		if got := largestRectangleArea(heights); got != want {
+			t.Fatalf("%v: %d, 期望 %d", heights, got, want)
+		}
+	}
+	matrix := [][]byte{
+		[]byte("10100"),
+		[]byte("10111"),
+		[]byte("11111"),
+		[]byte("10010"),
+	}
+	if got := maximalRectangle(matrix); got != 6 {
+		t.Fatal(got)
+	}
+}
+
+func TestMaxSlidingWindow(t *testing.T) {
+	if got := maxSlidingWindow([]int{1, 3, -1, -3, 5, 3, 6, 7}, 3); !reflect.DeepEqual(got, []int{3, 3, 5, 5, 6, 7}) {
+		t.Fatal(got)
+	}
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 500; i++ {
+		nums := randomInts(r, 1+r.Intn(30), 10)
+		k := 1 + r.Intn(len(nums))
+		var want []int
+		for j := 0; j+k <= len(nums); j++ {
+			m := nums[j]
+			for _, v := range nums[j : j+k] {
+				m = max(m, v)
+			}
+			want = append(want, m)
+		}
+		if got := maxSlidingWindow(nums, k); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%v k=%d: %v, 期望 %v", nums, k, got, want)
+		}
+	}
+}
+
+// 上一个更小元素：递增栈里，新元素入栈时压在它下面的就是
+func TestPreviousSmaller(t *testing.T) {
+	nums := []int{3, 7, 8, 4, 1, 5}
+	prev := make([]int, len(nums))
+	s := New(func(top, x int) bool { return top >= x })
+	s.Hooks.OnPush = func(pushed, below Entry[int], ok bool) {
+		prev[pushed.Index] = -1
+		if ok {
+			prev[pushed.Index] = below.Value
+		}
+	}
+	for _, v := range nums {
+		s.Push(v)
+	}
+	if !reflect.DeepEqual(prev, []int{-1, 3, 7, 3, -1, 1}) {
+		t.Fatal(prev)
+	}
+}
+
+// trace 系统按 OnOp 的顺序画动画，这里检查操作序列和每一步的栈
+func TestOps(t *testing.T) {
+	var ops []string
+	var stacks [][]int
+	s := NewDecreasing[int]()
+	s.Hooks.OnOp = func(op Op[int], entries []Entry[int]) {
+		ops = append(ops, op.Kind)
+		var idx []int
+		for _, e := range entries {
+			idx = append(idx, e.Index)
+		}
+		stacks = append(stacks, idx)
+	}
+	for _, v := range []int{73, 74, 75, 71, 69, 72} {
+		s.Push(v)
+	}
+	wantOps := []string{"push", "pop", "push", "pop", "push", "push", "push", "pop", "pop", "push"}
+	if !reflect.DeepEqual(ops, wantOps) {
+		t.Fatal(ops)
+	}
+	if got := stacks[len(stacks)-1]; !reflect.DeepEqual(got, []int{2, 5}) {
+		t.Fatal(got)
+	}
+
+	ops = nil
+	d := NewMaxDeque[int]()
+	d.Hooks.OnOp = func(op Op[int], _ []Entry[int]) { ops = append(ops, op.Kind) }
+	for i, v := range []int{5, 4, 3} {
+		d.Push(v)
+		d.Expire(i - 1)
+	}
+	if !reflect.DeepEqual(ops, []string{"push", "push", "push", "expire"}) {
+		t.Fatal(ops)
+	}
+}
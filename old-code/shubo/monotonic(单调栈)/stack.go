@@ -0,0 +1,94 @@
+package monotonic
+
+import "cmp"
+
+// 单调栈
+// 新元素入栈前，把栈顶所有 shouldPop(top, x) 为 true 的元素弹出：
+//   - 递减栈（pop 条件 top < x）：被弹出的元素遇到了"下一个更大元素" x，
+//     x 入栈后压在它下面的元素是它的"上一个大于等于它的元素"
+//   - 递增栈（pop 条件 top > x）：对称地得到"下一个更小"和"上一个小于等于"
+// 元素按 Push 的顺序自动编号，回调里拿到的都是 Entry，下标和值都有。
+
+type Entry[T any] struct {
+	Index int
+	Value T
+}
+
+// Op 一次栈操作，trace 系统用它画入栈、出栈动画
+type Op[T any] struct {
+	Kind  string   // push、pop、expire（只有 Deque 有）
+	Entry Entry[T] // 入栈或出栈的元素
+	By    Entry[T] // pop 时是触发出栈的新元素
+}
+
+// Hooks 都是可选的
+type Hooks[T any] struct {
+	// OnPop 元素被 by 挤出栈，此时 popped 已经不在栈里，Top 是它下面的元素
+	OnPop func(popped, by Entry[T])
+	// OnPush 元素入栈，prev 是压在它下面的元素，栈里只有它时 ok 为 false
+	OnPush func(pushed, prev Entry[T], ok bool)
+	// OnOp 每次操作之后调用，entries 是操作后的栈，从栈底到栈顶，不要修改
+	OnOp func(op Op[T], entries []Entry[T])
+}
+
+type Stack[T any] struct {
+	Hooks     Hooks[T]
+	entries   []Entry[T]
+	shouldPop func(top, x T) bool
+	next      int
+}
+
+func New[T any](shouldPop func(top, x T) bool) *Stack[T] {
+	return &Stack[T]{shouldPop: shouldPop}
+}
+
+// NewDecreasing 栈底到栈顶严格递减，用来找下一个更大元素
+func NewDecreasing[T cmp.Ordered]() *Stack[T] {
+	return New(func(top, x T) bool { return top < x })
+}
+
+// NewIncreasing 栈底到栈顶严格递增，用来找下一个更小元素
+func NewIncreasing[T cmp.Ordered]() *Stack[T] {
+	return New(func(top, x T) bool { return top > x })
+}
+
+// Push 弹出所有该弹出的元素，再把 v 入栈，返回 v 的编号
+func (s *Stack[T]) Push(v T) int {
+	e := Entry[T]{Index: s.next, Value: v}
+	s.next++
+	for len(s.entries) > 0 && s.shouldPop(s.entries[len(s.entries)-1].Value, v) {
+		top := s.entries[len(s.entries)-1]
+		s.entries = s.entries[:len(s.entries)-1]
+		if s.Hooks.OnPop != nil {
+			s.Hooks.OnPop(top, e)
+		}
+		if s.Hooks.OnOp != nil {
+			s.Hooks.OnOp(Op[T]{Kind: "pop", Entry: top, By: e}, s.entries)
+		}
+	}
+	prev, ok := s.Top()
+	s.entries = append(s.entries, e)
+	if s.Hooks.OnPush != nil {
+		s.Hooks.OnPush(e, prev, ok)
+	}
+	if s.Hooks.OnOp != nil {
+		s.Hooks.OnOp(Op[T]{Kind: "push", Entry: e}, s.entries)
+	}
+	return e.Index
+}
+
+func (s *Stack[T]) Top() (Entry[T], bool) {
+	if len(s.entries) == 0 {
+		return Entry[T]{}, false
+	}
+	return s.entries[len(s.entries)-1], true
+}
+
+func (s *Stack[T]) Len() int {
+	return len(s.entries)
+}
+
+// Entries 栈里的元素，从栈底到栈顶
+func (s *Stack[T]) Entries() []Entry[T] {
+	return append([]Entry[T](nil), s.entries...)
+}
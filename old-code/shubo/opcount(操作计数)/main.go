@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+)
+
+// 对比两种 mergeKLists 的工作量，输出 JSON 给网站展示
+// go run main.go opcount.go mergeKLists.go -k 8 -n 1000
+func main() {
+	k := flag.Int("k", 8, "链表条数")
+	n := flag.Int("n", 1000, "节点总数")
+	seed := flag.Int64("seed", 1, "随机种子，同样的种子计数一样")
+	flag.Parse()
+
+	ret := map[string]Counter{}
+	for name, f := range map[string]func(*Counter, []*ListNode) *ListNode{
+		"sort": mergeKListsSort,
+		"heap": mergeKListsHeap,
+	} {
+		var c Counter
+		f(&c, randomLists(rand.New(rand.NewSource(*seed)), *k, *n))
+		ret[name] = c
+	}
+	b, _ := json.MarshalIndent(ret, "", "  ")
+	fmt.Println(string(b))
+}
+
+// randomLists 生成 k 条升序链表，一共 n 个节点
+func randomLists(r *rand.Rand, k, n int) []*ListNode {
+	vals := make([][]int, k)
+	for i := 0; i < n; i++ {
+		j := r.Intn(k)
+		vals[j] = append(vals[j], r.Intn(10*n))
+	}
+	lists := make([]*ListNode, k)
+	for i, vs := range vals {
+		sortInts(vs)
+		dummy := &ListNode{}
+		cur := dummy
+		for _, v := range vs {
+			cur.Next = &ListNode{Val: v}
+			cur = cur.Next
+		}
+		lists[i] = dummy.Next
+	}
+	return lists
+}
+
+func sortInts(a []int) {
+	for i := 1; i < len(a); i++ {
+		for j := i; j > 0 && a[j] < a[j-1]; j-- {
+			a[j], a[j-1] = a[j-1], a[j]
+		}
+	}
+}
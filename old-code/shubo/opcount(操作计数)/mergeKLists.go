@@ -0,0 +1,88 @@
+package main
+
+import "sort"
+
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// 23. 合并K个升序链表 的两种写法，N 个节点、k 条链表
+
+// 全部节点放进数组再排序，和 shubo 的 mergeKLists 一样，比较次数 ~ N log N
+func mergeKListsSort(c *Counter, lists []*ListNode) *ListNode {
+	l := NewSlice[*ListNode](c, 0)
+	for i := 0; i < len(lists); i++ {
+		for cur := lists[i]; cur != nil; cur = Deref(c, cur).Next {
+			l.Append(cur)
+		}
+	}
+	sort.Slice(l.Raw(), func(i, j int) bool {
+		return Less(c, Deref(c, l.Get(i)).Val, Deref(c, l.Get(j)).Val)
+	})
+	if l.Len() == 0 {
+		return nil
+	}
+	for i := 0; i < l.Len()-1; i++ {
+		Deref(c, l.Get(i)).Next = l.Get(i + 1)
+	}
+	Deref(c, l.Get(l.Len()-1)).Next = nil
+	return l.Get(0)
+}
+
+// 小顶堆里只放每条链表的当前头结点，比较次数 ~ N log k
+func mergeKListsHeap(c *Counter, lists []*ListNode) *ListNode {
+	h := NewSlice[*ListNode](c, 0)
+	less := func(i, j int) bool {
+		return Less(c, Deref(c, h.Get(i)).Val, Deref(c, h.Get(j)).Val)
+	}
+	up := func(i int) {
+		for i > 0 {
+			p := (i - 1) / 2
+			if !less(i, p) {
+				return
+			}
+			h.Swap(i, p)
+			i = p
+		}
+	}
+	down := func(i int) {
+		for {
+			m := i
+			if l := 2*i + 1; l < h.Len() && less(l, m) {
+				m = l
+			}
+			if r := 2*i + 2; r < h.Len() && less(r, m) {
+				m = r
+			}
+			if m == i {
+				return
+			}
+			h.Swap(i, m)
+			i = m
+		}
+	}
+	for _, head := range lists {
+		if head != nil {
+			h.Append(head)
+			up(h.Len() - 1)
+		}
+	}
+	dummy := New(c, ListNode{})
+	tail := dummy
+	for h.Len() > 0 {
+		node := h.Get(0)
+		tail.Next = node
+		tail = node
+		if next := Deref(c, node).Next; next != nil {
+			h.Set(0, next)
+		} else {
+			last := h.Pop()
+			if h.Len() > 0 {
+				h.Set(0, last)
+			}
+		}
+		down(0)
+	}
+	return dummy.Next
+}
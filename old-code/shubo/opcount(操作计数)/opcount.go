@@ -0,0 +1,120 @@
+package main
+
+import "cmp"
+
+// 操作计数：用工作量而不是耗时比较算法
+// 题解里的切片、map、节点指针换成这里的包装，跑一遍就能拿到读、写、比较、分配的次数。
+// 同样的输入计数总是一样的，可以直接在测试里断言，也可以放到网站上展示。
+
+type Counter struct {
+	Reads    int `json:"reads"`
+	Writes   int `json:"writes"`
+	Compares int `json:"compares"`
+	Allocs   int `json:"allocs"`
+}
+
+func (c *Counter) Reset() { *c = Counter{} }
+
+func Less[T cmp.Ordered](c *Counter, a, b T) bool {
+	c.Compares++
+	return a < b
+}
+
+func Compare[T cmp.Ordered](c *Counter, a, b T) int {
+	c.Compares++
+	return cmp.Compare(a, b)
+}
+
+// Deref 每解引用一次节点指针算一次读，如 Deref(c, node).Next
+func Deref[T any](c *Counter, p *T) *T {
+	c.Reads++
+	return p
+}
+
+// New 新建节点算一次分配
+func New[T any](c *Counter, v T) *T {
+	c.Allocs++
+	return &v
+}
+
+type Slice[T any] struct {
+	c    *Counter
+	data []T
+}
+
+// NewSlice make([]T, n)，算一次分配
+func NewSlice[T any](c *Counter, n int) *Slice[T] {
+	c.Allocs++
+	return &Slice[T]{c: c, data: make([]T, n)}
+}
+
+// WrapSlice 包装题目给的输入，不算分配
+func WrapSlice[T any](c *Counter, data []T) *Slice[T] {
+	return &Slice[T]{c: c, data: data}
+}
+
+func (s *Slice[T]) Len() int { return len(s.data) }
+
+func (s *Slice[T]) Get(i int) T {
+	s.c.Reads++
+	return s.data[i]
+}
+
+func (s *Slice[T]) Set(i int, v T) {
+	s.c.Writes++
+	s.data[i] = v
+}
+
+func (s *Slice[T]) Swap(i, j int) {
+	s.c.Reads += 2
+	s.c.Writes += 2
+	s.data[i], s.data[j] = s.data[j], s.data[i]
+}
+
+// Append 扩容时算一次分配
+func (s *Slice[T]) Append(v T) {
+	if len(s.data) == cap(s.data) {
+		s.c.Allocs++
+	}
+	s.c.Writes++
+	s.data = append(s.data, v)
+}
+
+// Pop 删除并返回最后一个元素
+func (s *Slice[T]) Pop() T {
+	s.c.Reads++
+	v := s.data[len(s.data)-1]
+	s.data = s.data[:len(s.data)-1]
+	return v
+}
+
+// Raw 拿到底层切片，只在计数结束后取结果用
+func (s *Slice[T]) Raw() []T { return s.data }
+
+type Map[K comparable, V any] struct {
+	c *Counter
+	m map[K]V
+}
+
+func NewMap[K comparable, V any](c *Counter) *Map[K, V] {
+	c.Allocs++
+	return &Map[K, V]{c: c, m: map[K]V{}}
+}
+
+func (m *Map[K, V]) Len() int { return len(m.m) }
+
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	m.c.Reads++
+	v, ok := m.m[k]
+	return v, ok
+}
+
+func (m *Map[K, V]) Set(k K, v V) {
+	m.c.Writes++
+	m.m[k] = v
+}
+
+func (m *Map[K, V]) Delete(k K) {
+	m.c.Writes++
+	delete(m.m, k)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	var c Counter
+	s := NewSlice[int](&c, 0)
+	for i := 0; i < 5; i++ {
+		s.Append(i)
+	}
+	s.Swap(0, 4)
+	if s.Get(0) != 4 || s.Pop() != 0 {
+		t.Fatal(s.Raw())
+	}
+	// 0 -> 1 -> 2 -> 4 -> 8 扩容 4 次，加上 NewSlice 一次
+	want := Counter{Reads: 4, Writes: 7, Allocs: 5}
+	if c != want {
+		t.Fatalf("%+v != %+v", c, want)
+	}
+}
+
+func TestMap(t *testing.T) {
+	var c Counter
+	m := NewMap[string, int](&c)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Fatal(v, ok)
+	}
+	m.Delete("a")
+	if _, ok := m.Get("a"); ok || m.Len() != 1 {
+		t.Fatal("a 应该被删掉了")
+	}
+	want := Counter{Reads: 2, Writes: 3, Allocs: 1}
+	if c != want {
+		t.Fatalf("%+v != %+v", c, want)
+	}
+}
+
+func toSlice(head *ListNode) []int {
+	var ret []int
+	for ; head != nil; head = head.Next {
+		ret = append(ret, head.Val)
+	}
+	return ret
+}
+
+func TestMergeKLists(t *testing.T) {
+	const k, n = 8, 4096
+	var cs, ch Counter
+	a := toSlice(mergeKListsSort(&cs, randomLists(rand.New(rand.NewSource(1)), k, n)))
+	b := toSlice(mergeKListsHeap(&ch, randomLists(rand.New(rand.NewSource(1)), k, n)))
+	if len(a) != n || len(b) != n {
+		t.Fatal(len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] || (i > 0 && a[i] < a[i-1]) {
+			t.Fatalf("第 %d 个节点不一致: %d %d", i, a[i], b[i])
+		}
+	}
+	// 堆的比较次数不超过 2 N log k，排序的比较次数至少是 N log N 的量级
+	if float64(ch.Compares) > 2*n*math.Log2(k) {
+		t.Fatalf("heap compares = %d", ch.Compares)
+	}
+	if float64(cs.Compares) < n*math.Log2(n)/2 || cs.Compares < 2*ch.Compares {
+		t.Fatalf("sort compares = %d", cs.Compares)
+	}
+	t.Logf("sort %+v", cs)
+	t.Logf("heap %+v", ch)
+}
+
+// 同样的输入，计数必须完全一样
+func TestDeterministic(t *testing.T) {
+	for _, f := range []func(*Counter, []*ListNode) *ListNode{mergeKListsSort, mergeKListsHeap} {
+		var c1, c2 Counter
+		f(&c1, randomLists(rand.New(rand.NewSource(7)), 5, 500))
+		f(&c2, randomLists(rand.New(rand.NewSource(7)), 5, 500))
+		if c1 != c2 {
+			t.Fatalf("%+v != %+v", c1, c2)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	var c Counter
+	if mergeKListsSort(&c, nil) != nil || mergeKListsHeap(&c, []*ListNode{nil, nil}) != nil {
+		t.Fatal("空链表应该返回 nil")
+	}
+}
@@ -0,0 +1,167 @@
+package pq
+
+// 泛型二叉堆，less(a, b) 为 true 时 a 先出队
+// Push 返回句柄，可以用 Update 改优先级（decrease-key）或者 Remove 删除任意元素；
+// NewTopK 创建有界堆，只保留 less 意义下最"大"的 k 个元素，堆顶是其中最小的那个。
+
+type Heap[T any] struct {
+	items []*Handle[T]
+	less  func(a, b T) bool
+	limit int // 0 表示不限
+}
+
+// Handle 指向堆里的一个元素，出堆后 index 变成 -1
+type Handle[T any] struct {
+	Value T
+	index int
+}
+
+// InHeap 元素是否还在堆里
+func (x *Handle[T]) InHeap() bool {
+	return x != nil && x.index >= 0
+}
+
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// From 用已有的元素 O(n) 建堆
+func From[T any](values []T, less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{less: less, items: make([]*Handle[T], len(values))}
+	for i, v := range values {
+		h.items[i] = &Handle[T]{Value: v, index: i}
+	}
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// NewTopK 最多保留 k 个元素，满了以后新元素比堆顶大才替换堆顶
+func NewTopK[T any](k int, less func(a, b T) bool) *Heap[T] {
+	if k <= 0 {
+		panic("pq: k 必须大于 0")
+	}
+	return &Heap[T]{less: less, limit: k}
+}
+
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push 入堆，有界堆满了并且 v 不比堆顶大时丢弃 v，返回 nil
+func (h *Heap[T]) Push(v T) *Handle[T] {
+	if h.limit > 0 && len(h.items) == h.limit {
+		if !h.less(h.items[0].Value, v) {
+			return nil
+		}
+		h.Pop()
+	}
+	x := &Handle[T]{Value: v, index: len(h.items)}
+	h.items = append(h.items, x)
+	h.up(x.index)
+	return x
+}
+
+// Peek 堆顶，堆为空时 panic，调用前先判断 Len
+func (h *Heap[T]) Peek() T {
+	return h.items[0].Value
+}
+
+func (h *Heap[T]) Pop() T {
+	return h.remove(0)
+}
+
+// Remove 删除句柄对应的元素
+func (h *Heap[T]) Remove(x *Handle[T]) T {
+	if !x.InHeap() {
+		panic("pq: 元素已经不在堆里")
+	}
+	return h.remove(x.index)
+}
+
+// Update 修改优先级，变大变小都可以
+func (h *Heap[T]) Update(x *Handle[T], v T) {
+	if !x.InHeap() {
+		panic("pq: 元素已经不在堆里")
+	}
+	x.Value = v
+	h.fix(x.index)
+}
+
+// Values 堆里的元素，顺序是堆的数组顺序
+func (h *Heap[T]) Values() []T {
+	ret := make([]T, len(h.items))
+	for i, x := range h.items {
+		ret[i] = x.Value
+	}
+	return ret
+}
+
+// Sorted 按出队顺序返回所有元素，不修改堆
+func (h *Heap[T]) Sorted() []T {
+	c := From(h.Values(), h.less)
+	ret := make([]T, 0, c.Len())
+	for c.Len() > 0 {
+		ret = append(ret, c.Pop())
+	}
+	return ret
+}
+
+func (h *Heap[T]) remove(i int) T {
+	x := h.items[i]
+	last := len(h.items) - 1
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i != last {
+		h.fix(i)
+	}
+	x.index = -1
+	return x.Value
+}
+
+func (h *Heap[T]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !h.less(h.items[i].Value, h.items[p].Value) {
+			return
+		}
+		h.swap(i, p)
+		i = p
+	}
+}
+
+// down 下沉，返回是否移动过
+func (h *Heap[T]) down(i int) bool {
+	start, n := i, len(h.items)
+	for {
+		c := 2*i + 1
+		if c >= n {
+			break
+		}
+		if c+1 < n && h.less(h.items[c+1].Value, h.items[c].Value) {
+			c++
+		}
+		if !h.less(h.items[c].Value, h.items[i].Value) {
+			break
+		}
+		h.swap(i, c)
+		i = c
+	}
+	return i > start
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
@@ -0,0 +1,124 @@
+package pq
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func intLess(a, b int) bool { return a < b }
+
+func TestPushPop(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 50; n++ {
+		nums := r.Perm(n)
+		h := New(intLess)
+		for _, v := range nums {
+			h.Push(v)
+		}
+		var got []int
+		for h.Len() > 0 {
+			got = append(got, h.Pop())
+		}
+		sort.Ints(nums)
+		if len(nums) > 0 && !reflect.DeepEqual(got, nums) {
+			t.Fatalf("n=%d 出队顺序 %v", n, got)
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	nums := []int{5, 2, 8, 1, 9, 3}
+	h := From(nums, func(a, b int) bool { return a > b })
+	if got := h.Sorted(); !reflect.DeepEqual(got, []int{9, 8, 5, 3, 2, 1}) {
+		t.Fatal(got)
+	}
+	if h.Len() != len(nums) || h.Peek() != 9 {
+		t.Fatal("Sorted 不应该修改堆")
+	}
+}
+
+// 随机 Push/Pop/Update/Remove，和排序后的切片对拍
+func TestHandles(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	h := New(intLess)
+	var live []*Handle[int]
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op == 0 || len(live) == 0:
+			live = append(live, h.Push(r.Intn(100)))
+		case op == 1:
+			j := r.Intn(len(live))
+			h.Update(live[j], r.Intn(100))
+		case op == 2:
+			j := r.Intn(len(live))
+			h.Remove(live[j])
+			if live[j].InHeap() {
+				t.Fatal("Remove 之后句柄应该失效")
+			}
+			live = append(live[:j], live[j+1:]...)
+		default:
+			v := h.Pop()
+			for j, x := range live {
+				if !x.InHeap() {
+					if x.Value != v {
+						t.Fatalf("Pop 返回 %d，出堆的句柄是 %d", v, x.Value)
+					}
+					live = append(live[:j], live[j+1:]...)
+					break
+				}
+			}
+		}
+		want := make([]int, len(live))
+		for j, x := range live {
+			want[j] = x.Value
+		}
+		sort.Ints(want)
+		if got := h.Sorted(); len(got) != len(want) || (len(got) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Fatalf("第 %d 步 %v，期望 %v", i, got, want)
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	h := NewTopK(3, intLess)
+	for _, v := range []int{4, 1, 7, 3, 8, 5, 8} {
+		h.Push(v)
+	}
+	if got := h.Sorted(); !reflect.DeepEqual(got, []int{7, 8, 8}) {
+		t.Fatal(got)
+	}
+	if h.Push(2) != nil {
+		t.Fatal("比堆顶小的元素应该被丢弃")
+	}
+}
+
+// decrease-key 的典型用法：Dijkstra
+func TestDijkstra(t *testing.T) {
+	type edge struct{ to, w int }
+	g := [][]edge{
+		{{1, 4}, {2, 1}},
+		{{3, 1}},
+		{{1, 2}, {3, 5}},
+		{},
+	}
+	dist := []int{0, 1 << 30, 1 << 30, 1 << 30}
+	h := New(func(a, b int) bool { return dist[a] < dist[b] })
+	handles := make([]*Handle[int], len(g))
+	for i := range g {
+		handles[i] = h.Push(i)
+	}
+	for h.Len() > 0 {
+		u := h.Pop()
+		for _, e := range g[u] {
+			if d := dist[u] + e.w; d < dist[e.to] {
+				dist[e.to] = d
+				h.Update(handles[e.to], e.to)
+			}
+		}
+	}
+	if !reflect.DeepEqual(dist, []int{0, 3, 1, 4}) {
+		t.Fatal(dist)
+	}
+}
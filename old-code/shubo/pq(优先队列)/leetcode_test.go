@@ -0,0 +1,292 @@
+package pq
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// 用 Heap 写的 hot 100 堆题，每道题旁边是基于排序的写法，用来对拍和做基准
+// 这几道题题解目录里都没有；23. 合并K个升序链表有，堆的写法放在 mergeKLists(合并K个升序链表) 里，用生成的 heap.go
+
+// 215. 数组中的第K个最大元素：大小为 k 的有界堆，堆顶就是答案
+func findKthLargest(nums []int, k int) int {
+	h := NewTopK(k, intLess)
+	for _, v := range nums {
+		h.Push(v)
+	}
+	return h.Peek()
+}
+
+func findKthLargestSort(nums []int, k int) int {
+	s := append([]int{}, nums...)
+	sort.Ints(s)
+	return s[len(s)-k]
+}
+
+// 347. 前 K 个高频元素：按次数比较的有界堆
+func topKFrequent(nums []int, k int) []int {
+	count := map[int]int{}
+	for _, v := range nums {
+		count[v]++
+	}
+	h := NewTopK(k, func(a, b int) bool { return count[a] < count[b] })
+	for v := range count {
+		h.Push(v)
+	}
+	return h.Values()
+}
+
+func topKFrequentSort(nums []int, k int) []int {
+	count := map[int]int{}
+	for _, v := range nums {
+		count[v]++
+	}
+	keys := make([]int, 0, len(count))
+	for v := range count {
+		keys = append(keys, v)
+	}
+	sort.Slice(keys, func(i, j int) bool { return count[keys[i]] > count[keys[j]] })
+	return keys[:k]
+}
+
+// 295. 数据流的中位数：low 是大顶堆存较小的一半，high 是小顶堆存较大的一半
+type MedianFinder struct {
+	low, high *Heap[int]
+}
+
+func NewMedianFinder() MedianFinder {
+	return MedianFinder{
+		low:  New(func(a, b int) bool { return a > b }),
+		high: New(intLess),
+	}
+}
+
+func (this *MedianFinder) AddNum(num int) {
+	if this.low.Len() == 0 || num <= this.low.Peek() {
+		this.low.Push(num)
+	} else {
+		this.high.Push(num)
+	}
+	// 保持 low 比 high 多 0 或 1 个
+	if this.low.Len() > this.high.Len()+1 {
+		this.high.Push(this.low.Pop())
+	} else if this.high.Len() > this.low.Len() {
+		this.low.Push(this.high.Pop())
+	}
+}
+
+func (this *MedianFinder) FindMedian() float64 {
+	if this.low.Len() > this.high.Len() {
+		return float64(this.low.Peek())
+	}
+	return float64(this.low.Peek()+this.high.Peek()) / 2
+}
+
+// 排序写法：维护有序切片，二分找位置插入
+type MedianFinderSort struct {
+	nums []int
+}
+
+func (this *MedianFinderSort) AddNum(num int) {
+	i := sort.SearchInts(this.nums, num)
+	this.nums = append(this.nums, 0)
+	copy(this.nums[i+1:], this.nums[i:])
+	this.nums[i] = num
+}
+
+func (this *MedianFinderSort) FindMedian() float64 {
+	n := len(this.nums)
+	if n%2 == 1 {
+		return float64(this.nums[n/2])
+	}
+	return float64(this.nums[n/2-1]+this.nums[n/2]) / 2
+}
+
+// 239. 滑动窗口最大值（堆版本）：用句柄把滑出窗口的元素直接删掉，堆里始终只有 k 个元素
+func maxSlidingWindow(nums []int, k int) []int {
+	h := New(func(a, b int) bool { return nums[a] > nums[b] })
+	handles := make([]*Handle[int], len(nums))
+	ans := make([]int, 0, len(nums)-k+1)
+	for i := range nums {
+		handles[i] = h.Push(i)
+		if i >= k {
+			h.Remove(handles[i-k])
+		}
+		if i >= k-1 {
+			ans = append(ans, nums[h.Peek()])
+		}
+	}
+	return ans
+}
+
+// 排序写法：每个窗口排一次序
+func maxSlidingWindowSort(nums []int, k int) []int {
+	ans := make([]int, 0, len(nums)-k+1)
+	window := make([]int, k)
+	for i := 0; i+k <= len(nums); i++ {
+		copy(window, nums[i:i+k])
+		sort.Ints(window)
+		ans = append(ans, window[k-1])
+	}
+	return ans
+}
+
+func TestFindKthLargest(t *testing.T) {
+	if got := findKthLargest([]int{3, 2, 1, 5, 6, 4}, 2); got != 5 {
+		t.Fatal(got)
+	}
+	if got := findKthLargest([]int{3, 2, 3, 1, 2, 4, 5, 5, 6}, 4); got != 4 {
+		t.Fatal(got)
+	}
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 100; i++ {
+		nums := make([]int, 1+r.Intn(50))
+		for j := range nums {
+			nums[j] = r.Intn(20) - 10
+		}
+		k := 1 + r.Intn(len(nums))
+		if a, b := findKthLargest(nums, k), findKthLargestSort(nums, k); a != b {
+			t.Fatalf("%v k=%d: %d != %d", nums, k, a, b)
+		}
+	}
+}
+
+func TestTopKFrequent(t *testing.T) {
+	got := topKFrequent([]int{1, 1, 1, 2, 2, 3}, 2)
+	sort.Ints(got)
+	if !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatal(got)
+	}
+	if got := topKFrequent([]int{1}, 1); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatal(got)
+	}
+}
+
+func TestMedianFinder(t *testing.T) {
+	m := NewMedianFinder()
+	m.AddNum(1)
+	m.AddNum(2)
+	if m.FindMedian() != 1.5 {
+		t.Fatal(m.FindMedian())
+	}
+	m.AddNum(3)
+	if m.FindMedian() != 2 {
+		t.Fatal(m.FindMedian())
+	}
+	r := rand.New(rand.NewSource(4))
+	m = NewMedianFinder()
+	s := &MedianFinderSort{}
+	for i := 0; i < 1000; i++ {
+		v := r.Intn(200) - 100
+		m.AddNum(v)
+		s.AddNum(v)
+		if m.FindMedian() != s.FindMedian() {
+			t.Fatalf("第 %d 个数后 %v != %v", i, m.FindMedian(), s.FindMedian())
+		}
+	}
+}
+
+func TestMaxSlidingWindow(t *testing.T) {
+	if got := maxSlidingWindow([]int{1, 3, -1, -3, 5, 3, 6, 7}, 3); !reflect.DeepEqual(got, []int{3, 3, 5, 5, 6, 7}) {
+		t.Fatal(got)
+	}
+	if got := maxSlidingWindow([]int{1}, 1); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatal(got)
+	}
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 100; i++ {
+		nums := make([]int, 1+r.Intn(30))
+		for j := range nums {
+			nums[j] = r.Intn(10)
+		}
+		k := 1 + r.Intn(len(nums))
+		if a, b := maxSlidingWindow(nums, k), maxSlidingWindowSort(nums, k); !reflect.DeepEqual(a, b) {
+			t.Fatalf("%v k=%d: %v != %v", nums, k, a, b)
+		}
+	}
+}
+
+func BenchmarkFindKthLargest(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	nums := r.Perm(100000)
+	for _, k := range []int{10, 1000} {
+		b.Run(fmt.Sprintf("k=%d/heap", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findKthLargest(nums, k)
+			}
+		})
+		b.Run(fmt.Sprintf("k=%d/sort", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findKthLargestSort(nums, k)
+			}
+		})
+	}
+}
+
+func BenchmarkTopKFrequent(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	nums := make([]int, 100000)
+	for i := range nums {
+		nums[i] = r.Intn(10000)
+	}
+	b.Run("heap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			topKFrequent(nums, 10)
+		}
+	})
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			topKFrequentSort(nums, 10)
+		}
+	})
+}
+
+func BenchmarkMedianFinder(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	nums := make([]int, 20000)
+	// 题目里 -10^5 <= num <= 10^5，r.Int() 两数相加会溢出
+	for i := range nums {
+		nums[i] = r.Intn(200001) - 100000
+	}
+	b.Run("heap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := NewMedianFinder()
+			for _, v := range nums {
+				m.AddNum(v)
+				m.FindMedian()
+			}
+		}
+	})
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			m := &MedianFinderSort{}
+			for _, v := range nums {
+				m.AddNum(v)
+				m.FindMedian()
+			}
+		}
+	})
+}
+
+func BenchmarkMaxSlidingWindow(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	nums := make([]int, 10000)
+	for i := range nums {
+		nums[i] = r.Intn(100000)
+	}
+	for _, k := range []int{10, 100} {
+		b.Run(fmt.Sprintf("k=%d/heap", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				maxSlidingWindow(nums, k)
+			}
+		})
+		b.Run(fmt.Sprintf("k=%d/sort", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				maxSlidingWindowSort(nums, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package queue
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// 仓库里用 queue = queue[1:] 写的 BFS 已经换成了 Queue，换之前的版本原样留在各自目录的测试里对拍、比分配：
+//   - shubo/levelOrder(层序遍历)                    levelOrderSlice
+//   - shubo/lowestCommonAncestor(二叉树最近公公祖先) processSlice
+//   - songzhibin97/课程表                           canFinishSlice
+//
+// 200. 岛屿数量 仓库里只有 DFS，下面两个 BFS 都是这里写的，只用来看共用一个队列能省多少分配。
+
+// numIslandsSlice 用 queue[1:] 的写法
+func numIslandsSlice(grid [][]byte) int {
+	res := 0
+	for i := range grid {
+		for j := range grid[i] {
+			if grid[i][j] != '1' {
+				continue
+			}
+			res++
+			grid[i][j] = '2'
+			queue := [][2]int{{i, j}}
+			for len(queue) > 0 {
+				p := queue[0]
+				queue = queue[1:]
+				for _, d := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+					x, y := p[0]+d[0], p[1]+d[1]
+					if x >= 0 && y >= 0 && x < len(grid) && y < len(grid[0]) && grid[x][y] == '1' {
+						grid[x][y] = '2'
+						queue = append(queue, [2]int{x, y})
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// 所有岛屿共用一个队列，Reset 之后空间接着用
+func numIslands(grid [][]byte) int {
+	res := 0
+	var queue Queue[[2]int]
+	for i := range grid {
+		for j := range grid[i] {
+			if grid[i][j] != '1' {
+				continue
+			}
+			res++
+			grid[i][j] = '2'
+			queue.Reset()
+			queue.Push([2]int{i, j})
+			for queue.Len() > 0 {
+				p := queue.Pop()
+				for _, d := range [][2]int{{0, 1}, {1, 0}, {0, -1}, {-1, 0}} {
+					x, y := p[0]+d[0], p[1]+d[1]
+					if x >= 0 && y >= 0 && x < len(grid) && y < len(grid[0]) && grid[x][y] == '1' {
+						grid[x][y] = '2'
+						queue.Push([2]int{x, y})
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+func randomGrid(r *rand.Rand, m, n int) [][]byte {
+	grid := make([][]byte, m)
+	for i := range grid {
+		grid[i] = make([]byte, n)
+		for j := range grid[i] {
+			grid[i][j] = "01"[r.Intn(2)]
+		}
+	}
+	return grid
+}
+
+func copyGrid(grid [][]byte) [][]byte {
+	ret := make([][]byte, len(grid))
+	for i := range grid {
+		ret[i] = append([]byte(nil), grid[i]...)
+	}
+	return ret
+}
+
+func TestNumIslands(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		grid := randomGrid(r, 1+r.Intn(20), 1+r.Intn(20))
+		if a, b := numIslands(copyGrid(grid)), numIslandsSlice(copyGrid(grid)); a != b {
+			t.Fatalf("numIslands %d != %d", a, b)
+		}
+	}
+}
+
+func BenchmarkNumIslands(b *testing.B) {
+	// 316*316 ≈ 10^5 个格子，七成是陆地，连成几个大岛
+	r := rand.New(rand.NewSource(1))
+	grid := make([][]byte, 316)
+	for i := range grid {
+		grid[i] = make([]byte, 316)
+		for j := range grid[i] {
+			if r.Intn(10) < 7 {
+				grid[i][j] = '1'
+			} else {
+				grid[i][j] = '0'
+			}
+		}
+	}
+	for _, impl := range []struct {
+		name string
+		f    func([][]byte) int
+	}{{"slice", numIslandsSlice}, {"ring", numIslands}} {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				g := copyGrid(grid)
+				b.StartTimer()
+				impl.f(g)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package queue
+
+// 环形缓冲区实现的双端队列，两端入队出队都是均摊 O(1)
+// 和 queue = queue[1:] 相比：出队不会让底层数组越来越靠后，满了才扩容一倍，
+// 出队的位置会清零，不会拖住已经出队的节点。零值可以直接用。
+
+type Deque[T any] struct {
+	buf  []T // 长度总是 2 的幂，下标用 & (len-1) 取模
+	head int
+	n    int
+}
+
+// New 预留 capacity 个位置，BFS 时知道节点数可以省掉扩容
+func New[T any](capacity int) *Deque[T] {
+	size := 8
+	for size < capacity {
+		size <<= 1
+	}
+	return &Deque[T]{buf: make([]T, size)}
+}
+
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+func (d *Deque[T]) PushBack(v T) {
+	if d.n == len(d.buf) {
+		d.grow()
+	}
+	d.buf[(d.head+d.n)&(len(d.buf)-1)] = v
+	d.n++
+}
+
+func (d *Deque[T]) PushFront(v T) {
+	if d.n == len(d.buf) {
+		d.grow()
+	}
+	d.head = (d.head - 1) & (len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront 队列为空时 panic，调用前先判断 Len
+func (d *Deque[T]) PopFront() T {
+	if d.n == 0 {
+		panic("queue: PopFront 空队列")
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) & (len(d.buf) - 1)
+	d.n--
+	return v
+}
+
+func (d *Deque[T]) PopBack() T {
+	if d.n == 0 {
+		panic("queue: PopBack 空队列")
+	}
+	var zero T
+	i := (d.head + d.n - 1) & (len(d.buf) - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return v
+}
+
+func (d *Deque[T]) Front() T {
+	return d.At(0)
+}
+
+func (d *Deque[T]) Back() T {
+	return d.At(d.n - 1)
+}
+
+// At 从队头数第 i 个
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("queue: 下标越界")
+	}
+	return d.buf[(d.head+i)&(len(d.buf)-1)]
+}
+
+// Reset 清空队列，保留已经分配的空间，多次 BFS 可以复用同一个队列
+func (d *Deque[T]) Reset() {
+	clear(d.buf)
+	d.head, d.n = 0, 0
+}
+
+func (d *Deque[T]) grow() {
+	size := len(d.buf) * 2
+	if size == 0 {
+		size = 8
+	}
+	buf := make([]T, size)
+	// 环可能绕过了数组末尾，分两段拷贝
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
+
+// Queue 只用一端进、一端出的队列，BFS 用这个就够了
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+func NewQueue[T any](capacity int) *Queue[T] {
+	return &Queue[T]{d: *New[T](capacity)}
+}
+
+func (q *Queue[T]) Push(v T) { q.d.PushBack(v) }
+
+func (q *Queue[T]) Pop() T { return q.d.PopFront() }
+
+func (q *Queue[T]) Peek() T { return q.d.Front() }
+
+func (q *Queue[T]) Len() int { return q.d.Len() }
+
+func (q *Queue[T]) Reset() { q.d.Reset() }
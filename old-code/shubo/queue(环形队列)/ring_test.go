@@ -0,0 +1,84 @@
+package queue
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// 随机两端操作，和切片对拍
+func TestDequeAgainstSlice(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var d Deque[int]
+	var want []int
+	for i := 0; i < 20000; i++ {
+		switch op := r.Intn(5); {
+		case op == 0:
+			d.PushFront(i)
+			want = append([]int{i}, want...)
+		case op == 1 || len(want) == 0:
+			d.PushBack(i)
+			want = append(want, i)
+		case op == 2:
+			if got := d.PopFront(); got != want[0] {
+				t.Fatalf("第 %d 步 PopFront = %d, 期望 %d", i, got, want[0])
+			}
+			want = want[1:]
+		case op == 3:
+			if got := d.PopBack(); got != want[len(want)-1] {
+				t.Fatalf("第 %d 步 PopBack = %d, 期望 %d", i, got, want[len(want)-1])
+			}
+			want = want[:len(want)-1]
+		default:
+			d.Reset()
+			want = want[:0]
+		}
+		if d.Len() != len(want) {
+			t.Fatalf("第 %d 步 Len = %d, 期望 %d", i, d.Len(), len(want))
+		}
+		if len(want) > 0 && (d.Front() != want[0] || d.Back() != want[len(want)-1]) {
+			t.Fatalf("第 %d 步队头队尾不对", i)
+		}
+	}
+}
+
+func TestGrowAcrossWrap(t *testing.T) {
+	d := New[int](8)
+	for i := 0; i < 6; i++ {
+		d.PushBack(i)
+	}
+	for i := 0; i < 4; i++ {
+		d.PopFront()
+	}
+	// 此时 head=4，再放 20 个会绕过数组末尾并扩容两次
+	for i := 6; i < 26; i++ {
+		d.PushBack(i)
+	}
+	for i := 4; i < 26; i++ {
+		if got := d.At(i - 4); got != i {
+			t.Fatalf("At(%d) = %d", i-4, got)
+		}
+	}
+}
+
+// 出队的位置要清零，不然 GC 回收不了已经出队的节点
+func TestPopClearsSlot(t *testing.T) {
+	var q Queue[*int]
+	v := 1
+	q.Push(&v)
+	q.Pop()
+	for _, p := range q.d.buf {
+		if p != nil {
+			t.Fatal("出队后缓冲区里还有指针")
+		}
+	}
+}
+
+func TestPopEmptyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("空队列出队应该 panic")
+		}
+	}()
+	var q Queue[int]
+	q.Pop()
+}
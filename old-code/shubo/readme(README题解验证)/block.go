@@ -0,0 +1,35 @@
+package main
+
+import "strings"
+
+// Block README 里的一个 go 代码块，diff(多作者对拍) 的 block.go 是从这里 go generate 拷贝的
+type Block struct {
+	Index int // 第几个 go 代码块，从 1 开始，空代码块也占编号
+	Line  int // 代码第一行在 README 里的行号
+	Code  string
+}
+
+// extractGo 取出 ```go 和 ```golang 代码块，其他语言和没标语言的都跳过
+func extractGo(src string) []Block {
+	var ret []Block
+	var cur *Block
+	var code []string
+	for i, line := range strings.Split(src, "\n") {
+		fence := strings.TrimSpace(line)
+		if cur == nil {
+			if fence == "```go" || fence == "```golang" {
+				cur = &Block{Index: len(ret) + 1, Line: i + 2}
+				code = code[:0]
+			}
+			continue
+		}
+		if fence == "```" {
+			cur.Code = strings.Join(code, "\n")
+			ret = append(ret, *cur)
+			cur = nil
+			continue
+		}
+		code = append(code, strings.TrimRight(line, "\r"))
+	}
+	return ret
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// 代码块的状态
+const (
+	StatusEmpty     = "空代码块"
+	StatusParse     = "解析失败"
+	StatusCompile   = "编译失败"
+	StatusSignature = "签名不匹配" // 能编译，但函数名、参数和题目对不上，跑不了用例
+	StatusCompiled  = "未判题"   // 能编译，但没有判题用例，不算验证过
+	StatusPassed    = "通过"
+	StatusFailed    = "未通过"
+)
+
+type Report struct {
+	*README
+	Blocks []BlockReport `json:"blocks"`
+}
+
+type BlockReport struct {
+	Index    int           `json:"index"`
+	Line     int           `json:"line"`
+	Package  string        `json:"package,omitempty"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Passed   int           `json:"passed"`
+	Total    int           `json:"total"`
+	Failures []CaseFailure `json:"failures,omitempty"`
+}
+
+type CaseFailure struct {
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+	Output   string `json:"output,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Options struct {
+	Root    string        // cc11001100 目录
+	Work    string        // 生成代码的目录，必须是空的
+	Cases   string        // 判题用例，判题服务的 cases.go
+	Timeout time.Duration // 每个用例的超时
+}
+
+// Check 提取、编译、运行，按 README 返回结果
+func Check(opt Options) ([]Report, error) {
+	readmes, err := Scan(opt.Root)
+	if err != nil {
+		return nil, err
+	}
+	cases, err := LoadCases(opt.Cases)
+	if err != nil {
+		return nil, err
+	}
+	if err := emptyDir(opt.Work); err != nil {
+		return nil, err
+	}
+	if err := writeFile(opt.Work, "go.mod", "module "+module+"\n\ngo 1.22\n"); err != nil {
+		return nil, err
+	}
+	if err := writeLC(opt.Work); err != nil {
+		return nil, err
+	}
+
+	var units []*Unit
+	for _, r := range readmes {
+		for _, b := range r.Blocks {
+			u := &Unit{README: r, Block: b, Pkg: fmt.Sprintf("p%s_%d", r.ID, b.Index)}
+			units = append(units, u)
+			if strings.TrimSpace(b.Code) == "" {
+				u.Status = StatusEmpty
+				continue
+			}
+			if err := u.generate(opt.Work); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 第一遍只编译 README 里的代码
+	failed, err := compile(opt.Work)
+	if err != nil {
+		return nil, err
+	}
+	var runnable []*Unit
+	for _, u := range units {
+		if u.Status != "" {
+			continue
+		}
+		if out, ok := failed[u.Pkg]; ok {
+			u.Status, u.Error = StatusCompile, out
+			continue
+		}
+		u.Status = StatusCompiled
+		sig, ok := signatures[u.README.ID]
+		if !ok || len(cases[u.README.ID]) == 0 {
+			continue
+		}
+		src, err := u.runSource(sig)
+		if err != nil {
+			u.Status, u.Error = StatusSignature, err.Error()
+			continue
+		}
+		if err := writeFile(u.dir(opt.Work), "run.go", src); err != nil {
+			return nil, err
+		}
+		runnable = append(runnable, u)
+	}
+
+	// 第二遍加上 run.go，这时候失败说明参数或者返回值类型和题目对不上
+	if failed, err = compile(opt.Work); err != nil {
+		return nil, err
+	}
+	var pkgs []string
+	for _, u := range runnable {
+		if out, ok := failed[u.Pkg]; ok {
+			u.Status, u.Error = StatusSignature, out
+			os.Remove(filepath.Join(u.dir(opt.Work), "run.go"))
+			continue
+		}
+		pkgs = append(pkgs, u.Pkg)
+	}
+
+	results := map[*Unit]*BlockReport{}
+	for _, u := range units {
+		results[u] = &BlockReport{Index: u.Block.Index, Line: u.Block.Line, Status: u.Status, Error: u.Error}
+		if u.Status != StatusEmpty && u.Status != StatusParse {
+			results[u].Package = u.Pkg
+		}
+	}
+	if len(pkgs) > 0 {
+		if err := runCases(opt, cases, units, pkgs, results); err != nil {
+			return nil, err
+		}
+	}
+
+	var ret []Report
+	for _, r := range readmes {
+		rep := Report{README: r, Blocks: []BlockReport{}}
+		for _, u := range units {
+			if u.README == r {
+				rep.Blocks = append(rep.Blocks, *results[u])
+			}
+		}
+		ret = append(ret, rep)
+	}
+	return ret, nil
+}
+
+type job struct {
+	Pkg  string
+	Args []json.RawMessage
+}
+
+type result struct {
+	Output    json.RawMessage
+	Error     string
+	ElapsedNs int64
+}
+
+// runCases 生成判题程序，一次跑完所有包的所有用例
+func runCases(opt Options, cases map[string][]Case, units []*Unit, pkgs []string, results map[*Unit]*BlockReport) error {
+	if err := writeFile(opt.Work, "cmd/harness/main.go", harnessSource(pkgs)); err != nil {
+		return err
+	}
+	type ref struct {
+		u *Unit
+		c Case
+	}
+	var jobs []job
+	var refs []ref
+	runnable := map[string]bool{}
+	for _, p := range pkgs {
+		runnable[p] = true
+	}
+	for _, u := range units {
+		if !runnable[u.Pkg] {
+			continue
+		}
+		for _, c := range cases[u.README.ID] {
+			r := results[u]
+			r.Total++
+			args, err := parseArgs(c.Input, signatures[u.README.ID].Params)
+			if err != nil {
+				r.Failures = append(r.Failures, CaseFailure{Input: c.Input, Expected: c.Expected, Error: err.Error()})
+				continue
+			}
+			jobs = append(jobs, job{Pkg: u.Pkg, Args: args})
+			refs = append(refs, ref{u, c})
+		}
+	}
+	src, _ := json.Marshal(jobs)
+	if err := writeFile(opt.Work, "jobs.json", string(src)); err != nil {
+		return err
+	}
+	resultsPath := filepath.Join(opt.Work, "results.json")
+	cmd := exec.Command("go", "run", "./cmd/harness", "jobs.json", resultsPath, opt.Timeout.String())
+	cmd.Dir = opt.Work
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("运行判题程序失败: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(resultsPath)
+	if err != nil {
+		return err
+	}
+	var rets []result
+	if err := json.Unmarshal(data, &rets); err != nil {
+		return err
+	}
+	for i, ret := range rets {
+		u, c := refs[i].u, refs[i].c
+		r := results[u]
+		switch {
+		case ret.Error != "":
+			r.Failures = append(r.Failures, CaseFailure{Input: c.Input, Expected: c.Expected, Error: ret.Error})
+		case !sameJSON(ret.Output, []byte(c.Expected)):
+			r.Failures = append(r.Failures, CaseFailure{Input: c.Input, Expected: c.Expected, Output: string(ret.Output)})
+		default:
+			r.Passed++
+		}
+	}
+	for _, u := range units {
+		if r := results[u]; runnable[u.Pkg] {
+			r.Status = StatusPassed
+			if r.Passed < r.Total {
+				r.Status = StatusFailed
+			}
+		}
+	}
+	return nil
+}
+
+// sameJSON 忽略空白和数字写法的差异
+func sameJSON(a, b []byte) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// PrintText 按 README 打印，最后是各状态的代码块数
+func PrintText(w io.Writer, reports []Report) {
+	count := map[string]int{}
+	for _, r := range reports {
+		fmt.Fprintf(w, "%s. %s  %s\n", r.ID, r.Title, r.Path)
+		if len(r.Blocks) == 0 {
+			fmt.Fprintln(w, "  没有 go 代码块")
+			count["没有 go 代码块的 README"]++
+		}
+		for _, b := range r.Blocks {
+			count[b.Status]++
+			fmt.Fprintf(w, "  #%d 第 %d 行  %s", b.Index, b.Line, b.Status)
+			if b.Total > 0 {
+				fmt.Fprintf(w, " %d/%d", b.Passed, b.Total)
+			}
+			fmt.Fprintln(w)
+			if b.Error != "" {
+				fmt.Fprintf(w, "    %s\n", strings.ReplaceAll(b.Error, "\n", "\n    "))
+			}
+			for _, f := range b.Failures {
+				if f.Error != "" {
+					fmt.Fprintf(w, "    %s: %s\n", f.Input, f.Error)
+				} else {
+					fmt.Fprintf(w, "    %s: 输出 %s，期望 %s\n", f.Input, f.Output, f.Expected)
+				}
+			}
+		}
+	}
+	fmt.Fprintln(w)
+	for _, s := range []string{StatusPassed, StatusFailed, StatusCompiled, StatusSignature, StatusCompile, StatusParse, StatusEmpty, "没有 go 代码块的 README"} {
+		if count[s] > 0 {
+			fmt.Fprintf(w, "%s: %d\n", s, count[s])
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// 判题用例不另外维护一份，直接读判题服务的 cases.go，那边加了用例这里自动就有
+const judgeCases = "../judge(本地判题服务)/cases.go"
+
+// Case 输入和输出都是 LeetCode 格式
+type Case struct {
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+}
+
+// LoadCases 解析 cases.go 里的 var cases = map[string][]Case{...}，只认字符串字面量
+func LoadCases(path string) (map[string][]Case, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	var lit *ast.CompositeLit
+	for _, d := range f.Decls {
+		if g, ok := d.(*ast.GenDecl); ok && g.Tok == token.VAR {
+			for _, spec := range g.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Names) == 1 && vs.Names[0].Name == "cases" && len(vs.Values) == 1 {
+					lit, _ = vs.Values[0].(*ast.CompositeLit)
+				}
+			}
+		}
+	}
+	if lit == nil {
+		return nil, fmt.Errorf("%s 里没有 var cases = map[string][]Case{...}", path)
+	}
+	cases := map[string][]Case{}
+	for _, e := range lit.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			return nil, fmt.Errorf("%s: 看不懂的写法", path)
+		}
+		id, err := stringLit(kv.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: 题号%v", path, err)
+		}
+		list, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s 题的用例看不懂", path, id)
+		}
+		for _, e := range list.Elts {
+			c, ok := e.(*ast.CompositeLit)
+			if !ok || len(c.Elts) != 2 {
+				return nil, fmt.Errorf("%s: %s 题的用例应该写成 {输入, 输出}", path, id)
+			}
+			in, err := stringLit(c.Elts[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s 题的输入%v", path, id, err)
+			}
+			out, err := stringLit(c.Elts[1])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s 题的输出%v", path, id, err)
+			}
+			cases[id] = append(cases[id], Case{Input: in, Expected: out})
+		}
+	}
+	return cases, nil
+}
+
+func stringLit(e ast.Expr) (string, error) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", fmt.Errorf("不是字符串字面量")
+	}
+	return strconv.Unquote(lit.Value)
+}
+
+// 函数名和参数名，和 registry.go 里的 solutions 一致
+var signatures = map[string]Signature{
+	"1":   {Name: "twoSum", Params: []string{"nums", "target"}},
+	"20":  {Name: "isValid", Params: []string{"s"}},
+	"42":  {Name: "trap", Params: []string{"height"}},
+	"70":  {Name: "climbStairs", Params: []string{"n"}},
+	"121": {Name: "maxProfit", Params: []string{"prices"}},
+	"128": {Name: "longestConsecutive", Params: []string{"nums"}},
+	"136": {Name: "singleNumber", Params: []string{"nums"}},
+	"200": {Name: "numIslands", Params: []string{"grid"}},
+	"739": {Name: "dailyTemperatures", Params: []string{"temperatures"}},
+}
+
+type Signature struct {
+	Name   string
+	Params []string
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cc11001100 的题解只写在 README.md 的 ```go 代码块里，目录名是 "208. 实现 Trie (前缀树)" 这种格式
+
+type README struct {
+	Path   string  `json:"path"` // 相对 root 的路径
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Blocks []Block `json:"-"`
+}
+
+var dirPattern = regexp.MustCompile(`^(\d+)\.\s*(.+)$`)
+
+// parseDir "208. 实现 Trie (前缀树)" -> 208, 实现 Trie (前缀树)
+func parseDir(name string) (id, title string, ok bool) {
+	m := dirPattern.FindStringSubmatch(name)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], strings.TrimSpace(m[2]), true
+}
+
+// Scan 找出 root 下所有题目目录里的 README.md，按题号排序
+func Scan(root string) ([]*README, error) {
+	var ret []*README
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "README.md" {
+			return err
+		}
+		id, title, ok := parseDir(filepath.Base(filepath.Dir(path)))
+		if !ok {
+			return nil // 根目录和分类目录的 README
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		ret = append(ret, &README{Path: filepath.ToSlash(rel), ID: id, Title: title, Blocks: extractGo(string(src))})
+		return nil
+	})
+	sort.Slice(ret, func(i, j int) bool {
+		a, _ := strconv.Atoi(ret[i].ID)
+		b, _ := strconv.Atoi(ret[j].ID)
+		return a < b || a == b && ret[i].Path < ret[j].Path
+	})
+	return ret, err
+}
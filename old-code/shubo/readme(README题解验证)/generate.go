@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// 每个代码块生成一个包 p<题号>_<块号>，放在临时模块 readmecheck 里：
+//   - README 里的代码原样放进 solution.go，前面补上 package、用到的标准库 import 和共享的节点类型；
+//     用 //line 指令把行号对回 README，编译错误直接指向 README 的行
+//   - 有判题用例的题再生成 run.go，导出 ReadmeRun，把 LeetCode 格式的参数转成函数参数
+
+const module = "readmecheck"
+
+// 代码里常用、但 LeetCode 上不用写 import 的标准库
+var stdPackages = map[string]string{
+	"sort":    "sort",
+	"strings": "strings",
+	"strconv": "strconv",
+	"math":    "math",
+	"fmt":     "fmt",
+	"bytes":   "bytes",
+	"unicode": "unicode",
+	"heap":    "container/heap",
+	"list":    "container/list",
+	"bits":    "math/bits",
+	"slices":  "slices",
+	"maps":    "maps",
+	"errors":  "errors",
+}
+
+// 共享的节点类型，在 lc 包里定义，题解包里用别名，ReadmeRun 和判题程序才能互相传递
+var sharedTypes = []string{"TreeNode", "ListNode"}
+
+var packageClause = regexp.MustCompile(`(?m)^\s*package\s+\w+\s*$`)
+
+// Unit 一个代码块
+type Unit struct {
+	README *README
+	Block  Block
+	Pkg    string
+	Status string
+	Error  string
+	file   *ast.File
+	fset   *token.FileSet
+}
+
+func (u *Unit) dir(work string) string {
+	return filepath.Join(work, u.Pkg)
+}
+
+// generate 解析代码块并写出 solution.go，解析失败时设置 Status
+func (u *Unit) generate(work string) error {
+	// 去掉 package 子句，换行保留，行号才对得上
+	code := packageClause.ReplaceAllStringFunc(u.Block.Code, func(m string) string {
+		return strings.Repeat("\n", strings.Count(m, "\n"))
+	})
+	line := fmt.Sprintf("//line %s:%d\n", u.README.Path, u.Block.Line)
+	u.fset = token.NewFileSet()
+	f, err := parser.ParseFile(u.fset, "", "package "+u.Pkg+"\n"+line+code, parser.ParseComments)
+	if err != nil {
+		u.Status, u.Error = StatusParse, err.Error()
+		return nil
+	}
+	u.file = f
+
+	imported := map[string]bool{}
+	for _, im := range f.Imports {
+		imported[strings.Trim(im.Path.Value, `"`)] = true
+	}
+	unresolved := map[string]bool{}
+	for _, id := range f.Unresolved {
+		unresolved[id.Name] = true
+	}
+	var imports, aliases []string
+	for name, path := range stdPackages {
+		if unresolved[name] && !imported[path] {
+			imports = append(imports, fmt.Sprintf("%q", path))
+		}
+	}
+	for _, name := range sharedTypes {
+		if unresolved[name] {
+			aliases = append(aliases, fmt.Sprintf("type %s = rlc.%s", name, name))
+		}
+	}
+	if len(aliases) > 0 {
+		imports = append(imports, fmt.Sprintf("rlc %q", module+"/lc"))
+	}
+	sort.Strings(imports)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// 由 readme(README题解验证) 从 %s 第 %d 个代码块生成\n\npackage %s\n\n", u.README.Path, u.Block.Index, u.Pkg)
+	if len(imports) > 0 {
+		fmt.Fprintf(&b, "import (\n\t%s\n)\n\n", strings.Join(imports, "\n\t"))
+	}
+	for _, a := range aliases {
+		b.WriteString(a + "\n")
+	}
+	b.WriteString("\n" + line + code + "\n")
+	if err := os.MkdirAll(u.dir(work), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(u.dir(work), "solution.go"), []byte(b.String()), 0o644)
+}
+
+// 参数类型怎么从 JSON 转过来：共享节点类型和字符矩阵有专门的转换，只由内置类型组成的直接 json 解码
+var plainType = regexp.MustCompile(`^(\[\])*(int|int64|string|bool|byte|rune|float64)$`)
+
+var decoders = map[string]string{
+	"*TreeNode":   "rlc.ParseTree",
+	"*ListNode":   "rlc.ParseList",
+	"[]*ListNode": "rlc.ParseLists",
+	"[][]byte":    "rlc.Grid",
+}
+
+var encoders = map[string]string{
+	"*TreeNode": "rlc.FormatTree",
+	"*ListNode": "rlc.FormatList",
+}
+
+// runSource 生成 run.go，找不到函数或者参数类型不支持时返回错误
+func (u *Unit) runSource(sig Signature) (string, error) {
+	var fn *ast.FuncDecl
+	for _, d := range u.file.Decls {
+		if f, ok := d.(*ast.FuncDecl); ok && f.Recv == nil && f.Name.Name == sig.Name {
+			fn = f
+		}
+	}
+	if fn == nil {
+		return "", fmt.Errorf("没有找到函数 %s", sig.Name)
+	}
+	var params []string
+	for _, field := range fn.Type.Params.List {
+		t := u.typeString(field.Type)
+		for range max(1, len(field.Names)) {
+			params = append(params, t)
+		}
+	}
+	if len(params) != len(sig.Params) {
+		return "", fmt.Errorf("%s 有 %d 个参数，题目是 %d 个 %v", sig.Name, len(params), len(sig.Params), sig.Params)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "// 由 readme(README题解验证) 生成\n\npackage %s\n\n", u.Pkg)
+	fmt.Fprintf(&b, "import (\n\trjson \"encoding/json\"\n\n\trlc %q\n)\n\n", module+"/lc")
+	b.WriteString("func ReadmeRun(args []rjson.RawMessage) (any, error) {\n")
+	var names []string
+	for i, t := range params {
+		dec, ok := decoders[t]
+		if !ok {
+			if !plainType.MatchString(t) {
+				return "", fmt.Errorf("参数 %s 的类型 %s 不支持", sig.Params[i], t)
+			}
+			dec = "rlc.Decode[" + t + "]"
+		}
+		fmt.Fprintf(&b, "\ta%d, err := %s(args[%d])\n\tif err != nil {\n\t\treturn nil, err\n\t}\n", i, dec, i)
+		names = append(names, fmt.Sprintf("a%d", i))
+	}
+	call := fmt.Sprintf("%s(%s)", sig.Name, strings.Join(names, ", "))
+	switch results := fn.Type.Results; {
+	case results == nil || len(results.List) == 0:
+		// 原地修改的题目，比如 283. 移动零，答案是第一个参数
+		fmt.Fprintf(&b, "\t%s\n\treturn a0, nil\n", call)
+	case encoders[u.typeString(results.List[0].Type)] != "":
+		fmt.Fprintf(&b, "\treturn %s(%s), nil\n", encoders[u.typeString(results.List[0].Type)], call)
+	default:
+		fmt.Fprintf(&b, "\treturn %s, nil\n", call)
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+func (u *Unit) typeString(e ast.Expr) string {
+	var b bytes.Buffer
+	printer.Fprint(&b, u.fset, e)
+	return b.String()
+}
+
+// harnessSource 判题程序：从文件读任务，每个任务单独一个 goroutine，panic 和超时都记下来
+func harnessSource(pkgs []string) string {
+	var imports, runs strings.Builder
+	for _, p := range pkgs {
+		fmt.Fprintf(&imports, "\t%q\n", module+"/"+p)
+		fmt.Fprintf(&runs, "\t%q: %s.ReadmeRun,\n", p, p)
+	}
+	return fmt.Sprintf(`// 由 readme(README题解验证) 生成
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+%s)
+
+var runs = map[string]func([]json.RawMessage) (any, error){
+%s}
+
+type job struct {
+	Pkg  string
+	Args []json.RawMessage
+}
+
+type result struct {
+	Output    json.RawMessage
+	Error     string
+	ElapsedNs int64
+}
+
+// harness <任务文件> <结果文件> <超时>，结果写文件，题解自己往标准输出打印也不影响
+func main() {
+	src, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	timeout, err := time.ParseDuration(os.Args[3])
+	if err != nil {
+		panic(err)
+	}
+	var jobs []job
+	if err := json.Unmarshal(src, &jobs); err != nil {
+		panic(err)
+	}
+	results := make([]result, len(jobs))
+	for i, j := range jobs {
+		results[i] = run(runs[j.Pkg], j.Args, timeout)
+	}
+	out, _ := json.Marshal(results)
+	if err := os.WriteFile(os.Args[2], out, 0o644); err != nil {
+		panic(err)
+	}
+	os.Exit(0) // 超时的 goroutine 还在跑
+}
+
+func run(f func([]json.RawMessage) (any, error), args []json.RawMessage, timeout time.Duration) result {
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if e := recover(); e != nil {
+				done <- result{Error: fmt.Sprint("panic: ", e)}
+			}
+		}()
+		start := time.Now()
+		out, err := f(args)
+		r := result{ElapsedNs: time.Since(start).Nanoseconds()}
+		if err != nil {
+			r.Error = err.Error()
+		} else if r.Output, err = json.Marshal(out); err != nil {
+			r.Error = err.Error()
+		}
+		done <- r
+	}()
+	select {
+	case r := <-done:
+		return r
+	case <-time.After(timeout):
+		return result{Error: fmt.Sprintf("超过 %%v 没有返回", timeout)}
+	}
+}
+`, imports.String(), runs.String())
+}
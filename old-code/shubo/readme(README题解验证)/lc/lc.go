@@ -0,0 +1,138 @@
+// lc 包：生成的判题代码共享的节点类型和 LeetCode 格式的转换
+// readme(README题解验证) 和 diff(多作者对拍) 把各自的 lc 目录嵌进程序，运行时原样写到生成代码的目录；
+// diff 的 lc.go 是从这里 go generate 拷贝的，它另外在 design.go 里加了设计类题目用的函数
+package lc
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate go run ../../copygen(拷贝生成)/main.go -pkg lc -- ../../queue(环形队列)/ring.go
+
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+func Decode[T any](raw json.RawMessage) (T, error) {
+	var v T
+	err := json.Unmarshal(raw, &v)
+	return v, err
+}
+
+// ParseTree LeetCode 的层序格式，第 k 个非空节点的孩子是后面的第 2k+1、2k+2 个元素
+func ParseTree(raw json.RawMessage) (*TreeNode, error) {
+	vals, err := Decode[[]*int](raw)
+	if err != nil || len(vals) == 0 {
+		return nil, err
+	}
+	if vals[0] == nil {
+		return nil, fmt.Errorf("根节点不能是 null")
+	}
+	root := &TreeNode{Val: *vals[0]}
+	queue := NewQueue[*TreeNode](len(vals))
+	queue.Push(root)
+	for i := 1; i < len(vals); i++ {
+		if queue.Len() == 0 {
+			return nil, fmt.Errorf("第 %d 个元素没有父节点", i)
+		}
+		var n *TreeNode
+		if vals[i] != nil {
+			n = &TreeNode{Val: *vals[i]}
+			queue.Push(n)
+		}
+		if i%2 == 1 {
+			queue.Peek().Left = n
+		} else {
+			queue.Pop().Right = n
+		}
+	}
+	return root, nil
+}
+
+func FormatTree(root *TreeNode) []*int {
+	ret := []*int{}
+	var queue Queue[*TreeNode]
+	queue.Push(root)
+	for queue.Len() > 0 {
+		n := queue.Pop()
+		if n == nil {
+			ret = append(ret, nil)
+			continue
+		}
+		v := n.Val
+		ret = append(ret, &v)
+		queue.Push(n.Left)
+		queue.Push(n.Right)
+	}
+	for len(ret) > 0 && ret[len(ret)-1] == nil {
+		ret = ret[:len(ret)-1]
+	}
+	return ret
+}
+
+func ParseList(raw json.RawMessage) (*ListNode, error) {
+	vals, err := Decode[[]int](raw)
+	if err != nil {
+		return nil, err
+	}
+	dummy := &ListNode{}
+	cur := dummy
+	for _, v := range vals {
+		cur.Next = &ListNode{Val: v}
+		cur = cur.Next
+	}
+	return dummy.Next, nil
+}
+
+func ParseLists(raw json.RawMessage) ([]*ListNode, error) {
+	items, err := Decode[[]json.RawMessage](raw)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*ListNode, len(items))
+	for i, item := range items {
+		if ret[i], err = ParseList(item); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// FormatList 最多走 10^6 步，链表成环时不至于卡死
+func FormatList(head *ListNode) []int {
+	ret := []int{}
+	for ; head != nil && len(ret) < 1000000; head = head.Next {
+		ret = append(ret, head.Val)
+	}
+	return ret
+}
+
+// Grid 字符矩阵写成 [["1","0"],["0","1"]]，每一行要一样长
+func Grid(raw json.RawMessage) ([][]byte, error) {
+	rows, err := Decode[[][]string](raw)
+	if err != nil {
+		return nil, err
+	}
+	g := make([][]byte, len(rows))
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("grid 第 %d 行有 %d 列，第 0 行有 %d 列", i, len(row), len(rows[0]))
+		}
+		g[i] = make([]byte, len(row))
+		for j, c := range row {
+			if len(c) != 1 {
+				return nil, fmt.Errorf("grid[%d][%d] 应该是单个字符: %q", i, j, c)
+			}
+			g[i][j] = c[0]
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../judge(本地判题服务)/parse.go
+
+// 提取 cc11001100 README 里的 go 代码，编译后用判题用例跑一遍，按 README 报告结果
+// go run $(ls *.go | grep -v _test) -format text
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	root := flag.String("root", "../../cc11001100", "cc11001100 目录")
+	cases := flag.String("cases", judgeCases, "判题用例，判题服务的 cases.go")
+	work := flag.String("work", "", "在这个目录下新建一个目录放生成的代码，跑完保留；默认用系统临时目录，跑完删除")
+	format := flag.String("format", "text", "输出格式：text 或 json")
+	timeout := flag.Duration("timeout", 2*time.Second, "每个用例的超时")
+	flag.Parse()
+
+	if *work != "" {
+		if err := os.MkdirAll(*work, 0o755); err != nil {
+			return err
+		}
+	}
+	dir, err := os.MkdirTemp(*work, "readmecheck")
+	if err != nil {
+		return err
+	}
+	if *work == "" {
+		defer os.RemoveAll(dir)
+	} else {
+		fmt.Fprintln(os.Stderr, "生成的代码在", dir)
+	}
+	reports, err := Check(Options{Root: *root, Work: dir, Cases: *cases, Timeout: *timeout})
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "json":
+		b, _ := json.MarshalIndent(reports, "", "  ")
+		fmt.Println(string(b))
+	default:
+		PrintText(os.Stdout, reports)
+	}
+	return nil
+}
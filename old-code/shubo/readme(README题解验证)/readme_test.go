@@ -0,0 +1,172 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDir(t *testing.T) {
+	id, title, ok := parseDir("208. 实现 Trie (前缀树)")
+	if !ok || id != "208" || title != "实现 Trie (前缀树)" {
+		t.Fatal(id, title, ok)
+	}
+	for _, name := range []string{"图论", "README.assets", "Trie 208"} {
+		if _, _, ok := parseDir(name); ok {
+			t.Fatal(name)
+		}
+	}
+}
+
+// TestLoadCases 判题服务的用例能读出来，有签名的题都有用例
+func TestLoadCases(t *testing.T) {
+	cases, err := LoadCases(judgeCases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := range signatures {
+		if len(cases[id]) == 0 {
+			t.Fatal(id, "没有用例")
+		}
+	}
+	if c := cases["1"][0]; c.Input != "nums = [2,7,11,15], target = 9" || c.Expected != "[0,1]" {
+		t.Fatal(c)
+	}
+	bad := filepath.Join(t.TempDir(), "cases.go")
+	src := "package main\n\nvar cases = map[string][]Case{\n\t\"1\": {{input, `[0,1]`}},\n}\n"
+	if err := os.WriteFile(bad, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCases(bad); err == nil || !strings.Contains(err.Error(), "不是字符串字面量") {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractGo(t *testing.T) {
+	src := "# 题目\n\n```\nhttps://leetcode.cn\n```\n\n```go\nfunc a() {}\n```\n\n```java\nclass A {}\n```\n\n```golang\r\nfunc b() {}\r\n```\n\n```go\n```\n"
+	blocks := extractGo(src)
+	if len(blocks) != 3 {
+		t.Fatal(blocks)
+	}
+	if blocks[0].Code != "func a() {}" || blocks[0].Line != 8 || blocks[0].Index != 1 {
+		t.Fatal(blocks[0])
+	}
+	if blocks[1].Code != "func b() {}" || blocks[1].Index != 2 {
+		t.Fatalf("%q", blocks[1].Code)
+	}
+	if blocks[2].Code != "" {
+		t.Fatal(blocks[2])
+	}
+}
+
+func writeREADME(t *testing.T, root, dir string, blocks ...string) {
+	var b strings.Builder
+	b.WriteString("# " + dir + "\n\n# 思路\n\n")
+	for _, code := range blocks {
+		b.WriteString("```go\n" + code + "\n```\n\n")
+	}
+	if err := writeFile(filepath.Join(root, "分类", dir), "README.md", b.String()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestCheckWork 生成代码的目录里有东西时不能动它
+func TestCheckWork(t *testing.T) {
+	work := t.TempDir()
+	keep := filepath.Join(work, "main.go")
+	if err := os.WriteFile(keep, []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Check(Options{Root: t.TempDir(), Work: work, Cases: judgeCases, Timeout: time.Second}); err == nil {
+		t.Fatal("不是空目录也跑了")
+	}
+	if _, err := os.Stat(keep); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("要调用 go build")
+	}
+	root := t.TempDir()
+	writeREADME(t, root, "1. 两数之和",
+		"func twoSum(nums []int, target int) []int {\n    m := map[int]int{}\n    for i, v := range nums {\n        if j, ok := m[target-v]; ok {\n            return []int{j, i}\n        }\n        m[v] = i\n    }\n    return nil\n}",
+		"func twoSum(nums []int, target int) []int {\n    return []int{0, 1}\n}")
+	writeREADME(t, root, "20. 有效的括号", "func isValid(s string) bool {\n    return len(s) %\n}")
+	writeREADME(t, root, "70. 爬楼梯", "package main\n\nfunc climbStairs(n int) int {\n    return \"1\"\n}")
+	writeREADME(t, root, "136. 只出现一次的数字", "func single(nums []int) int {\n    return 0\n}")
+	writeREADME(t, root, "121. 买卖股票的最佳时机", "func maxProfit(prices []int) int {\n    for {\n    }\n}")
+	writeREADME(t, root, "226. 翻转二叉树", "func invertTree(root *TreeNode) *TreeNode {\n    sort.Ints(nil)\n    return root\n}")
+	writeREADME(t, root, "11. 盛最多水的容器")
+
+	reports, err := Check(Options{Root: root, Work: t.TempDir(), Cases: judgeCases, Timeout: 200 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]BlockReport{}
+	for _, r := range reports {
+		got[r.ID] = r.Blocks
+	}
+	if len(reports) != 7 || reports[0].ID != "1" || reports[1].ID != "11" {
+		t.Fatal(reports)
+	}
+	if b := got["1"]; b[0].Status != StatusPassed || b[0].Passed != 3 || b[1].Status != StatusFailed || b[1].Passed != 2 {
+		t.Fatal(b)
+	}
+	if b := got["20"][0]; b.Status != StatusParse {
+		t.Fatal(b)
+	}
+	// //line 指令让错误指向 README 里的行：代码从第 6 行开始，return 在第 9 行
+	if b := got["70"][0]; b.Status != StatusCompile || !strings.Contains(b.Error, "分类/70. 爬楼梯/README.md:9") {
+		t.Fatal(b)
+	}
+	if b := got["136"][0]; b.Status != StatusSignature || !strings.Contains(b.Error, "singleNumber") {
+		t.Fatal(b)
+	}
+	if b := got["121"][0]; b.Status != StatusFailed || len(b.Failures) != 2 || !strings.Contains(b.Failures[0].Error, "没有返回") {
+		t.Fatal(b)
+	}
+	if b := got["226"][0]; b.Status != StatusCompiled {
+		t.Fatal(b)
+	}
+	if len(got["11"]) != 0 {
+		t.Fatal(got["11"])
+	}
+	var out strings.Builder
+	PrintText(&out, reports)
+	if !strings.Contains(out.String(), "通过: 1\n") || !strings.Contains(out.String(), "没有 go 代码块的 README: 1") {
+		t.Fatal(out.String())
+	}
+}
+
+// 仓库里的 README：所有代码块都要能编译，有用例的都要通过
+func TestCC11001100(t *testing.T) {
+	if testing.Short() {
+		t.Skip("要调用 go build")
+	}
+	if _, err := os.Stat("../../cc11001100"); err != nil {
+		t.Skip(err)
+	}
+	reports, err := Check(Options{Root: "../../cc11001100", Work: t.TempDir(), Cases: judgeCases, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	passed := 0
+	for _, r := range reports {
+		for _, b := range r.Blocks {
+			switch b.Status {
+			case StatusPassed:
+				passed++
+			case StatusCompiled, StatusEmpty:
+			default:
+				t.Errorf("%s #%d: %s %s %v", r.Path, b.Index, b.Status, b.Error, b.Failures)
+			}
+		}
+	}
+	if passed == 0 {
+		t.Fatal("没有跑任何用例")
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// 生成代码的目录：建目录、写 lc 包、编译，diff(多作者对拍) 的 work.go 是从这里 go generate 拷贝的
+// module 由用到的程序自己定义
+
+// emptyDir 生成代码的目录不存在就建一个，已经有东西时拒绝，不去清空别人的目录
+func emptyDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("生成代码的目录 %s 不是空的", dir)
+	}
+	return nil
+}
+
+// compile 编译所有包，返回编译失败的包和错误输出
+func compile(work string) (map[string]string, error) {
+	cmd := exec.Command("go", "build", "-json", "./...")
+	cmd.Dir = work
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		return nil, err
+	}
+	failed := map[string]string{}
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var e struct{ ImportPath, Action, Output string }
+		if json.Unmarshal(sc.Bytes(), &e) != nil {
+			continue
+		}
+		pkg := strings.TrimPrefix(e.ImportPath, module+"/")
+		switch e.Action {
+		case "build-output":
+			// //line 里是相对路径，go build 会在前面加上包的目录
+			if !strings.HasPrefix(e.Output, "# ") {
+				failed[pkg] += strings.ReplaceAll(e.Output, pkg+"/", "")
+			}
+		case "build-fail":
+			if _, ok := failed[pkg]; !ok {
+				failed[pkg] = ""
+			}
+		}
+	}
+	if err != nil && len(failed) == 0 {
+		return nil, fmt.Errorf("go build 失败: %s", stderr.String())
+	}
+	for pkg, msg := range failed {
+		failed[pkg] = strings.TrimSpace(msg)
+	}
+	return failed, nil
+}
+
+// lcFiles 生成代码共享的 lc 包，原样写到生成代码的目录
+//
+//go:embed lc/*.go
+var lcFiles embed.FS
+
+func writeLC(work string) error {
+	entries, err := lcFiles.ReadDir("lc")
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		b, err := lcFiles.ReadFile("lc/" + e.Name())
+		if err != nil {
+			return err
+		}
+		if err := writeFile(work, "lc/"+e.Name(), string(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(dir, name, content string) error {
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
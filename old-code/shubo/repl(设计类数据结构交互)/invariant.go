@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// 设计类数据结构的不变量，每个操作之后检查一次
+// 重放和随机测试在第一个破坏不变量的操作处停下来，报告是哪一步
+
+// InvariantError 第 Index 个操作（从 1 开始）之后不变量被破坏
+type InvariantError struct {
+	Index  int
+	Method string
+	Args   []interface{}
+	Err    error
+}
+
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("第 %d 个操作 %s %s 之后不变量被破坏: %v", e.Index, e.Method, formatArgs(e.Args), e.Err)
+}
+
+// 146. LRU
+// len(m) == size <= cap；链表 prev/next 对称；链表里的节点和 map 一一对应
+func checkLRU(obj interface{}) error {
+	c := obj.(*LRUCache)
+	if len(c.m) != c.size {
+		return fmt.Errorf("len(m)=%d != size=%d", len(c.m), c.size)
+	}
+	if c.size > c.cap {
+		return fmt.Errorf("size=%d > cap=%d", c.size, c.cap)
+	}
+	if c.head.prev != nil || c.tail.next != nil {
+		return fmt.Errorf("哨兵 head.prev 和 tail.next 应该是 nil")
+	}
+	n := 0
+	for node := c.head; node != c.tail; node = node.next {
+		if node.next == nil {
+			return fmt.Errorf("从 head 走 %d 步遇到 nil，没有走到 tail", n)
+		}
+		if node.next.prev != node {
+			return fmt.Errorf("节点 (%d,%d) 的 next.prev 不指回自己", node.next.k, node.next.v)
+		}
+		if node != c.head {
+			if c.m[node.k] != node {
+				return fmt.Errorf("链表里的节点 (%d,%d) 不在 map 里", node.k, node.v)
+			}
+		}
+		if n++; n > c.size+1 {
+			return fmt.Errorf("链表长度超过 size=%d，可能成环", c.size)
+		}
+	}
+	if n-1 != c.size {
+		return fmt.Errorf("链表长度 %d != size=%d", n-1, c.size)
+	}
+	return nil
+}
+
+// 155. 最小栈
+// len(min) == len(val)；min[i] == 前 i+1 个元素的最小值，从栈底到栈顶单调不增
+func checkMinStack(obj interface{}) error {
+	s := obj.(*MinStack)
+	if len(s.min) != len(s.val) {
+		return fmt.Errorf("len(min)=%d != len(val)=%d", len(s.min), len(s.val))
+	}
+	for i := range s.val {
+		want := s.val[i]
+		if i > 0 && s.min[i-1] < want {
+			want = s.min[i-1]
+		}
+		if s.min[i] != want {
+			return fmt.Errorf("min[%d]=%d，应该是 %d", i, s.min[i], want)
+		}
+	}
+	return nil
+}
+
+// 208. Trie
+// 除了根节点，每个节点往下都能走到一个单词结尾，不能有死分支
+func checkTrie(obj interface{}) error {
+	var walk func(t *Trie, path string) (bool, error)
+	walk = func(t *Trie, path string) (bool, error) {
+		reach := t.IsEnd
+		for i, c := range t.Child {
+			if c == nil {
+				continue
+			}
+			ok, err := walk(c, path+string(rune('a'+i)))
+			if err != nil {
+				return false, err
+			}
+			reach = reach || ok
+		}
+		if !reach && path != "" {
+			return false, fmt.Errorf("前缀 %q 走不到任何单词结尾", path)
+		}
+		return reach, nil
+	}
+	_, err := walk(obj.(*Trie), "")
+	return err
+}
+
+func init() {
+	kinds["LRUCache"].Check = checkLRU
+	kinds["MinStack"].Check = checkMinStack
+	kinds["Trie"].Check = checkTrie
+}
+
+// randomOp 生成一个合法的随机操作，值域故意取得很小，让 key 经常重复
+func randomOp(k *Kind, r *rand.Rand, size int) (string, []string) {
+	switch k.Class {
+	case "LRUCache":
+		if r.Intn(2) == 0 {
+			return "get", []string{strconv.Itoa(r.Intn(8))}
+		}
+		return "put", []string{strconv.Itoa(r.Intn(8)), strconv.Itoa(r.Intn(100))}
+	case "MinStack":
+		// 空栈上 pop/top/getMin 是非法操作，题目保证不会出现
+		if size == 0 || r.Intn(2) == 0 {
+			return "push", []string{strconv.Itoa(r.Intn(21) - 10)}
+		}
+		return []string{"pop", "top", "getMin"}[r.Intn(3)], nil
+	case "Trie":
+		word := make([]byte, 1+r.Intn(4))
+		for i := range word {
+			word[i] = byte('a' + r.Intn(3))
+		}
+		return []string{"insert", "search", "startsWith"}[r.Intn(3)], []string{string(word)}
+	}
+	return "", nil
+}
+
+// Fuzz 随机执行 n 个操作，每步检查不变量，返回第一个错误
+// 出错时 s.Export() 就是能复现问题的 LeetCode 用例
+func Fuzz(s *Session, r *rand.Rand, n int) error {
+	size := 0
+	for i := 0; i < n; i++ {
+		method, args := randomOp(s.Kind, r, size)
+		if _, err := s.Call(method, args); err != nil {
+			return err
+		}
+		switch method {
+		case "push":
+			size++
+		case "pop":
+			size--
+		}
+	}
+	return nil
+}
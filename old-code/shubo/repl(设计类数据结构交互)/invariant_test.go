@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestFuzz(t *testing.T) {
+	for _, c := range []struct {
+		class string
+		ctor  []string
+	}{
+		{"LRUCache", []string{"1"}},
+		{"LRUCache", []string{"3"}},
+		{"MinStack", nil},
+		{"Trie", nil},
+	} {
+		for seed := int64(0); seed < 20; seed++ {
+			s, _ := NewSession(c.class, c.ctor)
+			if err := Fuzz(s, rand.New(rand.NewSource(seed)), 500); err != nil {
+				t.Fatalf("%s seed=%d: %v\n%s", c.class, seed, err, s.Export())
+			}
+		}
+	}
+}
+
+func TestCheckLRU(t *testing.T) {
+	corrupt := []struct {
+		name string
+		f    func(c *LRUCache)
+		want string
+	}{
+		{"size 没更新", func(c *LRUCache) { c.size++ }, "len(m)=2 != size=3"},
+		{"超出容量", func(c *LRUCache) { c.cap = 1 }, "size=2 > cap=1"},
+		{"prev 没指回来", func(c *LRUCache) { c.tail.prev = c.head }, "next.prev 不指回自己"},
+		{"map 里少了节点", func(c *LRUCache) { delete(c.m, 1); c.size-- }, "不在 map 里"},
+		{"链表断了", func(c *LRUCache) { c.head.next.next = nil }, "遇到 nil"},
+	}
+	for _, c := range corrupt {
+		s, _ := NewSession("LRUCache", []string{"2"})
+		s.Call("put", []string{"1", "1"})
+		s.Call("put", []string{"2", "2"})
+		c.f(s.obj.(*LRUCache))
+		if err := checkLRU(s.obj); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+	}
+}
+
+func TestCheckMinStack(t *testing.T) {
+	s := &MinStack{val: []int{3, 1, 2}, min: []int{3, 1, 2}}
+	if err := checkMinStack(s); err == nil || !strings.Contains(err.Error(), "min[2]=2，应该是 1") {
+		t.Fatal(err)
+	}
+	s = &MinStack{val: []int{3}, min: nil}
+	if checkMinStack(s) == nil {
+		t.Fatal("长度不一致应该报错")
+	}
+}
+
+func TestCheckTrie(t *testing.T) {
+	tr := NewTrie()
+	tr.Insert("ab")
+	tr.Child['a'-'a'].Child['c'-'a'] = &Trie{}
+	if err := checkTrie(&tr); err == nil || !strings.Contains(err.Error(), `"ac"`) {
+		t.Fatal(err)
+	}
+}
+
+// 故意写坏的最小栈：第 3 次 push 开始不维护 min，要在第 3 个操作处报错
+func TestInvariantErrorIndex(t *testing.T) {
+	k := *kinds["MinStack"]
+	pushes := 0
+	k.Methods = map[string]Method{
+		"push": {[]string{ArgInt}, func(obj interface{}, args []interface{}) interface{} {
+			s := obj.(*MinStack)
+			if pushes++; pushes >= 3 {
+				s.val = append(s.val, args[0].(int))
+				s.min = append(s.min, s.val[0])
+				return nil
+			}
+			s.Push(args[0].(int))
+			return nil
+		}},
+	}
+	s := &Session{Kind: &k}
+	s.replay()
+	var err error
+	for _, v := range []string{"5", "4", "1", "0"} {
+		if _, err = s.Call("push", []string{v}); err != nil {
+			break
+		}
+	}
+	var ie *InvariantError
+	if !errors.As(err, &ie) || ie.Index != 3 || ie.Args[0] != 1 {
+		t.Fatalf("%v", err)
+	}
+	if !strings.Contains(s.Export(), `[[],[5],[4],[1]]`) {
+		t.Fatal(s.Export())
+	}
+}
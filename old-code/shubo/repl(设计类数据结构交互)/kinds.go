@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 每种设计类数据结构注册一个 Kind：怎么构造、有哪些方法、怎么画内部状态
+// 新增一种数据结构只需要往 kinds 里加一项
+
+const (
+	ArgInt    = "int"
+	ArgString = "string"
+)
+
+type Method struct {
+	Args []string // 每个参数的类型，ArgInt 或 ArgString
+	Call func(obj interface{}, args []interface{}) interface{}
+}
+
+type Kind struct {
+	Class   string   // LeetCode 里的类名
+	Args    []string // 构造参数类型
+	New     func(args []interface{}) interface{}
+	Methods map[string]Method // key 是 LeetCode 里的方法名：put、get
+	Render  func(obj interface{}) string
+	Check   func(obj interface{}) error // 不变量检查，可以为空
+}
+
+var kinds = map[string]*Kind{
+	"LRUCache": {
+		Class: "LRUCache",
+		Args:  []string{ArgInt},
+		New: func(args []interface{}) interface{} {
+			c := NewLRUCache(args[0].(int))
+			return &c
+		},
+		Methods: map[string]Method{
+			"get": {[]string{ArgInt}, func(obj interface{}, args []interface{}) interface{} {
+				return obj.(*LRUCache).Get(args[0].(int))
+			}},
+			"put": {[]string{ArgInt, ArgInt}, func(obj interface{}, args []interface{}) interface{} {
+				obj.(*LRUCache).Put(args[0].(int), args[1].(int))
+				return nil
+			}},
+		},
+		Render: renderLRU,
+	},
+	"MinStack": {
+		Class: "MinStack",
+		New: func(args []interface{}) interface{} {
+			s := NewMinStack()
+			return &s
+		},
+		Methods: map[string]Method{
+			"push": {[]string{ArgInt}, func(obj interface{}, args []interface{}) interface{} {
+				obj.(*MinStack).Push(args[0].(int))
+				return nil
+			}},
+			"pop": {nil, func(obj interface{}, args []interface{}) interface{} {
+				obj.(*MinStack).Pop()
+				return nil
+			}},
+			"top": {nil, func(obj interface{}, args []interface{}) interface{} {
+				return obj.(*MinStack).Top()
+			}},
+			"getMin": {nil, func(obj interface{}, args []interface{}) interface{} {
+				return obj.(*MinStack).GetMin()
+			}},
+		},
+		Render: renderMinStack,
+	},
+	"Trie": {
+		Class: "Trie",
+		New: func(args []interface{}) interface{} {
+			t := NewTrie()
+			return &t
+		},
+		Methods: map[string]Method{
+			"insert": {[]string{ArgString}, func(obj interface{}, args []interface{}) interface{} {
+				obj.(*Trie).Insert(args[0].(string))
+				return nil
+			}},
+			"search": {[]string{ArgString}, func(obj interface{}, args []interface{}) interface{} {
+				return obj.(*Trie).Search(args[0].(string))
+			}},
+			"startsWith": {[]string{ArgString}, func(obj interface{}, args []interface{}) interface{} {
+				return obj.(*Trie).StartsWith(args[0].(string))
+			}},
+		},
+		Render: renderTrie,
+	},
+}
+
+// parseArgs 按参数类型把命令行里的参数转成 int 或 string
+// 字符串参数可以带引号，也可以不带
+func parseArgs(types []string, fields []string) ([]interface{}, error) {
+	if len(fields) != len(types) {
+		return nil, fmt.Errorf("需要 %d 个参数，实际是 %d 个", len(types), len(fields))
+	}
+	args := make([]interface{}, len(types))
+	for i, t := range types {
+		switch t {
+		case ArgInt:
+			v, err := strconv.Atoi(fields[i])
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 个参数应该是整数: %s", i+1, fields[i])
+			}
+			args[i] = v
+		case ArgString:
+			s := fields[i]
+			if uq, err := strconv.Unquote(s); err == nil {
+				s = uq
+			}
+			args[i] = s
+		}
+	}
+	return args, nil
+}
+
+// 146. LRU：map 按 key 排序打印，链表从 head 打印到 tail
+func renderLRU(obj interface{}) string {
+	c := obj.(*LRUCache)
+	var b strings.Builder
+	fmt.Fprintf(&b, "size=%d cap=%d\n", c.size, c.cap)
+	keys := make([]int, 0, len(c.m))
+	for k := range c.m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	b.WriteString("m: {")
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%d→(%d,%d)", k, c.m[k].k, c.m[k].v)
+	}
+	b.WriteString("}\n")
+	b.WriteString("list: head")
+	// 最多走 len(m)+2 步，链表成环时不至于卡死
+	steps := 0
+	for n := c.head.next; n != nil && n != c.tail; n = n.next {
+		if steps > len(c.m)+1 {
+			b.WriteString(" <-> ...")
+			break
+		}
+		fmt.Fprintf(&b, " <-> (%d,%d)", n.k, n.v)
+		steps++
+	}
+	b.WriteString(" <-> tail\n")
+	return b.String()
+}
+
+// 155. 最小栈：val 和 min 两个栈并排打印，栈顶在上
+func renderMinStack(obj interface{}) string {
+	s := obj.(*MinStack)
+	var b strings.Builder
+	b.WriteString("     val  min\n")
+	for i := len(s.val) - 1; i >= 0; i-- {
+		m := "?"
+		if i < len(s.min) {
+			m = strconv.Itoa(s.min[i])
+		}
+		top := "   "
+		if i == len(s.val)-1 {
+			top = "top"
+		}
+		fmt.Fprintf(&b, "%s %4d %4s\n", top, s.val[i], m)
+	}
+	if len(s.val) == 0 {
+		b.WriteString("    (空)\n")
+	}
+	return b.String()
+}
+
+// 208. Trie：按字母序缩进打印每个节点，* 表示单词结尾
+func renderTrie(obj interface{}) string {
+	var b strings.Builder
+	b.WriteString("root\n")
+	var walk func(t *Trie, depth int)
+	walk = func(t *Trie, depth int) {
+		for i, c := range t.Child {
+			if c == nil {
+				continue
+			}
+			end := ""
+			if c.IsEnd {
+				end = " *"
+			}
+			fmt.Fprintf(&b, "%s%c%s\n", strings.Repeat("  ", depth+1), 'a'+i, end)
+			walk(c, depth+1)
+		}
+	}
+	walk(obj.(*Trie), 0)
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package main
+
+// 146. LRU 缓存，shubo 的解法，Constructor 改名以免冲突，其余原样拷贝
+
+type LRUCache struct {
+	m          map[int]*DeListNode
+	head, tail *DeListNode
+	cap        int
+	size       int
+}
+type DeListNode struct {
+	k, v       int
+	prev, next *DeListNode
+}
+
+func NewLRUCache(capacity int) LRUCache {
+	v := LRUCache{
+		m:    map[int]*DeListNode{},
+		head: &DeListNode{},
+		tail: &DeListNode{},
+		cap:  capacity,
+	}
+	v.head.next = v.tail
+	v.tail.prev = v.head
+	return v
+}
+
+// 不存在直接返回-1
+// 存在将元素刷新到队尾
+func (this *LRUCache) Get(key int) int {
+	node, ok := this.m[key]
+	if !ok {
+		return -1
+	}
+	this.moveToTail(node)
+	return node.v
+}
+func (this *LRUCache) moveToTail(node *DeListNode) {
+	this.deleteNode(node)
+	this.appendToTail(node)
+}
+
+func (this *LRUCache) appendToTail(node *DeListNode) {
+	if node == nil {
+		return
+	}
+	node.prev = this.tail.prev
+	node.next = this.tail
+	this.tail.prev = node
+	node.prev.next = node
+}
+
+func (this *LRUCache) deleteNode(node *DeListNode) {
+	if node == nil {
+		return
+	}
+	node.prev.next = node.next
+	node.next.prev = node.prev
+}
+func (this *LRUCache) releaseCache() {
+	//容量不足则弹出队头，清除对应cache
+	if this.size > this.cap {
+		node := this.head.next
+		this.deleteNode(node)
+		delete(this.m, node.k)
+		this.size--
+	}
+}
+
+// Put
+// 如果已经存在，更新val并则刷新到队尾
+// 如果不存在，检查容量，容量不足则弹出队头，清除对应cache
+// 然后入队
+func (this *LRUCache) Put(key int, value int) {
+	if node, ok := this.m[key]; ok {
+		node.v = value
+		this.deleteNode(node)
+		this.moveToTail(node)
+	} else {
+		newNode := &DeListNode{k: key, v: value}
+		this.m[key] = newNode
+		this.appendToTail(newNode)
+		this.size++
+		//容量不足则弹出队头，清除对应cache
+		this.releaseCache()
+		// 入队
+	}
+}
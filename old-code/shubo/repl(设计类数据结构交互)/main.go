@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// 设计类数据结构的交互式调试，每个操作之后打印内部状态
+// go run $(ls *.go | grep -v _test) LRUCache 2
+// go run $(ls *.go | grep -v _test) MinStack
+// go run $(ls *.go | grep -v _test) Trie
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "用法: repl <LRUCache|MinStack|Trie> [构造参数...]")
+		os.Exit(2)
+	}
+	s, err := NewSession(os.Args[1], os.Args[2:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(help)
+	s.Run(os.Stdin, os.Stdout)
+}
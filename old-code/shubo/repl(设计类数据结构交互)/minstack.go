@@ -0,0 +1,35 @@
+package main
+
+// 155. 最小栈，songzhibin97 的解法，Constructor 改名以免冲突，其余原样拷贝
+// val 存元素，min[i] 是 val[0..i] 的最小值
+
+type MinStack struct {
+	min []int
+	val []int
+}
+
+func NewMinStack() MinStack {
+	return MinStack{}
+}
+
+func (this *MinStack) Push(val int) {
+	od := val
+	if len(this.min) != 0 && od > this.min[len(this.min)-1] {
+		od = this.min[len(this.min)-1]
+	}
+	this.min = append(this.min, od)
+	this.val = append(this.val, val)
+}
+
+func (this *MinStack) Pop() {
+	this.min = this.min[:len(this.min)-1]
+	this.val = this.val[:len(this.val)-1]
+}
+
+func (this *MinStack) Top() int {
+	return this.val[len(this.val)-1]
+}
+
+func (this *MinStack) GetMin() int {
+	return this.min[len(this.min)-1]
+}
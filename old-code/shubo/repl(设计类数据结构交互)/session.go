@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Op struct {
+	Method string
+	Args   []interface{}
+	Output interface{}
+}
+
+// Session 一次交互：构造参数 + 执行过的操作
+// 撤销就是去掉最后一个操作，从头重放一遍
+type Session struct {
+	Kind     *Kind
+	CtorArgs []interface{}
+	Ops      []Op
+	obj      interface{}
+}
+
+func NewSession(class string, ctorFields []string) (*Session, error) {
+	k, ok := kinds[class]
+	if !ok {
+		return nil, fmt.Errorf("不支持 %s，可选 LRUCache、MinStack、Trie", class)
+	}
+	args, err := parseArgs(k.Args, ctorFields)
+	if err != nil {
+		return nil, fmt.Errorf("%s 构造参数: %w", class, err)
+	}
+	s := &Session{Kind: k, CtorArgs: args}
+	s.replay()
+	return s, nil
+}
+
+func (s *Session) replay() {
+	s.obj = s.Kind.New(s.CtorArgs)
+	for _, op := range s.Ops {
+		s.Kind.Methods[op.Method].Call(s.obj, op.Args)
+	}
+}
+
+// Call 执行一个操作，如 put 1 1
+// 题解 panic 时不记录这次操作，并重放恢复到 panic 之前的状态
+func (s *Session) Call(method string, fields []string) (out interface{}, err error) {
+	m, ok := s.Kind.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("%s 没有 %s 方法", s.Kind.Class, method)
+	}
+	args, err := parseArgs(m.Args, fields)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s.replay()
+			out, err = nil, fmt.Errorf("%s %v panic: %v", method, fields, r)
+		}
+	}()
+	out = m.Call(s.obj, args)
+	s.Ops = append(s.Ops, Op{Method: method, Args: args, Output: out})
+	// 不变量被破坏时保留这次操作，方便打印出错的状态和导出用例
+	if s.Kind.Check != nil {
+		if err := s.Kind.Check(s.obj); err != nil {
+			return out, &InvariantError{Index: len(s.Ops), Method: method, Args: args, Err: err}
+		}
+	}
+	return out, nil
+}
+
+// Check 重放每个操作并检查不变量，返回第一个破坏不变量的操作
+func (s *Session) Check() error {
+	if s.Kind.Check == nil {
+		return nil
+	}
+	obj := s.Kind.New(s.CtorArgs)
+	for i, op := range s.Ops {
+		s.Kind.Methods[op.Method].Call(obj, op.Args)
+		if err := s.Kind.Check(obj); err != nil {
+			return &InvariantError{Index: i + 1, Method: op.Method, Args: op.Args, Err: err}
+		}
+	}
+	return nil
+}
+
+func (s *Session) Undo() bool {
+	if len(s.Ops) == 0 {
+		return false
+	}
+	s.Ops = s.Ops[:len(s.Ops)-1]
+	s.replay()
+	return true
+}
+
+func (s *Session) State() string {
+	return s.Kind.Render(s.obj)
+}
+
+func (s *Session) History() []string {
+	ret := make([]string, len(s.Ops))
+	for i, op := range s.Ops {
+		b, _ := json.Marshal(op.Output)
+		ret[i] = strings.TrimSpace(fmt.Sprintf("%d: %s %s", i+1, op.Method, formatArgs(op.Args))) + " -> " + string(b)
+	}
+	return ret
+}
+
+func formatArgs(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, a := range args {
+		b, _ := json.Marshal(a)
+		parts[i] = string(b)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Export 导出成 LeetCode 格式的用例：操作、参数、输出各一行
+func (s *Session) Export() string {
+	methods := []string{s.Kind.Class}
+	args := [][]interface{}{nonNil(s.CtorArgs)}
+	outputs := []interface{}{nil}
+	for _, op := range s.Ops {
+		methods = append(methods, op.Method)
+		args = append(args, nonNil(op.Args))
+		outputs = append(outputs, op.Output)
+	}
+	m, _ := json.Marshal(methods)
+	a, _ := json.Marshal(args)
+	o, _ := json.Marshal(outputs)
+	return fmt.Sprintf("%s\n%s\n%s\n", m, a, o)
+}
+
+func nonNil(args []interface{}) []interface{} {
+	if args == nil {
+		return []interface{}{}
+	}
+	return args
+}
+
+const help = `命令：
+  <方法> <参数...>   调用方法，如 put 1 1、get 2、insert apple
+  :state            打印内部状态
+  :history          打印执行过的操作
+  :undo             撤销上一个操作（从头重放）
+  :check            重放所有操作并检查不变量
+  :export           导出 LeetCode 格式的用例
+  :help             帮助
+  :quit             退出
+`
+
+// Run 读一行执行一行，每次调用后打印返回值和内部状态
+func (s *Session) Run(in io.Reader, out io.Writer) {
+	fmt.Fprint(out, s.State())
+	sc := bufio.NewScanner(in)
+	for {
+		fmt.Fprintf(out, "%s> ", s.Kind.Class)
+		if !sc.Scan() {
+			fmt.Fprintln(out)
+			return
+		}
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case ":quit", ":q":
+			return
+		case ":help":
+			fmt.Fprint(out, help)
+		case ":state":
+			fmt.Fprint(out, s.State())
+		case ":history":
+			for _, h := range s.History() {
+				fmt.Fprintln(out, h)
+			}
+		case ":undo":
+			if !s.Undo() {
+				fmt.Fprintln(out, "没有可以撤销的操作")
+				continue
+			}
+			fmt.Fprint(out, s.State())
+		case ":check":
+			if err := s.Check(); err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			fmt.Fprintln(out, "不变量检查通过")
+		case ":export":
+			fmt.Fprint(out, s.Export())
+		default:
+			ret, err := s.Call(fields[0], fields[1:])
+			if ie, ok := err.(*InvariantError); ok {
+				fmt.Fprintln(out, ie)
+				fmt.Fprint(out, s.State())
+				continue
+			}
+			if err != nil {
+				fmt.Fprintln(out, "错误:", err)
+				continue
+			}
+			b, _ := json.Marshal(ret)
+			fmt.Fprintf(out, "=> %s\n", b)
+			fmt.Fprint(out, s.State())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	s, err := NewSession("LRUCache", []string{"2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"put 1 1", "put 2 2", "get 1", "put 3 3", "get 2", "put 4 4", "get 1", "get 3", "get 4"} {
+		f := strings.Fields(line)
+		if _, err := s.Call(f[0], f[1:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `["LRUCache","put","put","get","put","get","put","get","get","get"]
+[[2],[1,1],[2,2],[1],[3,3],[2],[4,4],[1],[3],[4]]
+[null,null,null,1,null,-1,null,-1,3,4]
+`
+	if got := s.Export(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	wantState := "size=2 cap=2\nm: {3→(3,3), 4→(4,4)}\nlist: head <-> (3,3) <-> (4,4) <-> tail\n"
+	if got := s.State(); got != wantState {
+		t.Fatalf("got\n%s\nwant\n%s", got, wantState)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	s, _ := NewSession("LRUCache", []string{"2"})
+	s.Call("put", []string{"1", "1"})
+	s.Call("put", []string{"2", "2"})
+	before := s.State()
+	s.Call("put", []string{"3", "3"})
+	if !s.Undo() || s.State() != before || len(s.Ops) != 2 {
+		t.Fatalf("撤销之后应该回到 put 3 3 之前\n%s", s.State())
+	}
+	s.Undo()
+	s.Undo()
+	if s.Undo() {
+		t.Fatal("没有操作时不能撤销")
+	}
+}
+
+func TestMinStack(t *testing.T) {
+	s, _ := NewSession("MinStack", nil)
+	var outs []interface{}
+	for _, line := range []string{"push -2", "push 0", "push -3", "getMin", "pop", "top", "getMin"} {
+		f := strings.Fields(line)
+		out, err := s.Call(f[0], f[1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		outs = append(outs, out)
+	}
+	if outs[3] != -3 || outs[5] != 0 || outs[6] != -2 {
+		t.Fatal(outs)
+	}
+	want := "     val  min\ntop    0   -2\n      -2   -2\n"
+	if got := s.State(); got != want {
+		t.Fatalf("got\n%q\nwant\n%q", got, want)
+	}
+	// 空栈 pop 会 panic，REPL 要报错而不是退出
+	s.Call("pop", nil)
+	s.Call("pop", nil)
+	if _, err := s.Call("pop", nil); err == nil {
+		t.Fatal("空栈 pop 应该报错")
+	}
+	if len(s.Ops) != 9 {
+		t.Fatal("panic 的操作不应该记录", s.History())
+	}
+}
+
+func TestTrie(t *testing.T) {
+	s, _ := NewSession("Trie", nil)
+	for _, line := range []string{"insert apple", `search "apple"`, "search app", "startsWith app", "insert app", "search app"} {
+		f := strings.Fields(line)
+		if _, err := s.Call(f[0], f[1:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `["Trie","insert","search","search","startsWith","insert","search"]
+[[],["apple"],["apple"],["app"],["app"],["app"],["app"]]
+[null,null,true,false,true,null,true]
+`
+	if got := s.Export(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if !strings.Contains(s.State(), "      p *\n        l\n") {
+		t.Fatal(s.State())
+	}
+	// [26]*Trie 只支持小写字母
+	if _, err := s.Call("insert", []string{"Go"}); err == nil {
+		t.Fatal("大写字母应该报错")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := NewSession("LFUCache", nil); err == nil {
+		t.Fatal("不支持的类型应该报错")
+	}
+	if _, err := NewSession("LRUCache", nil); err == nil {
+		t.Fatal("缺少容量应该报错")
+	}
+	s, _ := NewSession("LRUCache", []string{"1"})
+	for _, line := range []string{"del 1", "put 1", "get x"} {
+		f := strings.Fields(line)
+		if _, err := s.Call(f[0], f[1:]); err == nil {
+			t.Fatalf("%s 应该报错", line)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	s, _ := NewSession("LRUCache", []string{"2"})
+	var out strings.Builder
+	s.Run(strings.NewReader("put 1 1\nget 1\nget 2\n:undo\n:history\n:export\n:quit\n"), &out)
+	got := out.String()
+	for _, want := range []string{"=> 1\n", "=> -1\n", "1: put 1 1 -> null\n2: get 1 -> 1\n", `["LRUCache","put","get"]`} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("输出里没有 %q\n%s", want, got)
+		}
+	}
+}
+
+// go test -fuzz FuzzSession
+// 任意命令都不能让会话 panic：参数不合法、违反题目前提的调用都应该报错
+func FuzzSession(f *testing.F) {
+	f.Add("LRUCache", "2", "put 1 1\nput 2 2\nget 1\nput 3 3\nget 2\nput 4 4\nget 1\nget 3\nget 4\n:check\n:export")
+	f.Add("LRUCache", "2", "put 1 1\nget 1\nget 2\n:undo\n:history\n:export\n:quit\n")
+	f.Add("MinStack", "", "push -2\npush 0\npush -3\ngetMin\npop\ntop\ngetMin\n:undo\n:check")
+	f.Add("Trie", "", "insert apple\nsearch apple\nsearch app\nstartsWith app\ninsert \"app\"\nsearch app")
+	f.Add("LRUCache", "x", "get")
+	f.Fuzz(func(t *testing.T, class, ctor, script string) {
+		s, err := NewSession(class, strings.Fields(ctor))
+		if err != nil {
+			return
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("%s(%s) %q panic: %v", class, ctor, script, r)
+			}
+		}()
+		s.Run(strings.NewReader(script), io.Discard)
+	})
+}
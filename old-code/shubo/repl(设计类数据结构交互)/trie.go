@@ -0,0 +1,45 @@
+package main
+
+// 208. 实现 Trie (前缀树)，shubo 的解法，Constructor 改名以免冲突，其余原样拷贝
+
+type Trie struct {
+	Child [26]*Trie
+	IsEnd bool
+}
+
+func NewTrie() Trie {
+	return Trie{}
+}
+
+func (this *Trie) Insert(word string) {
+	cur := this
+	for _, c := range word {
+		if cur.Child[c-'a'] == nil {
+			cur.Child[c-'a'] = &Trie{}
+		}
+		cur = cur.Child[c-'a']
+	}
+	cur.IsEnd = true
+}
+
+func (this *Trie) Search(word string) bool {
+	cur := this
+	for _, c := range word {
+		if cur.Child[c-'a'] == nil {
+			return false
+		}
+		cur = cur.Child[c-'a']
+	}
+	return cur.IsEnd
+}
+
+func (this *Trie) StartsWith(prefix string) bool {
+	cur := this
+	for _, c := range prefix {
+		if cur.Child[c-'a'] == nil {
+			return false
+		}
+		cur = cur.Child[c-'a']
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package main
+
+// Array 带埋点的数组，排序算法只能通过它读写数据，
+// 每次比较、交换、写入都会记成一个事件，SortingVisualizer 按顺序回放即可。
+
+const (
+	OpCompare = "compare"
+	OpSwap    = "swap"
+	OpWrite   = "write"
+)
+
+type Event struct {
+	Op    string `json:"op"`
+	I     int    `json:"i"`
+	J     int    `json:"j"`     // compare/swap 的另一个下标，-1 表示和 value 比较
+	Value int    `json:"value"` // write 写入的值，或者 compare 时数组外的值
+}
+
+type Counters struct {
+	Compares int `json:"compares"`
+	Swaps    int `json:"swaps"`
+	Writes   int `json:"writes"`
+}
+
+type Array struct {
+	nums   []int
+	Events []Event
+	Counters
+}
+
+func NewArray(nums []int) *Array {
+	return &Array{nums: nums}
+}
+
+func (a *Array) Len() int { return len(a.nums) }
+
+// Get 读不算操作，可视化只关心比较和改动
+func (a *Array) Get(i int) int { return a.nums[i] }
+
+// Less nums[i] < nums[j]
+func (a *Array) Less(i, j int) bool {
+	a.Compares++
+	a.Events = append(a.Events, Event{Op: OpCompare, I: i, J: j})
+	return a.nums[i] < a.nums[j]
+}
+
+// LessThan nums[i] < v，和数组外的值（pivot、归并缓冲区）比较时用
+func (a *Array) LessThan(i, v int) bool {
+	a.compareValue(i, v)
+	return a.nums[i] < v
+}
+
+// GreaterThan nums[i] > v
+func (a *Array) GreaterThan(i, v int) bool {
+	a.compareValue(i, v)
+	return a.nums[i] > v
+}
+
+func (a *Array) compareValue(i, v int) {
+	a.Compares++
+	a.Events = append(a.Events, Event{Op: OpCompare, I: i, J: -1, Value: v})
+}
+
+func (a *Array) Swap(i, j int) {
+	a.Swaps++
+	a.Events = append(a.Events, Event{Op: OpSwap, I: i, J: j})
+	a.nums[i], a.nums[j] = a.nums[j], a.nums[i]
+}
+
+func (a *Array) Set(i, v int) {
+	a.Writes++
+	a.Events = append(a.Events, Event{Op: OpWrite, I: i, Value: v})
+	a.nums[i] = v
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// 输出 SortingVisualizer 可以回放的 JSON
+// go run main.go array.go sorts.go -algo quick -input '[5,2,4,6,1,3]'
+// go run main.go array.go sorts.go -algo dutchFlag -pivot 3 -input '[5,2,4,6,1,3]'
+func main() {
+	algo := flag.String("algo", "bubble", "排序 bubble/insertion/merge/quick/heap/counting，或者三路分区 dutchFlag")
+	input := flag.String("input", "[5,2,4,6,1,3]", "LeetCode 格式的整数数组")
+	pivot := flag.Int("pivot", 1, "dutchFlag 的分区值")
+	flag.Parse()
+
+	var nums []int
+	if err := json.Unmarshal([]byte(*input), &nums); err != nil {
+		fmt.Fprintln(os.Stderr, "输入应该是整数数组:", err)
+		os.Exit(1)
+	}
+	var ret *Result
+	var err error
+	if _, ok := Partitions[*algo]; ok {
+		ret, err = RunPartition(*algo, nums, *pivot)
+	} else {
+		ret, err = Run(*algo, nums)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	b, _ := json.Marshal(ret)
+	fmt.Println(string(b))
+}
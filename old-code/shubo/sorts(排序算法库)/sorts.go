@@ -0,0 +1,243 @@
+package main
+
+import "fmt"
+
+// 经典排序算法，全部原地修改 Array，结果升序
+
+var Algorithms = map[string]func(a *Array){
+	"bubble":    BubbleSort,
+	"insertion": InsertionSort,
+	"merge":     MergeSort,
+	"quick":     QuickSort,
+	"heap":      HeapSort,
+	"counting":  CountingSort,
+}
+
+// Partitions 三路分区只保证小于、等于、大于 pivot 的三段有序，不是完整的排序，单独放
+var Partitions = map[string]func(a *Array, pivot int){
+	"dutchFlag": DutchFlag,
+}
+
+// 冒泡：一轮没有交换就提前结束
+func BubbleSort(a *Array) {
+	n := a.Len()
+	for i := 0; i < n-1; i++ {
+		swapped := false
+		for j := 0; j < n-1-i; j++ {
+			if a.Less(j+1, j) {
+				a.Swap(j, j+1)
+				swapped = true
+			}
+		}
+		if !swapped {
+			return
+		}
+	}
+}
+
+// 插入：往前挪，用写入代替交换，和教材上的写法一致
+func InsertionSort(a *Array) {
+	for i := 1; i < a.Len(); i++ {
+		v := a.Get(i)
+		j := i - 1
+		for j >= 0 && a.GreaterThan(j, v) {
+			a.Set(j+1, a.Get(j))
+			j--
+		}
+		if j+1 != i {
+			a.Set(j+1, v)
+		}
+	}
+}
+
+// 归并：左半边拷到缓冲区，再和还在原数组里的右半边归并
+func MergeSort(a *Array) {
+	buf := make([]int, a.Len())
+	var sort func(l, r int)
+	sort = func(l, r int) {
+		if r-l <= 1 {
+			return
+		}
+		mid := (l + r) / 2
+		sort(l, mid)
+		sort(mid, r)
+		n := copy(buf, a.nums[l:mid])
+		i, j, k := 0, mid, l
+		for i < n && j < r {
+			// 右边严格小才取右边，保证稳定
+			if a.LessThan(j, buf[i]) {
+				a.Set(k, a.Get(j))
+				j++
+			} else {
+				a.Set(k, buf[i])
+				i++
+			}
+			k++
+		}
+		for i < n {
+			a.Set(k, buf[i])
+			i++
+			k++
+		}
+	}
+	sort(0, a.Len())
+}
+
+// 快排：Lomuto 分区，取最后一个元素做 pivot
+func QuickSort(a *Array) {
+	var sort func(l, r int)
+	sort = func(l, r int) {
+		if l >= r {
+			return
+		}
+		p := l
+		for i := l; i < r; i++ {
+			if a.Less(i, r) {
+				if i != p {
+					a.Swap(i, p)
+				}
+				p++
+			}
+		}
+		if p != r {
+			a.Swap(p, r)
+		}
+		sort(l, p-1)
+		sort(p+1, r)
+	}
+	sort(0, a.Len()-1)
+}
+
+// 堆排：先建大顶堆，再把堆顶换到末尾
+func HeapSort(a *Array) {
+	n := a.Len()
+	down := func(i, n int) {
+		for {
+			c := 2*i + 1
+			if c >= n {
+				return
+			}
+			if c+1 < n && a.Less(c, c+1) {
+				c++
+			}
+			if !a.Less(i, c) {
+				return
+			}
+			a.Swap(i, c)
+			i = c
+		}
+	}
+	for i := n/2 - 1; i >= 0; i-- {
+		down(i, n)
+	}
+	for end := n - 1; end > 0; end-- {
+		a.Swap(0, end)
+		down(0, end)
+	}
+}
+
+// 计数数组最多这么长，值域再大内存吃不消
+const maxCountingRange = 1 << 20
+
+// 计数：不比较，只写入，适合值域小的数组；值域超过 maxCountingRange 时改用归并
+func CountingSort(a *Array) {
+	if a.Len() == 0 {
+		return
+	}
+	lo, hi := a.Get(0), a.Get(0)
+	for i := 1; i < a.Len(); i++ {
+		lo = min(lo, a.Get(i))
+		hi = max(hi, a.Get(i))
+	}
+	// 按无符号算差值，MinInt64 到 MaxInt64 也不会溢出
+	if uint64(hi)-uint64(lo) >= maxCountingRange {
+		MergeSort(a)
+		return
+	}
+	count := make([]int, hi-lo+1)
+	for i := 0; i < a.Len(); i++ {
+		count[a.Get(i)-lo]++
+	}
+	k := 0
+	for v, c := range count {
+		for ; c > 0; c-- {
+			a.Set(k, v+lo)
+			k++
+		}
+	}
+}
+
+// 荷兰国旗三路分区：< pivot 的放左边，> pivot 的放右边，== pivot 的留在中间
+// 75. 颜色分类 就是 pivot = 1 的情况
+func DutchFlag(a *Array, pivot int) {
+	lt, i, gt := 0, 0, a.Len()-1
+	for i <= gt {
+		switch {
+		case a.LessThan(i, pivot):
+			if i != lt {
+				a.Swap(i, lt)
+			}
+			lt++
+			i++
+		case a.GreaterThan(i, pivot):
+			a.Swap(i, gt)
+			gt--
+		default:
+			i++
+		}
+	}
+}
+
+// Result SortingVisualizer 回放用的 JSON
+type Result struct {
+	Algorithm string  `json:"algorithm"`
+	Input     []int   `json:"input"`
+	Output    []int   `json:"output"`
+	Events    []Event `json:"events"`
+	Counters
+}
+
+// Run 拷贝一份输入再排序，不修改调用方的切片
+func Run(algorithm string, input []int) (*Result, error) {
+	sort, ok := Algorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("未知的排序算法 %q", algorithm)
+	}
+	return run(algorithm, input, sort), nil
+}
+
+// RunPartition 和 Run 一样，输出只保证按 pivot 分成了三段
+func RunPartition(name string, input []int, pivot int) (*Result, error) {
+	partition, ok := Partitions[name]
+	if !ok {
+		return nil, fmt.Errorf("未知的分区算法 %q", name)
+	}
+	return run(name, input, func(a *Array) { partition(a, pivot) }), nil
+}
+
+func run(algorithm string, input []int, f func(a *Array)) *Result {
+	nums := append([]int{}, input...)
+	a := NewArray(nums)
+	f(a)
+	return &Result{
+		Algorithm: algorithm,
+		Input:     append([]int{}, input...),
+		Output:    nums,
+		Events:    a.Events,
+		Counters:  a.Counters,
+	}
+}
+
+func min(a, b int) int {
+	if a > b {
+		return b
+	}
+	return a
+}
+
+func max(a, b int) int {
+	if a < b {
+		return b
+	}
+	return a
+}
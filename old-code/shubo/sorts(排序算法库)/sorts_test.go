@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomInts(r *rand.Rand, n, hi int) []int {
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = r.Intn(hi)
+	}
+	return nums
+}
+
+// 按事件回放，SortingVisualizer 做的就是这件事
+func replay(input []int, events []Event) []int {
+	nums := append([]int{}, input...)
+	for _, e := range events {
+		switch e.Op {
+		case OpSwap:
+			nums[e.I], nums[e.J] = nums[e.J], nums[e.I]
+		case OpWrite:
+			nums[e.I] = e.Value
+		}
+	}
+	return nums
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAlgorithms(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for name := range Algorithms {
+		for _, n := range []int{0, 1, 2, 3, 10, 100} {
+			input := randomInts(r, n, 50)
+			ret, err := Run(name, input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := append([]int{}, input...)
+			sort.Ints(want)
+			if !equal(ret.Output, want) {
+				t.Fatalf("%s(%v) = %v", name, input, ret.Output)
+			}
+			if got := replay(ret.Input, ret.Events); !equal(got, want) {
+				t.Fatalf("%s 回放结果 %v, 期望 %v", name, got, want)
+			}
+			var c Counters
+			for _, e := range ret.Events {
+				switch e.Op {
+				case OpCompare:
+					c.Compares++
+				case OpSwap:
+					c.Swaps++
+				case OpWrite:
+					c.Writes++
+				}
+			}
+			if c != ret.Counters {
+				t.Fatalf("%s 计数 %+v 和事件 %+v 对不上", name, ret.Counters, c)
+			}
+		}
+	}
+}
+
+func TestCounters(t *testing.T) {
+	sorted := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	// 有序数组：冒泡一轮就结束，插入排序不需要写入
+	if ret, _ := Run("bubble", sorted); ret.Compares != 7 || ret.Swaps != 0 {
+		t.Fatalf("%+v", ret.Counters)
+	}
+	if ret, _ := Run("insertion", sorted); ret.Compares != 7 || ret.Writes != 0 {
+		t.Fatalf("%+v", ret.Counters)
+	}
+	if ret, _ := Run("counting", sorted); ret.Compares != 0 || ret.Writes != 8 {
+		t.Fatalf("%+v", ret.Counters)
+	}
+	// 逆序数组：冒泡交换次数等于逆序对数
+	if ret, _ := Run("bubble", []int{5, 4, 3, 2, 1}); ret.Swaps != 10 {
+		t.Fatalf("%+v", ret.Counters)
+	}
+	if _, err := Run("bogo", sorted); err == nil {
+		t.Fatal("未知算法应该报错")
+	}
+}
+
+// TestCountingSortRange 值域很大时不能按值域开数组
+func TestCountingSortRange(t *testing.T) {
+	for _, input := range [][]int{
+		{math.MaxInt64, math.MinInt64},
+		{3, -1 << 40, 1 << 40, 0},
+		{maxCountingRange - 1, 0, 5},
+	} {
+		ret, _ := Run("counting", input)
+		want := append([]int{}, input...)
+		sort.Ints(want)
+		if !equal(ret.Output, want) || !equal(replay(ret.Input, ret.Events), want) {
+			t.Fatalf("%v -> %v", input, ret.Output)
+		}
+	}
+}
+
+// TestPartition 分区后是原数组的排列，小于、等于、大于 pivot 的依次排开
+func TestPartition(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		input := randomInts(r, r.Intn(20), 10)
+		pivot := r.Intn(12) - 1
+		ret, err := RunPartition("dutchFlag", input, pivot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, want := append([]int{}, ret.Output...), append([]int{}, input...)
+		sort.Ints(got)
+		sort.Ints(want)
+		if !equal(got, want) || !equal(replay(ret.Input, ret.Events), ret.Output) {
+			t.Fatalf("%v -> %v", input, ret.Output)
+		}
+		part := 0
+		for _, v := range ret.Output {
+			p := 1
+			if v < pivot {
+				p = 0
+			} else if v > pivot {
+				p = 2
+			}
+			if p < part {
+				t.Fatalf("pivot %d: %v -> %v", pivot, input, ret.Output)
+			}
+			part = p
+		}
+	}
+	if _, err := RunPartition("quick", nil, 0); err == nil {
+		t.Fatal("排序算法不是分区算法")
+	}
+	if _, err := Run("dutchFlag", nil); err == nil {
+		t.Fatal("分区算法不是排序算法")
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	ret, _ := RunPartition("dutchFlag", []int{2, 0, 1}, 1)
+	b, _ := json.Marshal(ret)
+	want := `{"algorithm":"dutchFlag","input":[2,0,1],"output":[0,1,2],"events":[` +
+		`{"op":"compare","i":0,"j":-1,"value":1},{"op":"compare","i":0,"j":-1,"value":1},{"op":"swap","i":0,"j":2,"value":0},` +
+		`{"op":"compare","i":0,"j":-1,"value":1},{"op":"compare","i":0,"j":-1,"value":1},` +
+		`{"op":"compare","i":1,"j":-1,"value":1},{"op":"swap","i":1,"j":0,"value":0}],` +
+		`"compares":5,"swaps":2,"writes":0}`
+	if string(b) != want {
+		t.Fatalf("got  %s\nwant %s", b, want)
+	}
+}
+
+// 75. 颜色分类 songzhibin97 的解法，去掉了最后的 fmt.Println(nums)，其余原样
+func sortColorsSongzhibin97(nums []int) {
+	start, end := 0, len(nums)-1
+	for i := 0; i <= end; i++ {
+		if nums[i] == 0 {
+			nums[start], nums[i] = nums[i], nums[start]
+			start++
+		} else if nums[i] == 2 {
+			nums[end], nums[i] = nums[i], nums[end]
+			end--
+			i--
+		}
+	}
+}
+
+// 75. 颜色分类 shubo 的解法
+func sortColorsShubo(nums []int) {
+	i := 0
+	j := len(nums) - 1
+	for i < j {
+		if nums[i] == 0 {
+			i++
+			continue
+		}
+		if nums[j] == 2 {
+			j--
+			continue
+		}
+
+		if nums[i] > nums[j] {
+			nums[i], nums[j] = nums[j], nums[i]
+		} else { // 1,1 1,2 . 找0然后交换
+			for k := i; k < j; k++ {
+				if nums[k] == 0 {
+					nums[i], nums[k] = nums[k], nums[i]
+				}
+			}
+			i++
+		}
+	}
+}
+
+func TestSortColors(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		input := randomInts(r, r.Intn(20), 3)
+		ret, _ := RunPartition("dutchFlag", input, 1)
+		for name, f := range map[string]func([]int){
+			"songzhibin97": sortColorsSongzhibin97,
+			"shubo":        sortColorsShubo,
+		} {
+			nums := append([]int{}, input...)
+			f(nums)
+			if !equal(nums, ret.Output) {
+				t.Fatalf("%s sortColors(%v) = %v, 期望 %v", name, input, nums, ret.Output)
+			}
+		}
+	}
+}
+
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// 148. 排序链表 shubo 的归并解法，sortList(排序链表) 里的四个函数原样拷过来，测试函数没拷
+
+func sortList(head *ListNode) *ListNode {
+
+	return mergeSort(head)
+}
+
+// 先使用快慢指针找到mid
+// 递归二分
+//
+//	递归结束条件: 分到了最后一轮，即head没有后继
+//	比较左右分区的头节点值
+//	合并左右分区两个有序链表
+func dividedListNode(head *ListNode) (l, r *ListNode) {
+	slow := head
+	midPrev := head
+	fast := head
+	for fast != nil && fast.Next != nil {
+		midPrev = slow
+		slow = slow.Next
+		fast = fast.Next.Next
+	}
+	midPrev.Next = nil
+	return head, slow
+}
+func mergeSort(head *ListNode) *ListNode {
+	if head == nil || head.Next == nil {
+		return head
+	}
+	le, ri := dividedListNode(head)
+	l := mergeSort(le)
+	r := mergeSort(ri)
+	return mergeOrderedList(r, l)
+}
+
+// 合并两个有序链表
+func mergeOrderedList(list1, list2 *ListNode) *ListNode {
+	if list1 == nil {
+		return list2
+	}
+	if list2 == nil {
+		return list1
+	}
+	if list1.Val < list2.Val {
+		list1.Next = mergeOrderedList(list1.Next, list2)
+		return list1
+	} else {
+		list2.Next = mergeOrderedList(list1, list2.Next)
+		return list2
+	}
+}
+
+func TestSortList(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 200; i++ {
+		input := randomInts(r, r.Intn(30), 100)
+		dummy := &ListNode{}
+		cur := dummy
+		for _, v := range input {
+			cur.Next = &ListNode{Val: v}
+			cur = cur.Next
+		}
+		var got []int
+		for n := sortList(dummy.Next); n != nil; n = n.Next {
+			got = append(got, n.Val)
+		}
+		ret, _ := Run("merge", input)
+		if !equal(got, ret.Output) {
+			t.Fatalf("sortList(%v) = %v, 期望 %v", input, got, ret.Output)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../hot100(命令行)/trace.go ../hot100(命令行)/trap.go ../hot100(命令行)/dailyTemperatures.go ../hot100(命令行)/captions.go
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../monotonic(单调栈)/stack.go
+
+// 根据题解的执行轨迹生成分镜脚本
+// go run $(ls *.go | grep -v _test) -trace testdata/trap.json -format md
+func main() {
+	tracePath := flag.String("trace", "", "trace 文件路径")
+	metaPath := flag.String("meta", "../../../docs/leetcode-hot-100.json", "题目元数据")
+	tplPath := flag.String("templates", "", "额外的文案模板（JSON），覆盖内置文案")
+	format := flag.String("format", "json", "输出格式：json 或 md")
+	flag.Parse()
+
+	if *tracePath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	tr, err := LoadTrace(*tracePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "读取 trace 失败:", err)
+		os.Exit(1)
+	}
+	problems, err := LoadProblems(*metaPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "读取题目元数据失败:", err)
+		os.Exit(1)
+	}
+	p, ok := problems[tr.QuestionID]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "题目 %s 不在 hot 100 列表里\n", tr.QuestionID)
+		os.Exit(1)
+	}
+	tpl, err := LoadTemplates(*tplPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "读取文案模板失败:", err)
+		os.Exit(1)
+	}
+
+	sb := Build(tr, p, tpl)
+	switch *format {
+	case "md":
+		fmt.Print(sb.Markdown())
+	default:
+		b, _ := json.MarshalIndent(sb, "", "  ")
+		fmt.Println(string(b))
+	}
+}
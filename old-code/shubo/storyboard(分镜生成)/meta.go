@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// docs/leetcode-hot-100.json 的题目元数据，只取用得到的字段
+// coverage、diff、judge 里的 meta.go 是从这里 go generate 拷贝的，改完要重新生成
+
+type Problem struct {
+	QuestionFrontendID string `json:"questionFrontendId"`
+	Title              string `json:"title"`
+	TitleSlug          string `json:"titleSlug"`
+	TranslatedTitle    string `json:"translatedTitle"`
+	Difficulty         string `json:"difficulty"`
+	TopicTags          []struct {
+		Name           string `json:"name"`
+		Slug           string `json:"slug"` // coverage 按标签汇总时用
+		NameTranslated string `json:"nameTranslated"`
+	} `json:"topicTags"`
+}
+
+type hot100 struct {
+	Data struct {
+		FavoriteQuestionList struct {
+			Questions []Problem `json:"questions"`
+		} `json:"favoriteQuestionList"`
+	} `json:"data"`
+}
+
+// LoadProblems 读取题目列表，按 questionFrontendId 建索引
+func LoadProblems(path string) (map[string]Problem, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var h hot100
+	if err := json.Unmarshal(b, &h); err != nil {
+		return nil, err
+	}
+	m := make(map[string]Problem, len(h.Data.FavoriteQuestionList.Questions))
+	for _, q := range h.Data.FavoriteQuestionList.Questions {
+		m[q.QuestionFrontendID] = q
+	}
+	return m, nil
+}
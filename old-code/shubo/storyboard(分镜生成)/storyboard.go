@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 分镜阶段，对应 docs/每道题目演示动画的SOP.md 里的 初始化 -> 关键迭代 -> 结果
+const (
+	PhaseSetup     = "setup"
+	PhaseIteration = "iteration"
+	PhaseResult    = "result"
+)
+
+var phaseName = map[string]Caption{
+	PhaseSetup:     {Zh: "初始化", En: "Setup"},
+	PhaseIteration: {Zh: "关键迭代", En: "Key iteration"},
+	PhaseResult:    {Zh: "结果", En: "Result"},
+}
+
+type Storyboard struct {
+	QuestionID      string  `json:"questionId"`
+	Title           string  `json:"title"`
+	TranslatedTitle string  `json:"translatedTitle"`
+	TitleSlug       string  `json:"titleSlug"`
+	Difficulty      string  `json:"difficulty"`
+	Variant         string  `json:"variant"`
+	Input           string  `json:"input"`
+	Scenes          []Scene `json:"scenes"`
+}
+
+type Scene struct {
+	ID       string                 `json:"id"` // 镜头编号，从 01 开始
+	Phase    string                 `json:"phase"`
+	From, To int                    `json:"-"`
+	Steps    [2]int                 `json:"steps"` // 覆盖的 trace 步骤区间 [from, to]
+	Captions []Caption              `json:"captions"`
+	Focus    []string               `json:"focus,omitempty"` // 视觉焦点：这几步里变化过的数据
+	State    map[string]interface{} `json:"state,omitempty"` // 镜头结束时的数据快照
+}
+
+// Build 把 trace 的事件按 初始化/关键迭代/结果 分组成镜头
+// 标了 Key 的 step 单独成镜，相邻的非关键 step 合并成一个镜头；
+// 一个 Key 都没有标的 trace，每一步都算关键步骤。
+func Build(tr *Trace, p Problem, tpl Templates) *Storyboard {
+	sb := &Storyboard{
+		QuestionID:      tr.QuestionID,
+		Title:           p.Title,
+		TranslatedTitle: p.TranslatedTitle,
+		TitleSlug:       p.TitleSlug,
+		Difficulty:      p.Difficulty,
+		Variant:         tr.Variant,
+		Input:           tr.Input,
+	}
+	anyKey := false
+	for _, e := range tr.Events {
+		if e.Kind == KindStep && e.Key {
+			anyKey = true
+			break
+		}
+	}
+
+	var cur *Scene
+	var skipped int
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		if skipped > 1 {
+			cur.Captions = append(cur.Captions, tpl.Render("skipped", map[string]interface{}{"count": skipped - 1}))
+		}
+		cur.Steps = [2]int{cur.From, cur.To}
+		sort.Strings(cur.Focus)
+		sb.Scenes = append(sb.Scenes, *cur)
+		cur = nil
+		skipped = 0
+	}
+	open := func(phase string, e Event) {
+		flush()
+		cur = &Scene{Phase: phase, From: e.Step}
+	}
+	add := func(e Event, caption bool) {
+		cur.To = e.Step
+		if caption {
+			cur.Captions = append(cur.Captions, tpl.Render(e.Message, e.Args))
+		}
+		for _, c := range e.Changed {
+			if !contains(cur.Focus, c) {
+				cur.Focus = append(cur.Focus, c)
+			}
+		}
+		if e.State != nil {
+			cur.State = e.State
+		}
+	}
+
+	for _, e := range tr.Events {
+		switch e.Kind {
+		case KindInit:
+			if cur == nil || cur.Phase != PhaseSetup {
+				open(PhaseSetup, e)
+			}
+			add(e, true)
+		case KindStep:
+			if e.Key || !anyKey {
+				open(PhaseIteration, e)
+				add(e, true)
+				flush()
+				continue
+			}
+			// 非关键步骤：只保留第一步的文案，其余计数
+			if cur == nil || cur.Phase != PhaseIteration {
+				open(PhaseIteration, e)
+			}
+			add(e, skipped == 0)
+			skipped++
+		case KindResult:
+			open(PhaseResult, e)
+			add(e, true)
+		}
+	}
+	flush()
+
+	for i := range sb.Scenes {
+		sb.Scenes[i].ID = fmt.Sprintf("%02d", i+1)
+	}
+	return sb
+}
+
+func contains(arr []string, s string) bool {
+	for _, v := range arr {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown 按 docs/生成分镜的提示词.md 的镜头模板输出，动画同学在此基础上补充细节
+func (sb *Storyboard) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s. %s / %s\n\n", sb.QuestionID, sb.TranslatedTitle, sb.Title)
+	if sb.TitleSlug != "" {
+		fmt.Fprintf(&b, "- 题目：https://leetcode.cn/problems/%s/\n", sb.TitleSlug)
+	}
+	if sb.Difficulty != "" {
+		fmt.Fprintf(&b, "- 难度：%s\n", sb.Difficulty)
+	}
+	fmt.Fprintf(&b, "- 解法：%s\n", sb.Variant)
+	fmt.Fprintf(&b, "- 输入：`%s`\n\n", sb.Input)
+
+	for _, s := range sb.Scenes {
+		fmt.Fprintf(&b, "### [%s]-[%s]-[%s]\n\n", s.ID, sb.Variant, phaseName[s.Phase].Zh)
+		if s.From == s.To {
+			fmt.Fprintf(&b, "步骤：%d\n\n", s.From)
+		} else {
+			fmt.Fprintf(&b, "步骤：%d ~ %d\n\n", s.From, s.To)
+		}
+		b.WriteString("**视觉焦点**  \n")
+		if len(s.Focus) == 0 {
+			b.WriteString("- 画布元素：无变化\n")
+		} else {
+			fmt.Fprintf(&b, "- 画布元素：%s\n", strings.Join(s.Focus, "、"))
+		}
+		for _, k := range sortedKeys(s.State) {
+			fmt.Fprintf(&b, "- `%s` = %v\n", k, s.State[k])
+		}
+		b.WriteString("\n**注释系统**  \n")
+		for _, c := range s.Captions {
+			fmt.Fprintf(&b, "- 中文：%s\n", c.Zh)
+			fmt.Fprintf(&b, "- English: %s\n", c.En)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
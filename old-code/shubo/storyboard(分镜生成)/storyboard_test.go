@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// mustTraceTrap 用 hot100 埋了点的题解（trap.go 是生成的拷贝），和网页动画、命令行播放器是同一份 trace
+func mustTraceTrap(t *testing.T, height []int) *Trace {
+	b, _ := json.Marshal(height)
+	tr, err := traceTrap(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestBuild(t *testing.T) {
+	tr := mustTraceTrap(t, []int{0, 1, 0, 2, 1, 0, 1, 3, 2, 1, 2, 1})
+	sb := Build(tr, Problem{QuestionFrontendID: "42", TranslatedTitle: "接雨水"}, defaultTemplates)
+
+	if sb.Scenes[0].Phase != PhaseSetup || sb.Scenes[len(sb.Scenes)-1].Phase != PhaseResult {
+		t.Fatalf("首尾镜头应该是初始化和结果: %+v", sb.Scenes)
+	}
+	last := sb.Scenes[len(sb.Scenes)-1]
+	if last.Captions[0].Zh != "两指针相遇，一共接了 6 个单位的雨水" || last.Captions[0].En != "pointers meet, 6 units of water trapped" {
+		t.Fatalf("结果文案不对: %+v", last.Captions)
+	}
+	// 接到水的 5 步单独成镜，其余步骤合并
+	key := 0
+	covered := 0
+	for _, s := range sb.Scenes {
+		covered += s.Steps[1] - s.Steps[0] + 1
+		if s.Phase == PhaseIteration && len(s.Captions) == 1 && strings.Contains(s.Captions[0].Zh, "接水") && !strings.Contains(s.Captions[0].Zh, "接水 0") {
+			key++
+		}
+	}
+	if key != 5 {
+		t.Fatalf("关键镜头数 = %d, 期望 5", key)
+	}
+	if covered != len(tr.Events) {
+		t.Fatalf("镜头覆盖了 %d 步, trace 一共 %d 步", covered, len(tr.Events))
+	}
+	for i, s := range sb.Scenes {
+		if i > 0 && s.Steps[0] != sb.Scenes[i-1].Steps[1]+1 {
+			t.Fatalf("镜头 %s 和上一个镜头不连续", s.ID)
+		}
+	}
+}
+
+// 739 的每一步都要有文案，不能把 daily.* 的 key 原样打出来
+func TestBuildDaily(t *testing.T) {
+	tr, err := traceDailyTemperatures("[73,74,75,71,69,72,76,73]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb := Build(tr, Problem{QuestionFrontendID: "739", TranslatedTitle: "每日温度"}, defaultTemplates)
+	for _, s := range sb.Scenes {
+		for _, c := range s.Captions {
+			if strings.HasPrefix(c.Zh, "daily.") || strings.HasPrefix(c.En, "daily.") {
+				t.Fatalf("镜头 %s 没有文案: %+v", s.ID, c)
+			}
+		}
+	}
+	last := sb.Scenes[len(sb.Scenes)-1]
+	if last.Phase != PhaseResult || last.Captions[0].Zh != "栈里剩下的日子之后都不会升温，答案是 [1,1,4,2,1,1,0,0]" {
+		t.Fatalf("结果文案不对: %+v", last)
+	}
+	// 出栈是关键步骤，8 天里有 6 天找到了更高的温度
+	key := 0
+	for _, s := range sb.Scenes {
+		if len(s.Captions) == 1 && strings.Contains(s.Captions[0].Zh, "出栈") {
+			key++
+		}
+	}
+	if key != 6 {
+		t.Fatalf("出栈镜头数 = %d, 期望 6", key)
+	}
+}
+
+func TestBuildWithoutKey(t *testing.T) {
+	tr := mustTraceTrap(t, []int{4, 2, 3})
+	for i := range tr.Events {
+		tr.Events[i].Key = false
+	}
+	sb := Build(tr, Problem{}, defaultTemplates)
+	// 没标关键步骤时，每一步都单独成镜
+	if len(sb.Scenes) != len(tr.Events) {
+		t.Fatalf("镜头数 = %d, 期望 %d", len(sb.Scenes), len(tr.Events))
+	}
+}
+
+func TestRenderMissingTemplate(t *testing.T) {
+	c := defaultTemplates.Render("unknown.key", nil)
+	if c.Zh != "unknown.key" || c.En != "unknown.key" {
+		t.Fatal(c)
+	}
+}
+
+// TestTestdata testdata/trap.json 要和 traceTrap 的输出完全一样，埋点改了用 UPDATE_TESTDATA=1 go test 重新生成
+func TestTestdata(t *testing.T) {
+	want, _ := json.MarshalIndent(mustTraceTrap(t, []int{0, 1, 0, 2, 1, 0, 1, 3, 2, 1, 2, 1}), "", "  ")
+	want = append(want, '\n')
+	if os.Getenv("UPDATE_TESTDATA") != "" {
+		if err := os.WriteFile("testdata/trap.json", want, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := os.ReadFile("testdata/trap.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("testdata/trap.json 过期了，用 UPDATE_TESTDATA=1 go test 重新生成")
+	}
+	tr, err := LoadTrace("testdata/trap.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := Build(tr, Problem{TranslatedTitle: "接雨水", Title: "Trapping Rain Water"}, defaultTemplates).Markdown()
+	if !strings.Contains(md, "### [01]-[双指针]-[初始化]") {
+		t.Fatal(md)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 双语文案模板，Caption 和题目的文案在 captions.go 里，从 hot100(命令行) 拷贝，命令行播放器用的是同一份
+
+type Templates map[string]Caption
+
+// 内置文案：通用的几条加上 captions.go 里每道题的文案，题目的文案以简称加点开头，比如 trap.、daily.
+var defaultTemplates = func() Templates {
+	t := Templates{
+		"setup":   {Zh: "初始化", En: "Setup"},
+		"result":  {Zh: "返回结果 {result}", En: "Return {result}"},
+		"skipped": {Zh: "省略 {count} 步", En: "{count} steps omitted"},
+	}
+	for k, v := range captions {
+		t[k] = v
+	}
+	return t
+}()
+
+// LoadTemplates 读取外部文案，覆盖同名的内置文案
+func LoadTemplates(path string) (Templates, error) {
+	t := Templates{}
+	for k, v := range defaultTemplates {
+		t[k] = v
+	}
+	if path == "" {
+		return t, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ext Templates
+	if err := json.Unmarshal(b, &ext); err != nil {
+		return nil, err
+	}
+	for k, v := range ext {
+		t[k] = v
+	}
+	return t, nil
+}
+
+// Render 用参数填充模板，找不到模板时原样返回 key，方便发现漏写的文案
+func (t Templates) Render(key string, args map[string]interface{}) Caption {
+	c, ok := t[key]
+	if !ok {
+		return Caption{Zh: key, En: key}
+	}
+	return Caption{Zh: fill(c.Zh, args), En: fill(c.En, args)}
+}
+
+func fill(s string, args map[string]interface{}) string {
+	for k, v := range args {
+		s = strings.ReplaceAll(s, "{"+k+"}", fmt.Sprint(v))
+	}
+	return s
+}
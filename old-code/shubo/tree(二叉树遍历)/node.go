@@ -0,0 +1,8 @@
+package tree
+
+// traverse.go 里不定义节点类型，题解目录用 copygen 拷过去时用题解自己的 TreeNode
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
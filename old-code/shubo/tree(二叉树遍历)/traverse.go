@@ -0,0 +1,185 @@
+package tree
+
+import "iter"
+
+// 二叉树遍历的迭代器，题解目录用 copygen 拷一份，直接 for node := range InOrder(root) 就行
+// 产出的是节点指针，循环体里可以改 Val；break 会提前结束遍历。
+// 每种顺序都有递归和显式栈两种实现，结果一样，显式栈的不怕树很深。
+
+// PreOrder 先序：根 左 右，递归
+func PreOrder(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		var walk func(n *TreeNode) bool
+		walk = func(n *TreeNode) bool {
+			if n == nil {
+				return true
+			}
+			// 先记住孩子，循环体里改了 Left/Right 也不影响遍历，114 题要用
+			left, right := n.Left, n.Right
+			return yield(n) && walk(left) && walk(right)
+		}
+		walk(root)
+	}
+}
+
+// InOrder 中序：左 根 右，递归
+func InOrder(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		var walk func(n *TreeNode) bool
+		walk = func(n *TreeNode) bool {
+			return n == nil || walk(n.Left) && yield(n) && walk(n.Right)
+		}
+		walk(root)
+	}
+}
+
+// PostOrder 后序：左 右 根，递归
+func PostOrder(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		var walk func(n *TreeNode) bool
+		walk = func(n *TreeNode) bool {
+			return n == nil || walk(n.Left) && walk(n.Right) && yield(n)
+		}
+		walk(root)
+	}
+}
+
+// ReverseInOrder 反向中序：右 根 左，二叉搜索树上就是从大到小，538 题用
+func ReverseInOrder(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		var walk func(n *TreeNode) bool
+		walk = func(n *TreeNode) bool {
+			return n == nil || walk(n.Right) && yield(n) && walk(n.Left)
+		}
+		walk(root)
+	}
+}
+
+// PreOrderIter 先序，显式栈；孩子在 yield 之前就入栈了
+func PreOrderIter(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		if root == nil {
+			return
+		}
+		stack := []*TreeNode{root}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if n.Right != nil {
+				stack = append(stack, n.Right)
+			}
+			if n.Left != nil {
+				stack = append(stack, n.Left)
+			}
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// InOrderIter 中序，显式栈：一路向左入栈，出栈时访问，再转向右子树
+func InOrderIter(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		var stack []*TreeNode
+		cur := root
+		for cur != nil || len(stack) > 0 {
+			for cur != nil {
+				stack = append(stack, cur)
+				cur = cur.Left
+			}
+			cur = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			right := cur.Right
+			if !yield(cur) {
+				return
+			}
+			cur = right
+		}
+	}
+}
+
+// PostOrderIter 后序，显式栈：右子树访问完（或者没有）才访问根，用 prev 记上一个访问的节点
+func PostOrderIter(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		var stack []*TreeNode
+		var prev *TreeNode
+		cur := root
+		for cur != nil || len(stack) > 0 {
+			for cur != nil {
+				stack = append(stack, cur)
+				cur = cur.Left
+			}
+			top := stack[len(stack)-1]
+			if top.Right != nil && top.Right != prev {
+				cur = top.Right
+				continue
+			}
+			stack = stack[:len(stack)-1]
+			if !yield(top) {
+				return
+			}
+			prev = top
+		}
+	}
+}
+
+// Morris 中序遍历，额外空间 O(1)
+// 借用前驱节点空着的 Right 指针指回当前节点，第二次走到时再改回来。
+// 遍历过程中树是被临时修改过的：循环体里不要改树的结构，也不要并发读这棵树；
+// break 之后会把剩下的路走完（不再 yield），保证树恢复原样。
+func Morris(root *TreeNode) iter.Seq[*TreeNode] {
+	return func(yield func(*TreeNode) bool) {
+		done := false
+		cur := root
+		for cur != nil {
+			if cur.Left == nil {
+				if !done && !yield(cur) {
+					done = true
+				}
+				cur = cur.Right
+				continue
+			}
+			pre := cur.Left
+			for pre.Right != nil && pre.Right != cur {
+				pre = pre.Right
+			}
+			if pre.Right == nil {
+				pre.Right = cur // 第一次到：穿线，去左子树
+				cur = cur.Left
+				continue
+			}
+			pre.Right = nil // 第二次到：左子树走完了，拆线
+			if !done && !yield(cur) {
+				done = true
+			}
+			cur = cur.Right
+		}
+	}
+}
+
+// Levels 层序遍历，一次产出一层；产出的切片下一层会复用，需要保留就拷贝一份
+func Levels(root *TreeNode) iter.Seq[[]*TreeNode] {
+	return func(yield func([]*TreeNode) bool) {
+		if root == nil {
+			return
+		}
+		level := []*TreeNode{root}
+		var next []*TreeNode
+		for len(level) > 0 {
+			next = next[:0]
+			for _, n := range level {
+				if n.Left != nil {
+					next = append(next, n.Left)
+				}
+				if n.Right != nil {
+					next = append(next, n.Right)
+				}
+			}
+			if !yield(level) {
+				return
+			}
+			level, next = next, level
+		}
+	}
+}
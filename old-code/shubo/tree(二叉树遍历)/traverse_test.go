@@ -0,0 +1,217 @@
+package tree
+
+import (
+	"fmt"
+	"iter"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// randomTree n 个节点、形状随机的树，值是 0 ~ n-1 的一个排列
+func randomTree(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	vals := r.Perm(n)
+	root := &TreeNode{Val: vals[0]}
+	nodes := []*TreeNode{root}
+	for _, v := range vals[1:] {
+		for {
+			p := nodes[r.Intn(len(nodes))]
+			if r.Intn(2) == 0 && p.Left == nil {
+				p.Left = &TreeNode{Val: v}
+				nodes = append(nodes, p.Left)
+				break
+			}
+			if p.Right == nil {
+				p.Right = &TreeNode{Val: v}
+				nodes = append(nodes, p.Right)
+				break
+			}
+		}
+	}
+	return root
+}
+
+// shape 带空节点的先序序列，比较树有没有被改动
+func shape(n *TreeNode) string {
+	if n == nil {
+		return "#"
+	}
+	return fmt.Sprintf("%d(%s,%s)", n.Val, shape(n.Left), shape(n.Right))
+}
+
+func vals(seq iter.Seq[*TreeNode]) []int {
+	ret := []int{}
+	for n := range seq {
+		ret = append(ret, n.Val)
+	}
+	return ret
+}
+
+func reference(root *TreeNode, order string) []int {
+	ret := []int{}
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		if n == nil {
+			return
+		}
+		if order == "pre" {
+			ret = append(ret, n.Val)
+		}
+		if order == "reverse" {
+			walk(n.Right)
+		} else {
+			walk(n.Left)
+		}
+		if order == "in" || order == "reverse" {
+			ret = append(ret, n.Val)
+		}
+		if order == "reverse" {
+			walk(n.Left)
+		} else {
+			walk(n.Right)
+		}
+		if order == "post" {
+			ret = append(ret, n.Val)
+		}
+	}
+	walk(root)
+	return ret
+}
+
+var traversals = []struct {
+	name  string
+	order string
+	seq   func(*TreeNode) iter.Seq[*TreeNode]
+}{
+	{"PreOrder", "pre", PreOrder},
+	{"PreOrderIter", "pre", PreOrderIter},
+	{"InOrder", "in", InOrder},
+	{"InOrderIter", "in", InOrderIter},
+	{"Morris", "in", Morris},
+	{"PostOrder", "post", PostOrder},
+	{"PostOrderIter", "post", PostOrderIter},
+	{"ReverseInOrder", "reverse", ReverseInOrder},
+}
+
+func TestTraversals(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		root := randomTree(r, r.Intn(30))
+		before := shape(root)
+		for _, tr := range traversals {
+			want := reference(root, tr.order)
+			if got := vals(tr.seq(root)); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s %s: %v, 期望 %v", tr.name, before, got, want)
+			}
+			// 每个位置都试一次 break
+			for k := 1; k <= len(want); k++ {
+				var got []int
+				for n := range tr.seq(root) {
+					got = append(got, n.Val)
+					if len(got) == k {
+						break
+					}
+				}
+				if !reflect.DeepEqual(got, want[:k]) {
+					t.Fatalf("%s 在第 %d 个 break: %v", tr.name, k, got)
+				}
+				if after := shape(root); after != before {
+					t.Fatalf("%s break 之后树被改了\n%s\n%s", tr.name, before, after)
+				}
+			}
+		}
+	}
+}
+
+func TestLevels(t *testing.T) {
+	//     3
+	//    / \
+	//   9  20
+	//     /  \
+	//    15   7
+	root := &TreeNode{3, &TreeNode{Val: 9}, &TreeNode{20, &TreeNode{Val: 15}, &TreeNode{Val: 7}}}
+	var got [][]int
+	for level := range Levels(root) {
+		var vs []int
+		for _, n := range level {
+			vs = append(vs, n.Val)
+		}
+		got = append(got, vs)
+	}
+	if !reflect.DeepEqual(got, [][]int{{3}, {9, 20}, {15, 7}}) {
+		t.Fatal(got)
+	}
+	for range Levels(nil) {
+		t.Fatal("空树没有层")
+	}
+	n := 0
+	for range Levels(root) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Fatal(n)
+	}
+}
+
+// Morris 只有迭代器闭包本身的几次分配，次数和树的大小无关；显式栈的中序要给栈分配空间，树越深分配越多
+func TestMorrisAllocs(t *testing.T) {
+	chain := func(n int) *TreeNode {
+		var root *TreeNode
+		for i := 0; i < n; i++ {
+			root = &TreeNode{Val: i, Left: root}
+		}
+		return root
+	}
+	count := func(seq func(*TreeNode) iter.Seq[*TreeNode], root *TreeNode) float64 {
+		return testing.AllocsPerRun(10, func() {
+			sum := 0
+			for n := range seq(root) {
+				sum += n.Val
+			}
+		})
+	}
+	small, large := count(Morris, chain(10)), count(Morris, chain(10000))
+	if small != large {
+		t.Fatalf("Morris 分配次数 %v / %v", small, large)
+	}
+	if stack := count(InOrderIter, chain(10000)); stack <= large {
+		t.Fatalf("显式栈分配次数 %v 应该比 Morris 多", stack)
+	}
+}
+
+// 很深的树：显式栈和 Morris 都能遍历
+func TestDeepTree(t *testing.T) {
+	var root *TreeNode
+	for i := 0; i < 1000000; i++ {
+		root = &TreeNode{Val: i, Right: root}
+	}
+	for _, seq := range []func(*TreeNode) iter.Seq[*TreeNode]{PreOrderIter, InOrderIter, PostOrderIter, Morris} {
+		n := 0
+		for range seq(root) {
+			n++
+		}
+		if n != 1000000 {
+			t.Fatal(n)
+		}
+	}
+}
+
+func BenchmarkInOrder(b *testing.B) {
+	root := randomTree(rand.New(rand.NewSource(1)), 10000)
+	for _, tr := range traversals {
+		if tr.order != "in" {
+			continue
+		}
+		b.Run(tr.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for range tr.seq(root) {
+				}
+			}
+		})
+	}
+}
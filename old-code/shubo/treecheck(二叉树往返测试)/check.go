@@ -0,0 +1,261 @@
+package treecheck
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// 二叉树重建和序列化的往返性质测试
+// 随机生成节点值互不相同的树，求出遍历序列，交给被测的实现重建，要求和原树结构完全一样；
+// 序列化则要求 解析(序列化(树)) == 树，并且 序列化 -> 解析 -> 序列化 得到同一个字符串。
+// 树的大小从 0 开始递增，报告的反例是最先找到的最小的那棵树。
+
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+// Random n 个节点、形状随机的树，值是 1 ~ n 的一个排列
+func Random(r *rand.Rand, n int) *TreeNode {
+	if n == 0 {
+		return nil
+	}
+	vals := r.Perm(n)
+	root := &TreeNode{Val: vals[0] + 1}
+	nodes := []*TreeNode{root}
+	for _, v := range vals[1:] {
+		for {
+			p := nodes[r.Intn(len(nodes))]
+			if r.Intn(2) == 0 && p.Left == nil {
+				p.Left = &TreeNode{Val: v + 1}
+				nodes = append(nodes, p.Left)
+				break
+			}
+			if p.Right == nil {
+				p.Right = &TreeNode{Val: v + 1}
+				nodes = append(nodes, p.Right)
+				break
+			}
+		}
+	}
+	return root
+}
+
+func Equal(a, b *TreeNode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Val == b.Val && Equal(a.Left, b.Left) && Equal(a.Right, b.Right)
+}
+
+// 先序、中序、后序的值，check.go 会拷进被测目录，名字避开题解目录里 traverse_test.go 的迭代器
+func preorderValues(root *TreeNode) []int {
+	ret := []int{}
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		if n != nil {
+			ret = append(ret, n.Val)
+			walk(n.Left)
+			walk(n.Right)
+		}
+	}
+	walk(root)
+	return ret
+}
+
+func inorderValues(root *TreeNode) []int {
+	ret := []int{}
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		if n != nil {
+			walk(n.Left)
+			ret = append(ret, n.Val)
+			walk(n.Right)
+		}
+	}
+	walk(root)
+	return ret
+}
+
+func postorderValues(root *TreeNode) []int {
+	ret := []int{}
+	var walk func(n *TreeNode)
+	walk = func(n *TreeNode) {
+		if n != nil {
+			walk(n.Left)
+			walk(n.Right)
+			ret = append(ret, n.Val)
+		}
+	}
+	walk(root)
+	return ret
+}
+
+// Serialize LeetCode 的层序格式：非空节点依次列出两个孩子，空孩子写 null，去掉末尾的 null
+func Serialize(root *TreeNode) string {
+	if root == nil {
+		return "[]"
+	}
+	var out []string
+	queue := []*TreeNode{root}
+	for head := 0; head < len(queue); head++ {
+		n := queue[head]
+		if n == nil {
+			out = append(out, "null")
+			continue
+		}
+		out = append(out, strconv.Itoa(n.Val))
+		queue = append(queue, n.Left, n.Right)
+	}
+	for out[len(out)-1] == "null" {
+		out = out[:len(out)-1]
+	}
+	return "[" + strings.Join(out, ",") + "]"
+}
+
+// Parse Serialize 的逆操作：第 k 个非空节点的孩子在第 2k+1、2k+2 个位置
+func Parse(s string) (*TreeNode, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("树应该写成 [1,null,2] 的形式: %q", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return nil, nil
+	}
+	var nodes []*TreeNode
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "null" {
+			nodes = append(nodes, nil)
+			continue
+		}
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("节点值 %q 不是整数", f)
+		}
+		nodes = append(nodes, &TreeNode{Val: v})
+	}
+	if nodes[0] == nil {
+		if len(nodes) > 1 {
+			return nil, fmt.Errorf("根节点是 null，后面不应该还有节点")
+		}
+		return nil, nil
+	}
+	// 只有下标小于 next 的节点挂到了父节点上
+	next := 1
+	for i := 0; i < len(nodes) && next < len(nodes); i++ {
+		if i >= next {
+			return nil, fmt.Errorf("有 %d 个节点没有父节点", len(nodes)-next)
+		}
+		if nodes[i] == nil {
+			continue
+		}
+		nodes[i].Left = nodes[next]
+		if next+1 < len(nodes) {
+			nodes[i].Right = nodes[next+1]
+		}
+		next += 2
+	}
+	return nodes[0], nil
+}
+
+// Failure 反例
+type Failure struct {
+	Tree   string // 原树，层序格式
+	Input  string // 交给被测实现的输入
+	Got    string // 被测实现的输出
+	Reason string
+}
+
+func (f *Failure) Error() string {
+	return fmt.Sprintf("%s\n  原树: %s\n  输入: %s\n  输出: %s", f.Reason, f.Tree, f.Input, f.Got)
+}
+
+// Config 生成多少棵树：大小 MinNodes ~ MaxNodes，每个大小 PerSize 棵
+// 已知空树有问题的实现可以把 MinNodes 设成 1，看看非空的树还有没有别的问题
+type Config struct {
+	Seed     int64
+	MinNodes int
+	MaxNodes int
+	PerSize  int
+}
+
+var DefaultConfig = Config{Seed: 1, MaxNodes: 30, PerSize: 20}
+
+func (c Config) each(fn func(root *TreeNode) *Failure) *Failure {
+	r := rand.New(rand.NewSource(c.Seed))
+	for n := c.MinNodes; n <= c.MaxNodes; n++ {
+		for i := 0; i < c.PerSize; i++ {
+			if f := fn(Random(r, n)); f != nil {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// safely 被测实现 panic 时转成反例
+func safely(f **Failure, tree, input string) {
+	if r := recover(); r != nil {
+		*f = &Failure{Tree: tree, Input: input, Got: fmt.Sprint(r), Reason: "panic"}
+	}
+}
+
+// CheckPreIn 前序 + 中序重建（105 题）
+func CheckPreIn(c Config, build func(preorder, inorder []int) *TreeNode) *Failure {
+	return c.each(func(root *TreeNode) (f *Failure) {
+		pre, in := preorderValues(root), inorderValues(root)
+		input := fmt.Sprintf("preorder = %v, inorder = %v", pre, in)
+		defer safely(&f, Serialize(root), input)
+		if got := build(pre, in); !Equal(got, root) {
+			return &Failure{Tree: Serialize(root), Input: input, Got: Serialize(got), Reason: "重建的树和原树不同"}
+		}
+		return nil
+	})
+}
+
+// CheckInPost 中序 + 后序重建（106 题）
+func CheckInPost(c Config, build func(inorder, postorder []int) *TreeNode) *Failure {
+	return c.each(func(root *TreeNode) (f *Failure) {
+		in, post := inorderValues(root), postorderValues(root)
+		input := fmt.Sprintf("inorder = %v, postorder = %v", in, post)
+		defer safely(&f, Serialize(root), input)
+		if got := build(in, post); !Equal(got, root) {
+			return &Failure{Tree: Serialize(root), Input: input, Got: Serialize(got), Reason: "重建的树和原树不同"}
+		}
+		return nil
+	})
+}
+
+// CheckCodec 被测的序列化和反序列化互相往返
+func CheckCodec(c Config, serialize func(*TreeNode) string, parse func(string) *TreeNode) *Failure {
+	return c.each(func(root *TreeNode) (f *Failure) {
+		tree := Serialize(root)
+		defer safely(&f, tree, tree)
+		s := serialize(root)
+		back := parse(s)
+		if !Equal(back, root) {
+			return &Failure{Tree: tree, Input: s, Got: Serialize(back), Reason: "解析(序列化(树)) 和原树不同"}
+		}
+		if s2 := serialize(back); s2 != s {
+			return &Failure{Tree: tree, Input: s, Got: s2, Reason: "序列化 -> 解析 -> 序列化 结果变了"}
+		}
+		return nil
+	})
+}
+
+// CheckParse 被测的解析函数能不能读 LeetCode 格式的字符串
+func CheckParse(c Config, parse func(string) *TreeNode) *Failure {
+	return c.each(func(root *TreeNode) (f *Failure) {
+		s := Serialize(root)
+		defer safely(&f, s, s)
+		if got := parse(s); !Equal(got, root) {
+			return &Failure{Tree: s, Input: s, Got: Serialize(got), Reason: "解析 LeetCode 格式得到的树不对"}
+		}
+		return nil
+	})
+}
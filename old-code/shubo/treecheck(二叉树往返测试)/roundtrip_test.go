@@ -0,0 +1,202 @@
+package treecheck
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestReferenceCodec(t *testing.T) {
+	for _, s := range []string{"[]", "[1]", "[1,null,2]", "[3,9,20,null,null,15,7]", "[1,2,3,null,4,null,5,6]"} {
+		root, err := Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Serialize(root); got != s {
+			t.Fatalf("%s -> %s", s, got)
+		}
+	}
+	for _, s := range []string{"1,2", "[1,x]", "[null,1]", "[1,null,null,2]"} {
+		if _, err := Parse(s); err == nil {
+			t.Fatalf("%s 应该报错", s)
+		}
+	}
+	parse := func(s string) *TreeNode {
+		root, _ := Parse(s)
+		return root
+	}
+	if f := CheckCodec(DefaultConfig, Serialize, parse); f != nil {
+		t.Fatal(f)
+	}
+}
+
+// 被测的目录和在目录里跑的检查。检查在目录自己的包里编译，调用的就是仓库里的函数，
+// 不再拷贝实现：拷贝会停在拷贝那一刻，真代码修好了、改坏了都看不出来
+var subjects = []struct {
+	name, dir string
+	checks    []string // 返回 *Failure 的表达式
+}{
+	{"shubo buildTree", "../buildTree(从前序与中序序列构造二叉树)", []string{"CheckPreIn(DefaultConfig, buildTree)"}},
+	{"songzhibin97 前序+中序", "../../songzhibin97/从前序与中序遍历序列构造二叉树", []string{"CheckPreIn(DefaultConfig, buildTree)"}},
+	{"songzhibin97 中序+后序", "../../songzhibin97/从中序与后序遍历序列构造二叉树", []string{"CheckInPost(DefaultConfig, buildTree)"}},
+	{"shubo levelOrder", "../levelOrder(层序遍历)", arrayCodecChecks},
+	{"shubo mergeTrees", "../mergeTrees(合并二叉树)", arrayCodecChecks},
+	{"shubo convertBST", "../convertBST(把二叉搜索树转换为累加树)", []string{
+		"CheckParse(DefaultConfig, treecheckMust(transportCase2BSTTree))",
+		"CheckCodec(DefaultConfig, func(root *TreeNode) string { return treecheckString(bst2Array(root)) }, treecheckMust(transportCase2BSTTree))",
+	}},
+}
+
+// Tree2Array/Array2Tree 在 levelOrder、mergeTrees 里各有一份
+var arrayCodecChecks = []string{
+	"CheckParse(DefaultConfig, treecheckMust(func(s string) (*TreeNode, error) { return Array2Tree(treecheckPtrs(s)) }))",
+	`CheckCodec(DefaultConfig, func(root *TreeNode) string {
+		var arr []*int
+		Tree2Array(root, &arr)
+		return treecheckString(arr)
+	}, treecheckMust(func(s string) (*TreeNode, error) { return Array2Tree(treecheckPtrs(s)) }))`,
+}
+
+// 生成到被测目录里的测试，treecheck 开头的是把 []*int 接口适配成字符串的胶水
+const harness = `package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func treecheckString(arr []*int) string {
+	b, _ := json.Marshal(arr)
+	return string(b)
+}
+
+func treecheckPtrs(s string) []*int {
+	var arr []*int
+	json.Unmarshal([]byte(s), &arr)
+	return arr
+}
+
+// treecheckMust 解析报错当成 panic，检查会把它记成反例
+func treecheckMust(parse func(string) (*TreeNode, error)) func(string) *TreeNode {
+	return func(s string) *TreeNode {
+		root, err := parse(s)
+		if err != nil {
+			panic(err)
+		}
+		return root
+	}
+}
+
+func TestTreecheck(t *testing.T) {
+	for i, f := range []*Failure{
+{{checks}}
+	} {
+		if f != nil {
+			t.Errorf("第 %d 个检查: %v", i+1, f)
+		}
+	}
+}
+`
+
+var treeNodeDecl = regexp.MustCompile(`(?s)type TreeNode struct \{.*?\n\}\n`)
+
+// runChecks 把目录里的 go 文件和 check.go 拷到临时目录，check.go 改成 package main、用目录自己的 TreeNode，
+// 再生成调用 checks 的测试跑一遍
+func runChecks(t *testing.T, dir string, checks []string) ([]byte, error) {
+	check, err := os.ReadFile("check.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := strings.Replace(string(check), "package treecheck", "package main", 1)
+	src = treeNodeDecl.ReplaceAllString(src, "")
+
+	work := t.TempDir()
+	files := map[string]string{
+		"treecheck.go":      src,
+		"treecheck_test.go": strings.Replace(harness, "{{checks}}", "\t\t"+strings.Join(checks, ",\n\t\t")+",", 1),
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil || len(names) == 0 {
+		t.Fatalf("%s 里没有 go 文件: %v", dir, err)
+	}
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.Base(name)] = string(b)
+	}
+	args := []string{"test", "-count=1", "-run", "^TestTreecheck$"}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(work, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, name)
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = work
+	cmd.Env = append(os.Environ(), "GOTOOLCHAIN=local", "GOWORK=off", "GOFLAGS=")
+	return cmd.CombinedOutput()
+}
+
+func TestSubjects(t *testing.T) {
+	if testing.Short() {
+		t.Skip("要调用 go test")
+	}
+	for _, s := range subjects {
+		t.Run(s.name, func(t *testing.T) {
+			if out, err := runChecks(t, s.dir, s.checks); err != nil {
+				t.Fatalf("%v\n%s", err, out)
+			}
+		})
+	}
+}
+
+// TestRunChecks 检查确实跑在目录里的代码上：放一个错的实现进去要能找到反例
+func TestRunChecks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("要调用 go test")
+	}
+	dir := t.TempDir()
+	src := "package main\n\ntype TreeNode struct {\n\tVal   int\n\tLeft  *TreeNode\n\tRight *TreeNode\n}\n\n" +
+		"func buildTree(preorder []int, inorder []int) *TreeNode {\n\tif len(preorder) == 0 {\n\t\treturn nil\n\t}\n\treturn &TreeNode{Val: preorder[0]}\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runChecks(t, dir, []string{"CheckPreIn(DefaultConfig, buildTree)"})
+	if err == nil || !strings.Contains(string(out), "重建的树和原树不同") {
+		t.Fatalf("%v\n%s", err, out)
+	}
+}
+
+// go test -fuzz FuzzParse *.go
+// Parse 成功时：每个非 null 元素都要变成一个节点，Serialize 之后再 Parse 结果不变
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"[]", "[1]", "[1,null,2]", "[3,9,20,null,null,15,7]", "[1,2,3,null,4,null,5,6]",
+		"1,2", "[1,x]", "[null,1]", "[1,null,null,2]", "[2,1]", "[2,null,3,1]"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		root, err := Parse(s)
+		if err != nil {
+			return
+		}
+		want := 0
+		for _, tok := range strings.Split(s, ",") {
+			if tok = strings.TrimSpace(strings.Trim(strings.TrimSpace(tok), "[]")); tok != "" && tok != "null" {
+				want++
+			}
+		}
+		if got := len(inorderValues(root)); got != want {
+			t.Fatalf("%q 有 %d 个节点，解析出 %d 个", s, want, got)
+		}
+		out := Serialize(root)
+		again, err := Parse(out)
+		if err != nil || !Equal(root, again) {
+			t.Fatalf("%q -> %s: %v", s, out, err)
+		}
+	})
+}
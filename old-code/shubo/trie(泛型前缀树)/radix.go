@@ -0,0 +1,215 @@
+package trie
+
+import (
+	"sort"
+	"strings"
+)
+
+// Radix 压缩前缀树（Patricia 树）
+// 只有一个孩子的链条合并成一条边，边上存一段字符串，节点数最多是单词数的两倍；
+// 插入时如果新单词只和某条边匹配了一部分，就在分叉处把这条边拆成两段。
+// 边按字节切分，UTF-8 的字节序和码点顺序一致，所以 Walk 的顺序和 Words 相同。
+type Radix struct {
+	root *radixNode
+}
+
+type radixNode struct {
+	label    string       // 父节点到这里的边
+	children []*radixNode // 按 label[0] 排序，首字节互不相同
+	count    int
+	words    int
+}
+
+func NewRadix() *Radix {
+	return &Radix{root: &radixNode{}}
+}
+
+func (n *radixNode) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	return i, i < len(n.children) && n.children[i].label[0] == b
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func (r *Radix) Insert(word string) {
+	r.InsertN(word, 1)
+}
+
+func (r *Radix) InsertN(word string, n int) {
+	if n <= 0 {
+		return
+	}
+	isNew := r.Count(word) == 0
+	cur := r.root
+	if isNew {
+		cur.words++
+	}
+	for word != "" {
+		i, ok := cur.child(word[0])
+		if !ok {
+			leaf := &radixNode{label: word}
+			cur.children = append(cur.children, nil)
+			copy(cur.children[i+1:], cur.children[i:])
+			cur.children[i] = leaf
+			cur = leaf
+			if isNew {
+				cur.words++
+			}
+			break
+		}
+		c := cur.children[i]
+		l := commonPrefix(c.label, word)
+		if l < len(c.label) {
+			// 拆边：cur -> mid(label[:l]) -> c(label[l:])
+			mid := &radixNode{label: c.label[:l], children: []*radixNode{c}, words: c.words}
+			c.label = c.label[l:]
+			cur.children[i] = mid
+			c = mid
+		}
+		cur = c
+		if isNew {
+			cur.words++
+		}
+		word = word[l:]
+	}
+	cur.count += n
+}
+
+// find 精确找到 word 对应的节点
+func (r *Radix) find(word string) *radixNode {
+	cur := r.root
+	for word != "" {
+		i, ok := cur.child(word[0])
+		if !ok || !strings.HasPrefix(word, cur.children[i].label) {
+			return nil
+		}
+		cur = cur.children[i]
+		word = word[len(cur.label):]
+	}
+	return cur
+}
+
+// locate 找到第一个路径以 prefix 开头的节点，prefix 可能停在某条边的中间
+// 返回节点和从根到它的完整路径
+func (r *Radix) locate(prefix string) (*radixNode, string) {
+	cur := r.root
+	path := ""
+	for rest := prefix; rest != ""; {
+		i, ok := cur.child(rest[0])
+		if !ok {
+			return nil, ""
+		}
+		c := cur.children[i]
+		if strings.HasPrefix(rest, c.label) {
+			rest = rest[len(c.label):]
+		} else if strings.HasPrefix(c.label, rest) {
+			rest = ""
+		} else {
+			return nil, ""
+		}
+		cur = c
+		path += c.label
+	}
+	return cur, path
+}
+
+func (r *Radix) Search(word string) bool {
+	return r.Count(word) > 0
+}
+
+func (r *Radix) StartsWith(prefix string) bool {
+	n, _ := r.locate(prefix)
+	return n != nil && n.words > 0
+}
+
+func (r *Radix) Count(word string) int {
+	if n := r.find(word); n != nil {
+		return n.count
+	}
+	return 0
+}
+
+func (r *Radix) CountWithPrefix(prefix string) int {
+	if n, _ := r.locate(prefix); n != nil {
+		return n.words
+	}
+	return 0
+}
+
+func (r *Radix) Len() int {
+	return r.root.words
+}
+
+// Delete 删除单词，删掉空节点，再把只剩一个孩子的非单词节点和孩子合并，保持路径压缩
+func (r *Radix) Delete(word string) bool {
+	if !r.Search(word) {
+		return false
+	}
+	var parents []*radixNode
+	cur := r.root
+	for rest := word; rest != ""; {
+		i, _ := cur.child(rest[0])
+		parents = append(parents, cur)
+		cur = cur.children[i]
+		rest = rest[len(cur.label):]
+	}
+	cur.count = 0
+	for _, p := range parents {
+		p.words--
+	}
+	cur.words--
+	// 从下往上收拾
+	nodes := append(parents, cur)
+	for i := len(nodes) - 1; i > 0; i-- {
+		n, p := nodes[i], nodes[i-1]
+		j, _ := p.child(n.label[0])
+		switch {
+		case n.words == 0:
+			p.children = append(p.children[:j], p.children[j+1:]...)
+		case n.count == 0 && len(n.children) == 1:
+			c := n.children[0]
+			c.label = n.label + c.label
+			p.children[j] = c
+		}
+	}
+	return true
+}
+
+func (r *Radix) Walk(prefix string, fn func(word string, count int) bool) {
+	n, path := r.locate(prefix)
+	if n == nil {
+		return
+	}
+	radixWalk(n, path, fn)
+}
+
+func radixWalk(n *radixNode, path string, fn func(string, int) bool) bool {
+	if n.count > 0 && !fn(path, n.count) {
+		return false
+	}
+	for _, c := range n.children {
+		if !radixWalk(c, path+c.label, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Nodes 节点总数（包括根节点），用来和不压缩的前缀树比较
+func (r *Radix) Nodes() int {
+	var count func(n *radixNode) int
+	count = func(n *radixNode) int {
+		ret := 1
+		for _, c := range n.children {
+			ret += count(c)
+		}
+		return ret
+	}
+	return count(r.root)
+}
@@ -0,0 +1,325 @@
+package trie
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRadixSplitAndMerge(t *testing.T) {
+	r := NewRadix()
+	for _, s := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		r.Insert(s)
+	}
+	// 根 -> r -> {om -> {an -> {e, us}, ulus}, ub -> {e -> {ns, r}, ic -> {on, undus}}}
+	if got := r.Nodes(); got != 14 {
+		t.Fatalf("节点数 %d，期望 14", got)
+	}
+	if !r.StartsWith("rubi") || r.CountWithPrefix("rub") != 4 || r.CountWithPrefix("roma") != 2 {
+		t.Fatal("前缀停在边中间也要能找到")
+	}
+	if r.Search("rom") || r.Search("rubic") {
+		t.Fatal("拆出来的中间节点不是单词")
+	}
+	// 删掉 romanus 后 an 只剩一个孩子 e，要合并成 ane
+	r.Delete("romanus")
+	if got := r.Nodes(); got != 12 {
+		t.Fatalf("删除后节点数 %d，期望 12", got)
+	}
+	var got []string
+	r.Walk("rom", func(w string, _ int) bool {
+		got = append(got, w)
+		return true
+	})
+	if !reflect.DeepEqual(got, []string{"romane", "romulus"}) {
+		t.Fatal(got)
+	}
+	for _, s := range []string{"romane", "romulus", "rubens", "ruber", "rubicon", "rubicundus"} {
+		r.Delete(s)
+	}
+	if r.Len() != 0 || r.Nodes() != 1 {
+		t.Fatalf("全部删除后应该只剩根节点，Len=%d Nodes=%d", r.Len(), r.Nodes())
+	}
+}
+
+// Radix 和 Words 对拍，两者都通过 Dictionary 接口操作
+func TestRadixAgainstWords(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	alphabet := []rune("ab中文")
+	randWord := func() string {
+		b := make([]rune, r.Intn(5))
+		for i := range b {
+			b[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+	walk := func(d Dictionary, prefix string) []Suggestion {
+		var ret []Suggestion
+		d.Walk(prefix, func(w string, c int) bool {
+			ret = append(ret, Suggestion{w, c})
+			return true
+		})
+		return ret
+	}
+	var want, got Dictionary = NewWords(), NewRadix()
+	for i := 0; i < 5000; i++ {
+		s := randWord()
+		switch r.Intn(3) {
+		case 0:
+			if want.Delete(s) != got.Delete(s) {
+				t.Fatalf("Delete(%q)", s)
+			}
+		case 1:
+			want.InsertN(s, 2)
+			got.InsertN(s, 2)
+		default:
+			want.Insert(s)
+			got.Insert(s)
+		}
+		p := randWord()
+		if want.Len() != got.Len() || want.Count(p) != got.Count(p) ||
+			want.CountWithPrefix(p) != got.CountWithPrefix(p) || want.StartsWith(p) != got.StartsWith(p) {
+			t.Fatalf("第 %d 步 %q 不一致", i, p)
+		}
+		if w, g := walk(want, p), walk(got, p); !reflect.DeepEqual(w, g) {
+			t.Fatalf("Walk(%q) = %v, 期望 %v", p, g, w)
+		}
+	}
+}
+
+// 下面是对比用的两种前缀树：shubo/trie(前缀树) 的数组版，cc11001100 208 题的 map 版
+
+type arrayTrie struct {
+	Child [26]*arrayTrie
+	IsEnd bool
+}
+
+func (this *arrayTrie) Insert(word string) {
+	cur := this
+	for _, c := range word {
+		if cur.Child[c-'a'] == nil {
+			cur.Child[c-'a'] = &arrayTrie{}
+		}
+		cur = cur.Child[c-'a']
+	}
+	cur.IsEnd = true
+}
+
+func (this *arrayTrie) Search(word string) bool {
+	cur := this
+	for _, c := range word {
+		if cur.Child[c-'a'] == nil {
+			return false
+		}
+		cur = cur.Child[c-'a']
+	}
+	return cur.IsEnd
+}
+
+func (this *arrayTrie) Nodes() int {
+	ret := 1
+	for _, c := range this.Child {
+		if c != nil {
+			ret += c.Nodes()
+		}
+	}
+	return ret
+}
+
+type mapTrie struct {
+	Children map[rune]*mapTrie
+	IsEnd    bool
+}
+
+func newMapTrie() *mapTrie {
+	return &mapTrie{Children: map[rune]*mapTrie{}}
+}
+
+func (this *mapTrie) Insert(word string) {
+	cur := this
+	for _, c := range word {
+		next, ok := cur.Children[c]
+		if !ok {
+			next = newMapTrie()
+			cur.Children[c] = next
+		}
+		cur = next
+	}
+	cur.IsEnd = true
+}
+
+func (this *mapTrie) Search(word string) bool {
+	cur := this
+	for _, c := range word {
+		next, ok := cur.Children[c]
+		if !ok {
+			return false
+		}
+		cur = next
+	}
+	return cur.IsEnd
+}
+
+func (this *mapTrie) Nodes() int {
+	ret := 1
+	for _, c := range this.Children {
+		ret += c.Nodes()
+	}
+	return ret
+}
+
+type benchTrie interface {
+	Insert(word string)
+	Search(word string) bool
+	Nodes() int
+}
+
+var benchTries = []struct {
+	name    string
+	new     func() benchTrie
+	unicode bool // 数组版只能存 a-z
+}{
+	{"array", func() benchTrie { return &arrayTrie{} }, false},
+	{"map", func() benchTrie { return newMapTrie() }, true},
+	{"words", func() benchTrie { return NewWords() }, true},
+	{"radix", func() benchTrie { return NewRadix() }, true},
+}
+
+// titleWords hot 100 的标题：英文去掉非字母转小写，中文原样
+func titleWords(tb testing.TB) (en, zh []string) {
+	b, err := os.ReadFile("../../../docs/leetcode-hot-100.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var data struct {
+		Data struct {
+			FavoriteQuestionList struct {
+				Questions []struct {
+					Title           string `json:"title"`
+					TranslatedTitle string `json:"translatedTitle"`
+				} `json:"questions"`
+			} `json:"favoriteQuestionList"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		tb.Fatal(err)
+	}
+	for _, q := range data.Data.FavoriteQuestionList.Questions {
+		en = append(en, strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' {
+				return r
+			}
+			return -1
+		}, strings.ToLower(q.Title)))
+		zh = append(zh, q.TranslatedTitle)
+	}
+	return en, zh
+}
+
+// heapBytes 建树前后的堆大小差
+func heapBytes(build func() benchTrie) (benchTrie, uint64) {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	t := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(t)
+	if after.HeapAlloc < before.HeapAlloc {
+		return t, 0
+	}
+	return t, after.HeapAlloc - before.HeapAlloc
+}
+
+func TestMemoryComparison(t *testing.T) {
+	en, zh := titleWords(t)
+	nodes := map[string]int{}
+	for _, set := range []struct {
+		name  string
+		words []string
+	}{{"en", en}, {"zh", zh}} {
+		for _, bt := range benchTries {
+			if set.name == "zh" && !bt.unicode {
+				continue
+			}
+			tr, bytes := heapBytes(func() benchTrie {
+				tr := bt.new()
+				for _, w := range set.words {
+					tr.Insert(w)
+				}
+				return tr
+			})
+			for _, w := range set.words {
+				if !tr.Search(w) {
+					t.Fatalf("%s 找不到 %q", bt.name, w)
+				}
+			}
+			nodes[set.name+"/"+bt.name] = tr.Nodes()
+			t.Logf("%s/%-6s 节点 %5d  堆 %7d B", set.name, bt.name, tr.Nodes(), bytes)
+		}
+	}
+	// 100 个单词的压缩树最多 2*100 个节点，外加根节点
+	for _, k := range []string{"en/radix", "zh/radix"} {
+		if nodes[k] > 201 {
+			t.Fatalf("%s 节点数 %d 超过 2n+1", k, nodes[k])
+		}
+	}
+	if nodes["en/array"] != nodes["en/map"] || nodes["en/radix"] >= nodes["en/array"] {
+		t.Fatal(nodes)
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	en, zh := titleWords(b)
+	for _, set := range []struct {
+		name  string
+		words []string
+	}{{"en", en}, {"zh", zh}} {
+		for _, bt := range benchTries {
+			if set.name == "zh" && !bt.unicode {
+				continue
+			}
+			b.Run(fmt.Sprintf("%s/%s", set.name, bt.name), func(b *testing.B) {
+				b.ReportAllocs()
+				var tr benchTrie
+				for i := 0; i < b.N; i++ {
+					tr = bt.new()
+					for _, w := range set.words {
+						tr.Insert(w)
+					}
+				}
+				b.ReportMetric(float64(tr.Nodes()), "nodes")
+			})
+		}
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	en, zh := titleWords(b)
+	for _, set := range []struct {
+		name  string
+		words []string
+	}{{"en", en}, {"zh", zh}} {
+		for _, bt := range benchTries {
+			if set.name == "zh" && !bt.unicode {
+				continue
+			}
+			tr := bt.new()
+			for _, w := range set.words {
+				tr.Insert(w)
+			}
+			b.Run(fmt.Sprintf("%s/%s", set.name, bt.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if !tr.Search(set.words[i%len(set.words)]) {
+						b.Fatal(set.words[i%len(set.words)])
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Package trie 泛型前缀树
+// trie(前缀树) 用 [26]*Trie 按 c-'a' 取下标，遇到大写、数字、中文会越界 panic；
+// 这里和 cc11001100 的写法一样用 map 存孩子，key 可以是 rune、byte 或者任意有序类型，
+// 另外支持删除、按前缀计数、按前缀遍历和按词频补全。
+package trie
+
+import (
+	"cmp"
+	"container/heap"
+	"slices"
+)
+
+type node[K cmp.Ordered] struct {
+	children map[K]*node[K]
+	count    int // 以这个节点结尾的单词插入了几次，0 表示不是单词结尾
+	words    int // 子树里（包括自己）有多少个不同的单词
+}
+
+func newNode[K cmp.Ordered]() *node[K] {
+	return &node[K]{children: map[K]*node[K]{}}
+}
+
+type Trie[K cmp.Ordered] struct {
+	root *node[K]
+}
+
+func New[K cmp.Ordered]() *Trie[K] {
+	return &Trie[K]{root: newNode[K]()}
+}
+
+// Insert 插入一次，重复插入会累加词频
+func (t *Trie[K]) Insert(word []K) {
+	t.InsertN(word, 1)
+}
+
+// InsertN 插入 n 次，n <= 0 时什么也不做
+func (t *Trie[K]) InsertN(word []K, n int) {
+	if n <= 0 {
+		return
+	}
+	// 先确认是不是新单词，路径上的 words 才能一次加对
+	isNew := t.Count(word) == 0
+	cur := t.root
+	if isNew {
+		cur.words++
+	}
+	for _, c := range word {
+		next, ok := cur.children[c]
+		if !ok {
+			next = newNode[K]()
+			cur.children[c] = next
+		}
+		cur = next
+		if isNew {
+			cur.words++
+		}
+	}
+	cur.count += n
+}
+
+func (t *Trie[K]) find(prefix []K) *node[K] {
+	cur := t.root
+	for _, c := range prefix {
+		next, ok := cur.children[c]
+		if !ok {
+			return nil
+		}
+		cur = next
+	}
+	return cur
+}
+
+func (t *Trie[K]) Search(word []K) bool {
+	return t.Count(word) > 0
+}
+
+func (t *Trie[K]) StartsWith(prefix []K) bool {
+	n := t.find(prefix)
+	return n != nil && n.words > 0
+}
+
+// Count 单词的词频，不存在时为 0
+func (t *Trie[K]) Count(word []K) int {
+	if n := t.find(word); n != nil {
+		return n.count
+	}
+	return 0
+}
+
+// CountWithPrefix 以 prefix 开头的不同单词个数，O(len(prefix))
+func (t *Trie[K]) CountWithPrefix(prefix []K) int {
+	if n := t.find(prefix); n != nil {
+		return n.words
+	}
+	return 0
+}
+
+// Len 不同单词的个数
+func (t *Trie[K]) Len() int {
+	return t.root.words
+}
+
+// Delete 删除单词（不管插入过几次），并剪掉不再通往任何单词的节点
+func (t *Trie[K]) Delete(word []K) bool {
+	if !t.Search(word) {
+		return false
+	}
+	cur := t.root
+	cur.words--
+	for _, c := range word {
+		next := cur.children[c]
+		if next.words--; next.words == 0 {
+			delete(cur.children, c)
+			return true
+		}
+		cur = next
+	}
+	cur.count = 0
+	return true
+}
+
+// Walk 按字典序遍历以 prefix 开头的单词，fn 返回 false 时提前结束
+// word 在回调之后会被复用，需要保存时自己拷贝
+func (t *Trie[K]) Walk(prefix []K, fn func(word []K, count int) bool) {
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+	buf := append([]K(nil), prefix...)
+	walk(n, buf, fn)
+}
+
+func walk[K cmp.Ordered](n *node[K], buf []K, fn func([]K, int) bool) bool {
+	if n.count > 0 && !fn(buf, n.count) {
+		return false
+	}
+	keys := make([]K, 0, len(n.children))
+	for k := range n.children {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		if !walk(n.children[k], append(buf, k), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+type Entry[K cmp.Ordered] struct {
+	Word  []K
+	Count int
+}
+
+// TopK 以 prefix 开头、词频最高的 k 个单词，词频相同按字典序
+// 用大小为 k 的小顶堆，O(m log k)，m 是前缀下的单词数
+func (t *Trie[K]) TopK(prefix []K, k int) []Entry[K] {
+	if k <= 0 {
+		return nil
+	}
+	h := &entryHeap[K]{}
+	t.Walk(prefix, func(word []K, count int) bool {
+		e := Entry[K]{Word: word, Count: count}
+		if h.Len() < k {
+			e.Word = slices.Clone(word)
+			heap.Push(h, e)
+		} else if better(e, (*h)[0]) {
+			e.Word = slices.Clone(word)
+			(*h)[0] = e
+			heap.Fix(h, 0)
+		}
+		return true
+	})
+	ret := make([]Entry[K], h.Len())
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i] = heap.Pop(h).(Entry[K])
+	}
+	return ret
+}
+
+// better a 排在 b 前面：词频高的在前，词频相同字典序小的在前
+func better[K cmp.Ordered](a, b Entry[K]) bool {
+	if a.Count != b.Count {
+		return a.Count > b.Count
+	}
+	return slices.Compare(a.Word, b.Word) < 0
+}
+
+// 堆顶是当前 k 个里最差的
+type entryHeap[K cmp.Ordered] []Entry[K]
+
+func (h entryHeap[K]) Len() int           { return len(h) }
+func (h entryHeap[K]) Less(i, j int) bool { return better(h[j], h[i]) }
+func (h entryHeap[K]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *entryHeap[K]) Push(x any)        { *h = append(*h, x.(Entry[K])) }
+func (h *entryHeap[K]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
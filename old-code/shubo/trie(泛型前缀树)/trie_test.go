@@ -0,0 +1,202 @@
+package trie
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestLeetCodeExample(t *testing.T) {
+	w := NewWords()
+	w.Insert("apple")
+	if !w.Search("apple") || w.Search("app") || !w.StartsWith("app") {
+		t.Fatal("208 示例不对")
+	}
+	w.Insert("app")
+	if !w.Search("app") {
+		t.Fatal("app 已经插入了")
+	}
+}
+
+func TestUnicode(t *testing.T) {
+	w := NewWords()
+	for _, s := range []string{"二叉树的中序遍历", "二叉树的最大深度", "二分查找", "LRU 缓存", "3Sum", ""} {
+		w.Insert(s)
+	}
+	if !w.Search("LRU 缓存") || !w.Search("3Sum") || !w.Search("") {
+		t.Fatal("大写、数字、空串都应该能插入")
+	}
+	if got := w.CountWithPrefix("二叉树"); got != 2 {
+		t.Fatal(got)
+	}
+	if got := w.CountWithPrefix("二"); got != 3 {
+		t.Fatal(got)
+	}
+	if got := w.WithPrefix("二"); !reflect.DeepEqual(got, []string{"二分查找", "二叉树的中序遍历", "二叉树的最大深度"}) {
+		t.Fatal(got)
+	}
+	if w.Len() != 6 || w.CountWithPrefix("") != 6 {
+		t.Fatal(w.Len())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	w := NewWords()
+	w.Insert("apple")
+	w.Insert("app")
+	w.InsertN("app", 2)
+	if w.Count("app") != 3 || w.Len() != 2 {
+		t.Fatal(w.Count("app"), w.Len())
+	}
+	if !w.Delete("app") || w.Delete("app") || w.Delete("ap") {
+		t.Fatal("只能删除存在的单词")
+	}
+	if w.Search("app") || !w.Search("apple") || !w.StartsWith("app") || w.CountWithPrefix("a") != 1 {
+		t.Fatal("删除 app 不能影响 apple")
+	}
+	w.Delete("apple")
+	if w.StartsWith("a") || len(w.t.root.children) != 0 {
+		t.Fatal("删除之后要剪掉空分支")
+	}
+}
+
+func TestWalkEarlyExit(t *testing.T) {
+	w := NewWords()
+	for _, s := range []string{"b", "a", "ab", "abc", "c"} {
+		w.Insert(s)
+	}
+	var got []string
+	w.Walk("", func(word string, _ int) bool {
+		got = append(got, word)
+		return len(got) < 3
+	})
+	if !reflect.DeepEqual(got, []string{"a", "ab", "abc"}) {
+		t.Fatal(got)
+	}
+	w.Walk("x", func(string, int) bool {
+		t.Fatal("不存在的前缀不应该回调")
+		return true
+	})
+}
+
+func TestAutocomplete(t *testing.T) {
+	w := NewWords()
+	w.InsertN("two sum", 5)
+	w.InsertN("trapping rain water", 3)
+	w.InsertN("top k frequent elements", 3)
+	w.InsertN("trie", 1)
+	w.InsertN("house robber", 9)
+	got := w.Autocomplete("t", 3)
+	want := []Suggestion{{"two sum", 5}, {"top k frequent elements", 3}, {"trapping rain water", 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("%v != %v", got, want)
+	}
+	if got := w.Autocomplete("t", 0); len(got) != 0 {
+		t.Fatal(got)
+	}
+	if got := w.Autocomplete("z", 3); len(got) != 0 {
+		t.Fatal(got)
+	}
+}
+
+// 和 map 对拍：随机插入、删除，检查计数和补全
+func TestAgainstMap(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	alphabet := []rune("ab中文")
+	randWord := func() string {
+		b := make([]rune, r.Intn(4))
+		for i := range b {
+			b[i] = alphabet[r.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+	w := NewWords()
+	m := map[string]int{}
+	for i := 0; i < 5000; i++ {
+		s := randWord()
+		if r.Intn(3) == 0 {
+			if w.Delete(s) != (m[s] > 0) {
+				t.Fatalf("Delete(%q)", s)
+			}
+			delete(m, s)
+		} else {
+			w.Insert(s)
+			m[s]++
+		}
+		p := randWord()
+		var want []Suggestion
+		for k, v := range m {
+			if strings.HasPrefix(k, p) {
+				want = append(want, Suggestion{k, v})
+			}
+		}
+		if got := w.CountWithPrefix(p); got != len(want) {
+			t.Fatalf("CountWithPrefix(%q) = %d, 期望 %d", p, got, len(want))
+		}
+		sort.Slice(want, func(i, j int) bool {
+			if want[i].Count != want[j].Count {
+				return want[i].Count > want[j].Count
+			}
+			// UTF-8 的字节序和码点顺序一致
+			return want[i].Word < want[j].Word
+		})
+		if len(want) > 3 {
+			want = want[:3]
+		}
+		got := w.Autocomplete(p, 3)
+		if len(got) != len(want) || (len(got) > 0 && !reflect.DeepEqual(got, want)) {
+			t.Fatalf("Autocomplete(%q) = %v, 期望 %v", p, got, want)
+		}
+	}
+}
+
+func TestGenericKeys(t *testing.T) {
+	tr := New[int]()
+	tr.Insert([]int{1, 2, 3})
+	tr.Insert([]int{1, 2})
+	if !tr.Search([]int{1, 2}) || tr.Search([]int{1}) || tr.CountWithPrefix([]int{1}) != 2 {
+		t.Fatal("int 序列也应该能用")
+	}
+}
+
+// 用 hot 100 的中英文标题做搜索
+func TestTitles(t *testing.T) {
+	b, err := os.ReadFile("../../../docs/leetcode-hot-100.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data struct {
+		Data struct {
+			FavoriteQuestionList struct {
+				Questions []struct {
+					Title           string `json:"title"`
+					TranslatedTitle string `json:"translatedTitle"`
+				} `json:"questions"`
+			} `json:"favoriteQuestionList"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	w := NewWords()
+	for _, q := range data.Data.FavoriteQuestionList.Questions {
+		w.Insert(q.TranslatedTitle)
+		w.Insert(strings.ToLower(q.Title))
+	}
+	if w.Len() != 200 {
+		t.Fatalf("100 道题中英文标题应该有 200 个，实际 %d", w.Len())
+	}
+	if got := w.WithPrefix("二叉树的"); len(got) < 5 {
+		t.Fatal(got)
+	}
+	if got := w.WithPrefix("binary tree "); len(got) < 3 {
+		t.Fatal(got)
+	}
+	if !w.Search("接雨水") || !w.Search("trapping rain water") {
+		t.Fatal("接雨水")
+	}
+}
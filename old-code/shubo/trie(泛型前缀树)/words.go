@@ -0,0 +1,83 @@
+package trie
+
+// Dictionary 字符串前缀树的公共接口，Words 和 Radix 都实现了它
+type Dictionary interface {
+	Insert(word string)
+	InsertN(word string, n int)
+	Search(word string) bool
+	StartsWith(prefix string) bool
+	Count(word string) int
+	CountWithPrefix(prefix string) int
+	Delete(word string) bool
+	Len() int
+	Walk(prefix string, fn func(word string, count int) bool)
+}
+
+var (
+	_ Dictionary = (*Words)(nil)
+	_ Dictionary = (*Radix)(nil)
+)
+
+// Words 字符串版本，按 rune 切分，中文、大写、数字都可以
+
+type Words struct {
+	t *Trie[rune]
+}
+
+func NewWords() *Words {
+	return &Words{t: New[rune]()}
+}
+
+func (w *Words) Insert(word string)                { w.t.Insert([]rune(word)) }
+func (w *Words) InsertN(word string, n int)        { w.t.InsertN([]rune(word), n) }
+func (w *Words) Search(word string) bool           { return w.t.Search([]rune(word)) }
+func (w *Words) StartsWith(prefix string) bool     { return w.t.StartsWith([]rune(prefix)) }
+func (w *Words) Count(word string) int             { return w.t.Count([]rune(word)) }
+func (w *Words) CountWithPrefix(prefix string) int { return w.t.CountWithPrefix([]rune(prefix)) }
+func (w *Words) Delete(word string) bool           { return w.t.Delete([]rune(word)) }
+func (w *Words) Len() int                          { return w.t.Len() }
+
+// Walk 按字典序（rune 的码点顺序）遍历以 prefix 开头的单词
+func (w *Words) Walk(prefix string, fn func(word string, count int) bool) {
+	w.t.Walk([]rune(prefix), func(word []rune, count int) bool {
+		return fn(string(word), count)
+	})
+}
+
+// WithPrefix 以 prefix 开头的所有单词
+func (w *Words) WithPrefix(prefix string) []string {
+	var ret []string
+	w.Walk(prefix, func(word string, _ int) bool {
+		ret = append(ret, word)
+		return true
+	})
+	return ret
+}
+
+type Suggestion struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Autocomplete 按词频补全，最多返回 k 个
+func (w *Words) Autocomplete(prefix string, k int) []Suggestion {
+	top := w.t.TopK([]rune(prefix), k)
+	ret := make([]Suggestion, len(top))
+	for i, e := range top {
+		ret[i] = Suggestion{Word: string(e.Word), Count: e.Count}
+	}
+	return ret
+}
+
+// Nodes 节点总数（包括根节点）
+func (w *Words) Nodes() int {
+	var count func(n *node[rune]) int
+	count = func(n *node[rune]) int {
+		ret := 1
+		for _, c := range n.children {
+			ret += count(c)
+		}
+		return ret
+	}
+	return count(w.t.root)
+}
@@ -0,0 +1,44 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+)
+
+// 编译成 wasm 给网页调用，离线也能跑：
+//
+//	GOOS=js GOARCH=wasm go build -o hot100.wasm main_js.go dailyTemperatures.go parse.go registry.go runner.go solutions.go stack.go trace.go trap.go
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// JS 侧：
+//
+//	const go = new Go();
+//	const { instance } = await WebAssembly.instantiateStreaming(fetch('hot100.wasm'), go.importObject);
+//	go.run(instance);
+//	const ret = JSON.parse(hot100Run('42', 'height = [0,1,0,2,1,0,1,3,2,1,2,1]'));
+//	// ret.output / ret.trace / ret.elapsedNs / ret.error
+//
+// 只能跑 registry.go 里注册的 7 道题：1、20、42、70、121、136、739，其中 42 和 739 带执行轨迹。
+// 别的题返回的 ret.error 是“题目 xx 还不能在线运行”，网页要退回到判题服务或者只展示题解。
+func main() {
+	js.Global().Set("hot100Run", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) != 2 {
+			b, _ := json.Marshal(Result{Error: "用法: hot100Run(questionId, input)"})
+			return string(b)
+		}
+		b, _ := json.Marshal(Run(args[0].String(), args[1].String()))
+		return string(b)
+	}))
+	js.Global().Set("hot100Problems", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ret := map[string][]string{}
+		for id, s := range solutions {
+			ret[id] = s.Params
+		}
+		b, _ := json.Marshal(ret)
+		return string(b)
+	}))
+	// 阻塞住，保持导出的函数可用
+	select {}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// 和 judge(本地判题服务)/parse_test.go 一样，种子换成 runner_test.go 里的输入
+// go test -fuzz FuzzParseArgs parse.go registry.go runner.go trace.go trap.go runner_test.go parse_test.go
+
+var seeds = []string{
+	`nums = [2,7,11,15], target = 9`,
+	`target = 9, nums = [2,7,11,15]`,
+	`[2,7,11,15], 9`,
+	` [ [1,2],[3,4] ] ,  -1 `,
+	`s = "(,]"`,
+	`s = "()[]{}"`,
+	`height = [0,1,0,2,1,0,1,3,2,1,2,1]`,
+	`n = 3`,
+	`n = 100`,
+	`prices = [7,1,5,3,6,4]`,
+	`nums = [4,1,2,1,2]`,
+	`temperatures = [73,74,75,71,69,72,76,73]`,
+}
+
+func sortedSolutions() []Solution {
+	ids := make([]string, 0, len(solutions))
+	for id := range solutions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	ret := make([]Solution, len(ids))
+	for i, id := range ids {
+		ret[i] = solutions[id]
+	}
+	return ret
+}
+
+// 解析成功时：参数个数对、每个参数是合法的 JSON，按 name = value 拼回去再解析结果不变
+func FuzzParseArgs(f *testing.F) {
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		for _, s := range sortedSolutions() {
+			args, err := parseArgs(input, s.Params)
+			if err != nil {
+				continue
+			}
+			if len(args) != len(s.Params) {
+				t.Fatalf("%s %q: %d 个参数", s.Name, input, len(args))
+			}
+			parts := make([]string, len(args))
+			for i, a := range args {
+				if !json.Valid(a) {
+					t.Fatalf("%s %q: 参数 %s 不是 JSON: %s", s.Name, input, s.Params[i], a)
+				}
+				parts[i] = s.Params[i] + " = " + string(a)
+			}
+			again, err := parseArgs(strings.Join(parts, ", "), s.Params)
+			if err != nil {
+				t.Fatalf("%s %q -> %q: %v", s.Name, input, strings.Join(parts, ", "), err)
+			}
+			for i := range args {
+				if string(again[i]) != string(args[i]) {
+					t.Fatalf("%s %q: 参数 %s %s -> %s", s.Name, input, s.Params[i], args[i], again[i])
+				}
+			}
+		}
+	})
+}
+
+// 直接调用 Solution.Run，绕过 Run 里的 recover：不合法的输入要报错，不能让题解 panic
+func FuzzRun(f *testing.F) {
+	for _, s := range seeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		if len(input) > 200 {
+			return
+		}
+		for _, s := range sortedSolutions() {
+			args, err := parseArgs(input, s.Params)
+			if err != nil {
+				continue
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("%s %q panic: %v", s.Name, input, r)
+					}
+				}()
+				s.Run(args)
+			}()
+		}
+	})
+}
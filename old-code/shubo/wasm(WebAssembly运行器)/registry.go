@@ -0,0 +1,41 @@
+package main
+
+import "encoding/json"
+
+// 可以在网页里直接运行的题解，按 questionFrontendId 注册
+// Run 返回结果和执行轨迹，没有埋点的题解 trace 为 nil
+//
+// 题解和判题服务跑的是同一份代码：solutions.go 从 judge(本地判题服务)/solutions.go 生成，
+// 两边都不是作者目录里的代码，作者目录是独立的 package main，编不进同一个程序。
+// 判题服务还注册了暴力、dp、并查集等解法和 128、200 两道题，这里只注册默认解法。
+// 加一道题要先把解法写进 judge 的 solutions.go，在这里 go generate 后注册，
+// 再把用到的文件名加到 main_js.go 里的 go build 命令上。
+
+type Solution struct {
+	Name   string
+	Params []string
+	Run    RunFunc
+}
+
+var solutions = map[string]Solution{
+	"1":   {Name: "twoSum", Params: []string{"nums", "target"}, Run: ints2(twoSum)},
+	"20":  {Name: "isValid", Params: []string{"s"}, Run: runIsValid},
+	"42":  {Name: "trap", Params: []string{"height"}, Run: runTrap},
+	"70":  {Name: "climbStairs", Params: []string{"n"}, Run: runClimbStairs},
+	"121": {Name: "maxProfit", Params: []string{"prices"}, Run: ints(maxProfit)},
+	"136": {Name: "singleNumber", Params: []string{"nums"}, Run: ints(singleNumber)},
+	"739": {Name: "dailyTemperatures", Params: []string{"temperatures"}, Run: runDailyTemperatures},
+}
+
+// 739 的结果用普通解法算，执行轨迹另外用埋了点的单调栈跑一遍
+func runDailyTemperatures(args []json.RawMessage) (interface{}, *Trace, error) {
+	temperatures, err := decode[[]int](args[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	tr, err := traceDailyTemperatures(string(args[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+	return dailyTemperatures(temperatures), tr, nil
+}
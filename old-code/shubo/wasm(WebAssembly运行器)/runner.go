@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../judge(本地判题服务)/parse.go ../hot100(命令行)/trace.go ../hot100(命令行)/trap.go ../hot100(命令行)/dailyTemperatures.go
+//go:generate go run ../copygen(拷贝生成)/main.go -- ../judge(本地判题服务)/solutions.go
+//go:generate go run ../copygen(拷贝生成)/main.go -pkg main -- ../monotonic(单调栈)/stack.go
+
+type Result struct {
+	Output    json.RawMessage `json:"output,omitempty"` // LeetCode 格式的输出
+	Trace     *Trace          `json:"trace,omitempty"`
+	ElapsedNs int64           `json:"elapsedNs"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Run 按题号运行题解，错误放在 Result.Error 里，方便 JS 侧统一处理
+func Run(questionID, input string) (ret Result) {
+	s, ok := solutions[questionID]
+	if !ok {
+		ret.Error = fmt.Sprintf("题目 %s 还不能在线运行", questionID)
+		return
+	}
+	args, err := parseArgs(input, s.Params)
+	if err != nil {
+		ret.Error = err.Error()
+		return
+	}
+	defer func() {
+		// 题解 panic（比如数组越界）也要把错误带回网页，而不是让整个 wasm 挂掉
+		if r := recover(); r != nil {
+			ret = Result{Error: fmt.Sprintf("运行出错: %v", r)}
+		}
+	}()
+	start := time.Now()
+	out, tr, err := s.Run(args)
+	ret.ElapsedNs = time.Since(start).Nanoseconds()
+	if err != nil {
+		ret.Error = err.Error()
+		return
+	}
+	ret.Output, _ = json.Marshal(out)
+	ret.Trace = tr
+	return
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// go test $(ls *.go | grep -v _js)
+
+func TestParseArgs(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{`nums = [2,7,11,15], target = 9`, []string{`[2,7,11,15]`, `9`}},
+		{`target = 9, nums = [2,7,11,15]`, []string{`[2,7,11,15]`, `9`}},
+		{`[2,7,11,15], 9`, []string{`[2,7,11,15]`, `9`}},
+		{` [ [1,2],[3,4] ] ,  -1 `, []string{`[ [1,2],[3,4] ]`, `-1`}},
+	}
+	for _, c := range cases {
+		args, err := parseArgs(c.input, []string{"nums", "target"})
+		if err != nil {
+			t.Fatalf("%s: %v", c.input, err)
+		}
+		for i := range args {
+			if string(args[i]) != c.want[i] {
+				t.Fatalf("%s: 第 %d 个参数 %s, 期望 %s", c.input, i, args[i], c.want[i])
+			}
+		}
+	}
+	for _, bad := range []string{`[1,2]`, `nums = [1,2], k = 3`, `[1,2, 3`, `[1,2], x`} {
+		if _, err := parseArgs(bad, []string{"nums", "target"}); err == nil {
+			t.Fatalf("%s 应该解析失败", bad)
+		}
+	}
+	// 字符串里的逗号和括号不参与切分
+	args, err := parseArgs(`s = "(,]"`, []string{"s"})
+	if err != nil || string(args[0]) != `"(,]"` {
+		t.Fatal(args, err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	cases := []struct{ id, input, want string }{
+		{"1", `nums = [2,7,11,15], target = 9`, `[0,1]`},
+		{"20", `s = "()[]{}"`, `true`},
+		{"20", `s = "(]"`, `false`},
+		{"42", `height = [0,1,0,2,1,0,1,3,2,1,2,1]`, `6`},
+		{"42", `[4,2,0,3,2,5]`, `9`},
+		{"70", `n = 3`, `3`},
+		{"121", `prices = [7,1,5,3,6,4]`, `5`},
+		{"136", `nums = [4,1,2,1,2]`, `4`},
+		{"739", `temperatures = [73,74,75,71,69,72,76,73]`, `[1,1,4,2,1,1,0,0]`},
+	}
+	for _, c := range cases {
+		ret := Run(c.id, c.input)
+		if ret.Error != "" || string(ret.Output) != c.want {
+			t.Fatalf("%s(%s) = %s %s, 期望 %s", c.id, c.input, ret.Output, ret.Error, c.want)
+		}
+	}
+	if ret := Run("42", "[1,2,3]"); ret.Trace == nil || len(ret.Trace.Events) == 0 {
+		t.Fatal("42 应该带执行轨迹")
+	}
+	if ret := Run("739", "[73,74,75]"); ret.Trace == nil || ret.Trace.QuestionID != "739" || string(ret.Output) != "[1,1,0]" {
+		t.Fatalf("739 应该带执行轨迹: %+v", ret)
+	}
+}
+
+func TestRunError(t *testing.T) {
+	for _, c := range []struct{ id, input string }{
+		{"9999", `[1]`},
+		{"1", `nums = [1,2]`},
+		{"1", `nums = "x", target = 1`},
+		{"70", `n = 100`},
+	} {
+		ret := Run(c.id, c.input)
+		if ret.Error == "" {
+			t.Fatalf("%s(%s) 应该报错", c.id, c.input)
+		}
+		b, _ := json.Marshal(ret)
+		var back Result
+		if err := json.Unmarshal(b, &back); err != nil || back.Error != ret.Error {
+			t.Fatal(string(b))
+		}
+	}
+}
+
+// TestSolutionsDocumented main_js.go 里写明了能跑哪几道题，注册的题变了要跟着改
+func TestSolutionsDocumented(t *testing.T) {
+	b, err := os.ReadFile("main_js.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := slices.SortedFunc(maps.Keys(solutions), func(a, b string) int {
+		x, _ := strconv.Atoi(a)
+		y, _ := strconv.Atoi(b)
+		return x - y
+	})
+	want := fmt.Sprintf("注册的 %d 道题：%s，", len(ids), strings.Join(ids, "、"))
+	if !strings.Contains(string(b), want) {
+		t.Fatalf("main_js.go 里应该写 %q", want)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomGrid(r *rand.Rand, m, n, land int) [][]byte {
+	grid := make([][]byte, m)
+	for i := range grid {
+		grid[i] = make([]byte, n)
+		for j := range grid[i] {
+			grid[i][j] = '0'
+			if r.Intn(10) < land {
+				grid[i][j] = '1'
+			}
+		}
+	}
+	return grid
+}
+
+func copyGrid(grid [][]byte) [][]byte {
+	ret := make([][]byte, len(grid))
+	for i := range grid {
+		ret[i] = append([]byte(nil), grid[i]...)
+	}
+	return ret
+}
+
+func TestNumIslandsDsu(t *testing.T) {
+	grid := [][]byte{
+		[]byte("11000"),
+		[]byte("11000"),
+		[]byte("00100"),
+		[]byte("00011"),
+	}
+	if got := numIslandsDsu(grid); got != 3 {
+		t.Fatal(got)
+	}
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 300; i++ {
+		grid := randomGrid(r, 1+r.Intn(15), 1+r.Intn(15), r.Intn(10))
+		if a, b := numIslandsDsu(grid), numIslands(copyGrid(grid)); a != b {
+			t.Fatalf("%q: %d != %d", grid, a, b)
+		}
+	}
+}
+
+// go test -bench . -benchmem *.go
+func BenchmarkNumIslands(b *testing.B) {
+	grid := randomGrid(rand.New(rand.NewSource(1)), 300, 300, 6)
+	for _, impl := range []struct {
+		name string
+		f    func([][]byte) int
+	}{{"dfs", numIslands}, {"dsu", numIslandsDsu}} {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				g := copyGrid(grid)
+				b.StartTimer()
+				impl.f(g)
+			}
+		})
+	}
+}
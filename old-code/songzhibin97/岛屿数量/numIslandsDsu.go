@@ -0,0 +1,28 @@
+package main
+
+//go:generate go run ../../shubo/copygen(拷贝生成)/main.go -pkg main -- ../../shubo/dsu(并查集)/dsu.go
+
+// 并查集解法：陆地和右边、下边的陆地合并，集合个数减去水的格子数，不改 grid
+func numIslandsDsu(grid [][]byte) int {
+	if len(grid) == 0 {
+		return 0
+	}
+	m, n := len(grid), len(grid[0])
+	d := New(m * n)
+	water := 0
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if grid[i][j] != '1' {
+				water++
+				continue
+			}
+			if i+1 < m && grid[i+1][j] == '1' {
+				d.Union(i*n+j, (i+1)*n+j)
+			}
+			if j+1 < n && grid[i][j+1] == '1' {
+				d.Union(i*n+j, i*n+j+1)
+			}
+		}
+	}
+	return d.Count() - water
+}
@@ -2,6 +2,8 @@ package main
 
 import "fmt"
 
+//go:generate go run ../../shubo/copygen(拷贝生成)/main.go -pkg main -- ../../shubo/queue(环形队列)/ring.go
+
 func canFinish(numCourses int, prerequisites [][]int) bool {
 	// 建立出边数组
 	graph := make([][]int, numCourses)
@@ -11,20 +13,19 @@ func canFinish(numCourses int, prerequisites [][]int) bool {
 		count[prerequisite[1]]++
 	}
 	res := 0
-	queue := []int{}
+	queue := NewQueue[int](numCourses)
 	for i, v := range count {
 		if v == 0 {
-			queue = append(queue, i)
+			queue.Push(i)
 			res++
 		}
 	}
-	for len(queue) != 0 {
-		q := queue[0]
-		queue = queue[1:]
+	for queue.Len() != 0 {
+		q := queue.Pop()
 		for _, v := range graph[q] {
 			count[v]--
 			if count[v] == 0 {
-				queue = append(queue, v)
+				queue.Push(v)
 				res++
 			}
 		}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// canFinishSlice 换成环形队列之前的 canFinish，原样保留，和现在的版本对拍、比分配
+func canFinishSlice(numCourses int, prerequisites [][]int) bool {
+	// 建立出边数组
+	graph := make([][]int, numCourses)
+	count := make([]int, numCourses)
+	for _, prerequisite := range prerequisites {
+		graph[prerequisite[0]] = append(graph[prerequisite[0]], prerequisite[1])
+		count[prerequisite[1]]++
+	}
+	res := 0
+	queue := []int{}
+	for i, v := range count {
+		if v == 0 {
+			queue = append(queue, i)
+			res++
+		}
+	}
+	for len(queue) != 0 {
+		q := queue[0]
+		queue = queue[1:]
+		for _, v := range graph[q] {
+			count[v]--
+			if count[v] == 0 {
+				queue = append(queue, v)
+				res++
+			}
+		}
+	}
+	return res == numCourses
+}
+
+// randomCourses n 门课，课号大的依赖课号小的，一定无环；cycle 为 true 时加一条回边
+func randomCourses(r *rand.Rand, n int, cycle bool) [][]int {
+	var pre [][]int
+	for i := 1; i < n; i++ {
+		for k := 0; k < 2; k++ {
+			pre = append(pre, []int{i, r.Intn(i)})
+		}
+	}
+	if cycle && n > 1 {
+		pre = append(pre, []int{0, n - 1})
+	}
+	return pre
+}
+
+func TestCanFinish(t *testing.T) {
+	if !canFinish(5, [][]int{{1, 4}, {2, 4}, {3, 1}, {3, 2}}) || canFinish(2, [][]int{{1, 0}, {0, 1}}) {
+		t.Fatal("示例")
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		n := 1 + r.Intn(50)
+		cycle := i%2 == 0 && n > 1
+		pre := randomCourses(r, n, cycle)
+		if a, b := canFinish(n, pre), canFinishSlice(n, pre); a != b || a == cycle {
+			t.Fatalf("n=%d: %v %v", n, a, b)
+		}
+	}
+}
+
+// 10^5 门课，-benchmem 看分配次数和字节数
+// go test -bench . -benchmem *.go
+func BenchmarkCanFinish(b *testing.B) {
+	const n = 100000
+	pre := randomCourses(rand.New(rand.NewSource(1)), n, false)
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			canFinishSlice(n, pre)
+		}
+	})
+	b.Run("ring", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			canFinish(n, pre)
+		}
+	})
+}
@@ -97,6 +97,30 @@ start_service() {
     npm start
 }
 
+# 启动本地判题服务（可选，需要安装 Go），React 页面开发时通过 http://127.0.0.1:8100 调用
+start_judge() {
+    if ! command -v go >/dev/null 2>&1; then
+        log_warn "未安装 Go，跳过本地判题服务"
+        return 0
+    fi
+    # 8100 上可能是别的服务，不像 3000 端口那样直接杀掉，被占用时不启动
+    if lsof -Pi :8100 -sTCP:LISTEN -t >/dev/null 2>&1 ; then
+        log_warn "端口 8100 被占用，跳过本地判题服务"
+        return 0
+    fi
+    JUDGE_DIR="old-code/shubo/judge(本地判题服务)"
+    JUDGE_BIN="${TMPDIR:-/tmp}/hot100-judge"
+    log_info "编译本地判题服务..."
+    (cd "$JUDGE_DIR" && go build -o "$JUDGE_BIN" $(ls *.go | grep -v _test))
+    if [ $? -ne 0 ]; then
+        log_warn "本地判题服务编译失败，跳过"
+        return 0
+    fi
+    (cd "$JUDGE_DIR" && exec "$JUDGE_BIN" -addr 127.0.0.1:8100) &
+    JUDGE_PID=$!
+    log_info "本地判题服务已启动: http://127.0.0.1:8100/problems"
+}
+
 # 主函数
 main() {
     echo ""
@@ -115,12 +139,25 @@ main() {
     # 2. 检查端口
     check_port 3000
     
-    # 3. 启动服务
+    # 3. 启动本地判题服务
+    echo ""
+    start_judge
+
+    # 4. 启动服务
     echo ""
     start_service
 }
 
-# 捕获 Ctrl+C 信号
+# 停止本地判题服务，脚本不管怎么退出都要执行
+stop_judge() {
+    if [ -n "$JUDGE_PID" ]; then
+        kill $JUDGE_PID 2>/dev/null
+        JUDGE_PID=""
+    fi
+}
+trap stop_judge EXIT
+
+# 捕获 Ctrl+C 信号，exit 之后由 EXIT 的 trap 停止判题服务
 trap 'log_warn "收到中断信号，正在停止服务..."; exit 0' INT TERM
 
 # 执行主函数